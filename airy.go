@@ -0,0 +1,313 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// AiryAi returns a big.Float representation of the Airy function of
+// the first kind Ai(x). Precision is the same as the one of x. The
+// function returns 0 when x = ±Inf.
+func AiryAi(x *big.Float) *big.Float {
+	return airySpecial(x, false, false, "AiryAi")
+}
+
+// AiryBi returns a big.Float representation of the Airy function of
+// the second kind Bi(x). Precision is the same as the one of x. The
+// function returns +Inf when x = +Inf and 0 when x = -Inf.
+func AiryBi(x *big.Float) *big.Float {
+	return airySpecial(x, true, false, "AiryBi")
+}
+
+// AiryAiPrime returns a big.Float representation of Ai'(x), the
+// derivative of the Airy function of the first kind. Precision is
+// the same as the one of x. The function returns 0 when x = +Inf,
+// and panics if x = -Inf.
+func AiryAiPrime(x *big.Float) *big.Float {
+	return airySpecial(x, false, true, "AiryAiPrime")
+}
+
+// AiryBiPrime returns a big.Float representation of Bi'(x), the
+// derivative of the Airy function of the second kind. Precision is
+// the same as the one of x. The function returns +Inf when x = +Inf,
+// and panics if x = -Inf.
+func AiryBiPrime(x *big.Float) *big.Float {
+	return airySpecial(x, true, true, "AiryBiPrime")
+}
+
+// airySpecial returns Bi(x) if bi is true and Ai(x) otherwise, or
+// their derivatives if deriv is true, with the precision of x, using
+// fname in the panic messages.
+func airySpecial(x *big.Float, bi, deriv bool, fname string) *big.Float {
+
+	prec := x.Prec()
+
+	if x.IsInf() {
+		switch {
+		// Bi(+Inf) = Bi'(+Inf) = +Inf
+		case x.Sign() > 0 && bi:
+			return new(big.Float).SetPrec(prec).SetInf(false)
+
+		// Ai'(-Inf) and Bi'(-Inf) oscillate with growing amplitude
+		case x.Sign() < 0 && deriv:
+			panic(fname + ": argument is -Inf")
+		}
+
+		// Ai(±Inf) = Bi(-Inf) = Ai'(+Inf) = 0
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return airy(x, bi, deriv, prec+64).SetPrec(prec)
+}
+
+// airy returns Bi(x) if bi is true and Ai(x) otherwise, or their
+// derivatives if deriv is true, for finite x, computed to prec bits
+// of precision.
+func airy(x *big.Float, bi, deriv bool, prec uint) *big.Float {
+
+	// The asymptotic expansions are in ζ = (2/3)|x|^(3/2), and their
+	// smallest term is about exp(-2ζ).
+	xf, _ := x.Float64()
+	zf := 2 * math.Pow(math.Abs(xf), 1.5) / 3
+
+	// The series for Ai(x), x > 0, has terms of about exp(ζ) and a
+	// result of about exp(-ζ), which costs about 2ζ·log2(e) bits,
+	// while for x < 0 both functions oscillate and the sums cancel
+	// close to their zeros.
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		var r *big.Float
+		var scale int
+		if 2*zf > float64(wprec)*math.Ln2+math.Log(float64(wprec))+4 {
+			r, scale = airyAsymptotic(x, bi, deriv, wprec)
+
+			// For large x > 0, exp(∓ζ) underflows to 0 or
+			// overflows to ±Inf.
+			if r.Sign() == 0 || r.IsInf() {
+				return r.SetPrec(prec)
+			}
+		} else {
+			r, scale = airySeries(x, bi, deriv, wprec)
+		}
+
+		if lost := lostBits(r, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec)
+	}
+}
+
+// airySeries returns Bi(x) if bi is true and Ai(x) otherwise, or
+// their derivatives if deriv is true, computed to prec bits of
+// precision using their power series; and the binary exponent of the
+// largest term.
+func airySeries(x *big.Float, bi, deriv bool, prec uint) (*big.Float, int) {
+
+	// Ai(x) = c₁f(x) - c₂g(x)
+	// Bi(x) = √3(c₁f(x) + c₂g(x))
+	// where c₁ = 3^(-2/3)/Γ(2/3), c₂ = 3^(-1/3)/Γ(1/3) and
+	//     f(x) = 1 + x³/(2·3) + x⁶/(2·3·5·6) + ...
+	//     g(x) = x + x⁴/(3·4) + x⁷/(3·4·6·7) + ...
+	// and the same for the derivatives, with f' and g'.
+	x3 := new(big.Float).SetPrec(prec).Mul(x, x)
+	x3.Mul(x3, x)
+
+	var f, g *big.Float
+	var sf, sg int
+	one := big.NewFloat(1)
+	if !deriv {
+		f, sf = airySum(x3, one, -1, 0, prec)
+		g, sg = airySum(x3, x, 0, 1, prec)
+	} else {
+		t := new(big.Float).SetPrec(prec).Mul(x, x)
+		f, sf = airySum(x3, t.SetMantExp(t, -1), 0, 2, prec)
+		g, sg = airySum(x3, one, -2, 0, prec)
+	}
+
+	// 3^(1/3), 3^(-1/3)/Γ(1/3) and 3^(-2/3)/Γ(2/3)
+	c := Pow(big.NewFloat(3).SetPrec(prec), new(big.Float).SetPrec(prec).Quo(one, big.NewFloat(3)))
+	c2 := Gamma(new(big.Float).SetPrec(prec).Quo(one, big.NewFloat(3)))
+	c2.Quo(one, c2.Mul(c2, c))
+	c1 := Gamma(new(big.Float).SetPrec(prec).Quo(big.NewFloat(2), big.NewFloat(3)))
+	c1.Quo(one, c1.Mul(c1, c).Mul(c1, c))
+
+	f.Mul(f, c1)
+	g.Mul(g, c2)
+	sf += c1.MantExp(nil)
+	sg += c2.MantExp(nil)
+	if sg > sf {
+		sf = sg
+	}
+
+	if !bi {
+		return f.Sub(f, g), sf
+	}
+	f.Add(f, g)
+	return f.Mul(f, new(big.Float).SetPrec(prec).Sqrt(big.NewFloat(3))), sf + 1
+}
+
+// airySum returns Σ t_k, for k >= 0, with
+//
+//	t_k = t_(k-1)·x³/((3k+a)(3k+b))
+//
+// and t_0 = t0, computed to prec bits of precision, and the binary
+// exponent of its largest term.
+func airySum(x3, t0 *big.Float, a, b int64, prec uint) (*big.Float, int) {
+
+	t := new(big.Float).SetPrec(prec).Set(t0)
+	s := new(big.Float).SetPrec(prec).Set(t)
+	if t.Sign() == 0 {
+		return s, math.MinInt32
+	}
+
+	// the terms decrease when (3k)² > |x³|
+	xf, _ := new(big.Float).Abs(x3).Float64()
+	d := new(big.Float)
+	scale := t.MantExp(nil)
+	for k := int64(1); ; k++ {
+		t.Mul(t, x3)
+		t.Quo(t, d.SetInt64((3*k+a)*(3*k+b)))
+		s.Add(s, t)
+		if e := t.MantExp(nil); e > scale {
+			scale = e
+		}
+		if t.Sign() == 0 || float64(9*k*k) > xf && t.MantExp(nil)-s.MantExp(nil) < -int(prec) {
+			return s, scale
+		}
+	}
+}
+
+// airyAsymptotic returns Bi(x) if bi is true and Ai(x) otherwise, or
+// their derivatives if deriv is true, for large |x|, computed to
+// prec bits of precision using their asymptotic expansions; and the
+// binary exponent of the largest term.
+func airyAsymptotic(x *big.Float, bi, deriv bool, prec uint) (*big.Float, int) {
+
+	// The absolute error on ζ = (2/3)|x|^(3/2) becomes a relative
+	// error in exp(±ζ) and in the sin and cos of ζ - π/4, so we need
+	// as many extra bits as its exponent.
+	eprec := prec
+	if e := x.MantExp(nil); e > 0 {
+		eprec += uint(3*e/2 + 1)
+	}
+	z := new(big.Float).SetPrec(eprec).Abs(x)
+	zeta := new(big.Float).SetPrec(eprec).Sqrt(z)
+	q := new(big.Float).SetPrec(prec).Sqrt(zeta) // |x|^(1/4)
+	zeta.Mul(zeta, z)
+	zeta.Mul(zeta, big.NewFloat(2))
+	zeta.Quo(zeta, big.NewFloat(3))
+
+	// With u_k = Γ(3k+1/2)/(54^k k! Γ(k+1/2)), v_k = -(6k+1)/(6k-1)·u_k
+	// and w_k = u_k (or v_k for the derivatives), the functions are
+	// given in terms of
+	//     E = Σ σ^k w_(2k)/ζ^(2k),  O = Σ σ^k w_(2k+1)/ζ^(2k+1)
+	// where σ = 1 for x > 0 and σ = -1 for x < 0.
+	even, odd := airyAsymSums(zeta, deriv, x.Sign() < 0, prec)
+	sp := pi(prec)
+	sp.Sqrt(sp)
+
+	// The factor |x|^(-1/4), or |x|^(1/4) for the derivatives, over √π
+	fac := new(big.Float).SetPrec(prec)
+	if deriv {
+		fac.Quo(q, sp)
+	} else {
+		fac.Quo(fac.SetInt64(1), q)
+		fac.Quo(fac, sp)
+	}
+
+	if x.Sign() > 0 {
+		// Ai(x)  ~ exp(-ζ)/(2√π x^(1/4)) · (E - O)
+		// Ai'(x) ~ -x^(1/4) exp(-ζ)/(2√π) · (E - O)
+		// Bi(x)  ~ exp(ζ)/(√π x^(1/4)) · (E + O)
+		// Bi'(x) ~ x^(1/4) exp(ζ)/√π · (E + O)
+		r := new(big.Float).SetPrec(prec)
+		if bi {
+			r.Add(even, odd)
+			r.Mul(r, Exp(zeta))
+		} else {
+			r.Sub(even, odd)
+			r.Mul(r, Exp(zeta.Neg(zeta)))
+			r.SetMantExp(r, -1)
+			if deriv {
+				r.Neg(r)
+			}
+		}
+		r.Mul(r, fac)
+		return r, r.MantExp(nil)
+	}
+
+	// With φ = ζ - π/4,
+	//     Ai(x)  ~ (cos(φ)E + sin(φ)O)/(√π |x|^(1/4))
+	//     Bi(x)  ~ (cos(φ)O - sin(φ)E)/(√π |x|^(1/4))
+	//     Ai'(x) ~ |x|^(1/4)/√π · (sin(φ)E - cos(φ)O)
+	//     Bi'(x) ~ |x|^(1/4)/√π · (cos(φ)E + sin(φ)O)
+	p := pi(eprec)
+	zeta.Sub(zeta, p.SetMantExp(p, -2))
+	sin, cos := sincos(zeta)
+
+	var a, b *big.Float
+	switch {
+	case !bi && !deriv:
+		a, b = even.Mul(even, cos), odd.Mul(odd, sin)
+	case bi && !deriv:
+		a, b = odd.Mul(odd, cos), even.Mul(even, sin.Neg(sin))
+	case !bi && deriv:
+		a, b = even.Mul(even, sin), odd.Mul(odd, cos.Neg(cos))
+	default:
+		a, b = even.Mul(even, cos), odd.Mul(odd, sin)
+	}
+	a.Mul(a, fac)
+	b.Mul(b, fac)
+	scale := a.MantExp(nil)
+	if e := b.MantExp(nil); e > scale {
+		scale = e
+	}
+	return a.Add(a, b), scale
+}
+
+// airyAsymSums returns the sums
+//
+//	Σ σ^k w_(2k)/ζ^(2k),  Σ σ^k w_(2k+1)/ζ^(2k+1)
+//
+// for k >= 0, computed to prec bits of precision, where σ = -1 if
+// neg is true and 1 otherwise, and w_k is u_k, or v_k if deriv is
+// true, the coefficients of the asymptotic expansions of the Airy
+// functions. The sums are stopped when the terms are less than
+// 2**(-prec).
+func airyAsymSums(zeta *big.Float, deriv, neg bool, prec uint) (*big.Float, *big.Float) {
+
+	// u_k = u_(k-1)·(6k-5)(6k-3)(6k-1)/(216k(2k-1))
+	// v_k = -u_k·(6k+1)/(6k-1)
+	even := big.NewFloat(1).SetPrec(prec)
+	odd := new(big.Float).SetPrec(prec)
+	u := big.NewFloat(1).SetPrec(prec) // u_k/ζ^k
+	t := new(big.Float).SetPrec(prec)
+	d := new(big.Float)
+	for k := int64(1); ; k++ {
+		u.Mul(u, d.SetInt64((6*k-5)*(6*k-3)))
+		u.Mul(u, d.SetInt64(6*k-1))
+		u.Quo(u, d.SetInt64(216*k*(2*k-1)))
+		u.Quo(u, zeta)
+
+		t.Set(u)
+		if deriv {
+			t.Mul(t, d.SetInt64(-(6*k + 1)))
+			t.Quo(t, d.SetInt64(6*k-1))
+		}
+		if neg && k%4 >= 2 {
+			t.Neg(t)
+		}
+		if k%2 == 0 {
+			even.Add(even, t)
+		} else {
+			odd.Add(odd, t)
+		}
+
+		if t.MantExp(nil) < -int(prec) {
+			return even, odd
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestAiryAi(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0", "3.55028053887817239260063186004183176397979174199177240583326510300810042450126712957174246054040271688420448730349495839758292670446161937105040240022585386384009902601035712819051568203290249169644766182327967770241898959479617348908640625732389760141764005678039738773380486317610875452025323349222388969631079767781701840765135209937041315573391533132662876e-1"},
+		{"1", "1.35292416312881415524147423515466306174944142988330706009102054757633534802265723663487109908748683213053258593353270716841239700126950651287562998619013509302938500031237666731142340271955124155227955742071671242249296046280497488764831586417431025864061681830443779293594309577038000388735095988755800208229646353963046452680217054487144073220996842757712737e-1"},
+		{"-1", "5.35560883292352118799516565638874707466930897683617002770631517205436056864481735329276745984825981447391155248566555756296118587986963150878468438907211986631180952085072949073366826050161122595987766522731470255740496646694399949980797902622655021033397358486477866746422445951793310038586117146938189729193567650629099050314342708813860791683873164858754053e-1"},
+		{"0.375", "2.60669573173895278714359054693791918977927189779100268982704997009791083108593228509191955035355339355741496159492951804492798470329687200674663402585743045665023858269438545096379917702005122046779234094239922115422612325452128236045669157797016892780264760140115124258245804671919817371441054721984661189950619632747494468045270962608614360880015392489070485e-1"},
+		{"-2.25", "6.15986587770052775171764237625762831886589535207986079515978822614563775559267872339786398241797404390875961758570368299120102908438811380051725349459698162316299952921447629968216715197837885044792606729874577806774919815598730144226064583533211407042780412311855100725241828105263291741951791895549291044016487697675612215607004407780164335549918302533746487e-2"},
+		{"5", "1.08344428136074417349865025033459804795777834796889391335129425161977170922390606020786785712816105418581055243024238201719439874330627562341380419277048552245374383018504011332463254278370909709270585080072763135998347386538276199574577522390084028774577173464752513548197604643623288609774528919863450620099696036692529370330309162647626891967715618238670380e-4"},
+		{"-10", "4.02412384864431906894303140299345901017407164121577838351546163680001794174570741396842264990656469853868392904582455142029571827948604158204516238125661519418138135689327263060633087817657912629100701385462972689837682442459862125041944562497031703413446765429987465482902817978044345386085245371055193765633376005569441451967780766503675414234602032940770898e-2"},
+		{"12.5", "2.39682782607804993628166893941142005194803061701938421867981227839080035634093461884953069362554991659434133089518892122333242980486170183922192809571545239548649033205462722412479316738425450306771103167349483143636403060943761117135815597012714980165053192661858452667801936745239478734865143922387926542129448911406274878526258342525378199446719059618399891e-14"},
+		{"-30.75", "2.34553084258644752981012060925251502524828648117016827544172747822048221772473949246181585592867089432461892695127260719176500716126783847722572725690565729183694021954103474293744705935609414581154185041098096441932825462353433419299307951104879312122906284457553896034565420316986802087992974980392148924972971552317820775535349758832270453070177666963130096e-1"},
+		{"1p-60", "3.55028053887817239035573138105331866833307814659470647978999589542793044728421547381561158301364524772754715136509886412837144726260125551367032789917045064177680375019583564763746559469527031976127592977538389494913536273404708136407843353160170105313598426783313984638896914408411125787684257350312712870549601751010567379695649298007232878850632578449024525e-1"},
+		{"100.5", "1.76185267280118506562528786728989462692787100362601157975936166614549642582990563108704115565181564306333951547910195656590925532607479611953563613046750611767468293359306094374610684998204310710377242326117070808590903796127823659054612579330878207290671703294712658170794777353924892091468272472702868758864508225130673264592125116112429081596712184360142601e-293"},
+		{"-100.5", "2.79276452779873518006577038373065199709181769960980207514801799452283416423779933005299247638376535057676841227160284840136310792488522083076013417970163515843371651628210410118172184639431516086327713676392951981157255741285309148744234629373713735598694166816738102706953598927810195782730801610005996253903625065373198734588311738865092769839766451530500140e-2"},
+		{"-1000", "5.59718957730199188421918267561344747037797890571224644341472483804758670376690794720072420946873814583942490564967646245130682198170540525008102587941594562506077404641840049597340609033483271678993614474758200470091328083062313558136570563848911876341988449186106612520769719922235288624029667779198219041229639080337839466370870667320213649666866335811236340e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.AiryAi(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, AiryAi(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestAiryBi(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0", "6.14926627446000735150922369093613553594728188648596505040878753014296519305520640529387343345267569240728438782242516724523554227287639109737188726926734554738700046981716190274409246985203176695638338117150781553622377026236423898256352735534442640825378002296473380715200578588114951420385071229984978083124234562383041582551747500876875991840180019761542383e-1"},
+		{"1", "1.20742359495287125943637881702828699538534894464444253753862097168161734902655048311247282365030529443267772785441862319241382395084640903200573389664240685952548360234008623800123541161336933535660891059661840828536998816008648215283540189434448610346594026849854623741288735830087730509406228840628300402228701687292587042887039059776375572216008907977811956e+0"},
+		{"-1", "1.03997389496944611888689990978599144637018111392523972435256973234638158378160757223705800053532842190946454596739104086890241484000181534498264303826042658419027559079264671884734707201211673524462293109887084574899558631439193316306146978488209993206476200809216177608007685570234481936197590379571607600934049591970376454498468755297662010438782216859393754e-1"},
+		{"0.375", "7.89188581859933271219503897359718390968046618438672800984392434521035177559366795489631269587870052804105130864330767163915022711606030441591297496798887562519220537340364360535267538383430683999159388631131518927021415273252262678147014774757508053109641993133042430579408098531300925830759921020707065818984127083469441075926439481402702865795921590337331820e-1"},
+		{"-2.25", "-4.53920686750117307045535215441767006272719971989846263701528916330500672464892848696801510054272058831990242266829232825658126378487967726680504605304989462372381279049009229092649884690556776503527306040184023197469407546369690283960523608992514519196569051659675867116025204620452002227626746930421806197652212951222248774787830946507068317840296393695114700e-1"},
+		{"5", "6.57792044171171182441080578874443878556312406329286857087363253630442561372760555337260696521481821157052336769208317723915014295957693823162589920631263391374567283983333346797645507130267441226064700463690341110546519310838596917161966970708614308378066058307759449032597633071868760880097227127020337173521770162916985093603335563766079633868575942118251992e+2"},
+		{"-10", "-3.14679829643838633161754211502315695529515727473277098495991966601933768575225745004977477618403317096889879392895965815658556966167718637162183999991421610584416588249202085561469913887910102037447674459361596179560201596637844714673316768985632132110035671203162144527613047361188884221290678519176928541716917796887012401390427946640880591133277422045160369e-1"},
+		{"12.5", "1.87829193562205186740249309218021906003324645956966453601112575985866691032858072287727678105921301080956550417165478960592581555797158203838154037294256018909155499457760702032343325487854709630777319497003989290684407036934429435573533197690761916673094091498767870289123808206746154285564372721099036116792393270200705588456756566918322480606056029248527078e+12"},
+		{"-30.75", "4.88529834381247615570388389742582717248755289369288793489858463284337892590483845472186852703483307471267318483247997480864941711498330195337413979261014146703205006067902258155161285605458467305150887816774599426240348503086964632789011042320709262080877897445723873078705336612608590020505151348812604639121510003844351670791033996808071457094504967238832289e-2"},
+		{"1p-60", "6.14926627446000735539750537847994909571769360892514882106394071234443418441121105299981711546033628956363873034279172702155974778310960447615968074606805451040350842204332629486439507585352278201484027018336047740447811224325355476671965685674839897354627944354374952252020585148228283833810032121602627833997512830184291965944731306433364992098459440740026518e-1"},
+		{"100.5", "9.01088771555125490427792373383244459170281272928132375314568947986137483878465706712552462733295462879059159741215173480221666764206476728125032519746959888635352617898263499302137593179901348069781925159983430140855840573551592543455729862783510618302476826874935608075257986519974876353281962925649728889180069247026305662625529453545297020697757255691892131e+290"},
+		{"-100.5", "1.75987925909957888888629786024253331644706099167853169099165094644924917321328432288472859091174352684275894044213638804087628172907621768806855508029442211964067859131941931383874586108365003118458531047876144312868197422528805286332495460043539703825504890125870682413157446317774099850656821723717947568986274641157142354536213950322948900288052035697472859e-1"},
+		{"-1000", "-8.32645741170806330114775631901771476548636870585959585757570939377567827563950633113695686880012210505436898313675568980462134754891080642816683003234090504419118356560100687249821483708831009778668829875662121185882444863519939928075263608589194006342574408663781091454839026968735611931023374477078509611556740297720065863487449624359495374666828150685413973e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.AiryBi(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, AiryBi(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestAiryAiPrime(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0", "-2.58819403792806798405183560189203963479091138354934582210001813856102772676790280654196405827275384313371193211789133381275035952167626014785050989848419446632029644888805601878383305126950525128293342497999883570749079259060158951050944322089384059673577719328025106650170175689836578607525583113547817131862044958292721332591326821052031400011829967224451661e-1"},
+		{"1", "-1.59147441296793212787500252497229686573889201511610969415199521489173383468002119188636155820532073337891699667486343304387538506144584126795425529186937806168389050249733094167296944628740470894659681238074121723136351303352035537223875194504856231113264905484240601012297140306687134156426417609986782154818291620123708804962014378464879920785578199930154372e-1"},
+		{"-1", "-1.01605671166452093950454698453575618418903954666706641053999297228918190667232439732008154601809865966823435009262084676859857871821573875022680592506209644348649233378677004128560177677742815376646944765510901547712451201889884127997352941282103642775961106726269821708587813664727612070978078323214969231347396931204699357444577962475904495656844242993585294e-2"},
+		{"0.375", "-2.38328201850655207876023406515190652143343486033239311259611205384018743870561749479345749645586721913902549588561698427228728838076076302084192496533974152819518524372732260259003305257770855315040660858807309577826191046476719564068294897683004901467312728900804697288802213991945371435165313440699302445686320976844812238570202605626715484910038220578335838e-1"},
+		{"-2.25", "6.95016206701528655939463384014331132159720464002002403196499033178827222244085950888929079963701599558165999968285764695854117797488735759602010448238216626362602263595584126790563372577450953754464026460524001269470958721834800002865504001853429947829138837718326347508573897007312709702020127004681916667835447321002539328229450328034523058997390702692558427e-1"},
+		{"5", "-2.47413890868462476000236172063050605655833965380477199248578987367100862753141376139709111161652061234556265078851975889090277460207949291338961601865183926592886341886948776555653104839975481598675019684194697530332546214994145431974742347869975407695245294485151856155584994511012927269094681537785569314180167274017780766610090524670397767866140930198832108e-4"},
+		{"-10", "9.96265044132790055904572541288909658904840391437421312640058691412460295875058131809944045028077262854432237688153723983432590004348724162487820988196029974422318039433424801531738486979123528661373486053495207547703178662763785840435927291866097269729637845332057877227685855438329405433739031370549309855213037191236372877381766809501002779105444683489901991e-1"},
+		{"12.5", "-8.52134656467385644529697724955264951308572185356872237534191995926564793368705974703016515139367678745855324903091885144118228143156486110509468350475641318772280267125168106167547750732967013963891511153260599021219611870106183233496649915202788087860828470189611374269626426148832029250869396439709449250083342047289761593468292470778520890741973086049260654e-14"},
+		{"-30.75", "-2.68997479575643002281327028442914874516922619484029359870382173363751326998322965952187585103147696616375294161879314948531537578157478602778779259879992597586961132044754380219826233057717734030450229659173092056804829931942076695386848895050413590045076403019697576437840352041331463538025047402026106529161546193799627756420347122748094001960756826761415491e-1"},
+		{"1p-60", "-2.58819403792806798405183560189203963345544427401795435938871741346305366977344497360473788902123727888002190072101425215544893896949959182234605107654963504032794455824842064100851031473032235133301046318935404838371368753428769688267972986026508783024755984304867126418503983117548742124456652935763762068051713861367064454638221552589724761049303237685276210e-1"},
+		{"100.5", "-1.76668981274995859503473705861200577286542584668497060840538895929178709398456982055522844323804300316929268167751464415987791642046770488041670810322053121822486597215541162020297609197021240973722237970012669548301091631152026032537442247186956829328366758819703066722773335326948194017433677482143875770353176373132760202910883943143459191846639870046927969e-292"},
+		{"-100.5", "-1.76420427121342133744079286686073157437963675257350748373679602122589567906754233661398896904497643805005293771509032457647493120065261882709732596900019242775074440680774746833711237590805161942267353477386550409604794352431115898485391228300550182302429630356997631113440935857431947206904345490101419210048478508993031974158053136616563996979870155528265135e+0"},
+		{"-1000", "2.63307101952412873107885254705118560884980307431596942700217420598986433727302749825268933060087520867666102223860484592804093652567707797862026487576056233287634708603149419943085471974914953920895357922797075326575897835198046973939466136276096687470690678320978367413350818274520800543724333756700723748790706870452090840287648452292749976468639015588438039e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.AiryAiPrime(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, AiryAiPrime(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestAiryBiPrime(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0", "4.48288357353826357914823710398828390866226799212262061082808778372330755009780647185046574400736362878496329249031699773802889479552819613842015273070467162789410574115183712495214393556450290323553936005496778185924799912870305470719196885430733196376765678985138935448064849160993302135556364494686696643297023256068694250478489892307963540817309377635669146e-1"},
+		{"1", "9.32435933392775632959451453674435344269565375238628395492869912038570105321235991416513716628312373338348403390032830602779894923473627225687069976399572192455274492838779925034688097358038893495753430506126082680732348835159132132492088781781386833890066656636299511635357741699350264026109425432011946512313195155639942354066259072739605282957684007446652249e-1"},
+		{"-1", "5.92375626422792350816779229181600973276795883367362905782071993948298797907423340843694898283569694870093061549379263026198037482720929146146987081465917550260741595490074038743410678084006975541011520849004696908723117807304237230828416130080825216144487738263543801252954760370156681913080229233794495987780424140334894740636663959801480145272376901022648992e-1"},
+		{"0.375", "4.99574955996764676714920545034576308829210923800694115135575790619826007713178694901243378633010420207458264034391670992932382646358052468320130361887548765354605149476935661816412518448125214574243485740682337122292588490544559222523835705692598925084426258371893412999950352000597095686518108232914014402386611585200714218006902308533111178946713556124419042e-1"},
+		{"-2.25", "4.59044464849105037456227109055655880394299757927523385936381911631900862107814771891471115319743602845035759414248297101586133968588184534010230552781200876851245741036006511053679760996796078211143307258576378801463833616970616575894041720832225463840499893332271064800308237622246266469994045591001569929032374415763699572975365383999783714430488301780377966e-2"},
+		{"5", "1.43581908021798251867172123800461829826571568337174458865283938883697849850042739795678794436321178183283262311794988443062807353302717308985878478067279606158363762783828973266435192034183087692596343059229958566918442245023144420005272330360949237216838703079344705169406903301649652796748238593871850351675799004619639478900641097235035362682812010361933061e+3"},
+		{"-10", "1.19414113399909238277525336681521824536420239516302632435785588637067095890541724010954330594509900662632965004889416859546209318340512338572425053416592265346108429075317795848072414185378725219661104269491047499109522882052166791019976797423195649218543214029328489103398801741569870652785129857803194457996293626882748994303853662079380558988321297973199207e-1"},
+		{"12.5", "6.60264868136429539057669098975243411048251460092417633022199361818942476068858381269931290173222727121398047421967237098066288672137225906994821478659812762238704809723366573091099561465570415287689977111915636914832022901184536787239788584711212578478189880206070472657263561022667430494818025244077610990864011091607250278541016722708626190397407961663201062e+12"},
+		{"-30.75", "1.30106392646140232486821974560034099002355905146306368818288338644480825387578165875548050117962848342924644973907093499369121538533980693517502155454977856320403994599046169167846529807984773710171193769034821088907560617735568460577610663365785153876330734214076686725459585708655070563755130003833314251136708393796037220065286105125327640140465066719011939e+0"},
+		{"1p-60", "4.48288357353826357914823710398828391097536487766814150246539167506496999436835527557406768033565178083971140459198889255545412267619016092522270830133347189275527590993593024599123170038026375948974026419871356140815790815245439674177837841007947899976525136928895593325351381189224778715176981588501350358582523426670935349988876099938951262431541257798841728e-1"},
+		{"100.5", "9.03114393905763580776747623990533912882725610102464666607397855285008232896118504752578283938700059453238908946948390362845198625802980703931287928417562243922252700165742924775251912960358546273713667258797023395663340390169733810812959846432324229012493630378151857373471237865348408095951160424671366511612668708474130901413870232173576241887080051736150395e+291"},
+		{"-100.5", "2.80411596949937839687608218862755597008884783553459396968831228195445263712784959629408220611905412781586095059484688831393977268632479192453788507269744094462265315224696443607148454372421302768192795263564672667974948848910656291596514468142001988733809868077536572948052358630708883099272340295641973510815085872408140266846279940770501578346359766961226574e-1"},
+		{"-1000", "1.76996594013598897975312004337279603806846673882684758252122415904106267012883323788565091599029328188068375424654341048346210199381850460900438619878289062103632520713516190886216104272370930247365120931947984920419521933190126115416300109512247935543809380457770403024697237812003274146470969930777064140229240218939949979252468304770870982342769969493456374e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.AiryBiPrime(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, AiryBiPrime(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestAiryWronskian(t *testing.T) {
+	// Ai(x)Bi'(x) - Ai'(x)Bi(x) = 1/π
+	const prec = 200
+	pi := bigfloat.Gamma(big.NewFloat(0.5).SetPrec(prec))
+	pi.Mul(pi, pi)
+	for _, x := range []float64{-250.5, -50.5, -7, -1.5, 0, 0.5, 3, 20, 150} {
+		z := big.NewFloat(x).SetPrec(prec)
+		w := new(big.Float).Mul(bigfloat.AiryAi(z), bigfloat.AiryBiPrime(z))
+		w.Sub(w, new(big.Float).Mul(bigfloat.AiryAiPrime(z), bigfloat.AiryBi(z)))
+		w.Mul(w, pi)
+		if d := w.Sub(w, big.NewFloat(1)); d.Sign() != 0 && d.MantExp(nil) > -prec+8 {
+			t.Errorf("Airy Wronskian at x = %v is off by %g", x, d)
+		}
+	}
+}
+
+func TestAiryFloat64(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(*big.Float) *big.Float
+		x    float64
+		want float64
+	}{
+		{"AiryAi", bigfloat.AiryAi, 0, 0.35502805388781724},
+		{"AiryAi", bigfloat.AiryAi, 10, 1.1047532552898687e-10},
+		{"AiryBi", bigfloat.AiryBi, 0, 0.61492662744600074},
+		{"AiryBi", bigfloat.AiryBi, -10, -0.31467982964383865},
+		{"AiryAiPrime", bigfloat.AiryAiPrime, 0, -0.25881940379280680},
+		{"AiryBiPrime", bigfloat.AiryBiPrime, 0, 0.44828835735382636},
+	} {
+		x, _ := test.f(big.NewFloat(test.x)).Float64()
+		if math.Abs(x-test.want) > 1e-15*math.Abs(test.want) {
+			t.Errorf("%s(%g) = %g; want %g", test.name, test.x, x, test.want)
+		}
+	}
+}
+
+func TestAirySpecialValues(t *testing.T) {
+	inf, negInf := big.NewFloat(math.Inf(+1)), big.NewFloat(math.Inf(-1))
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"AiryAi(+Inf)", bigfloat.AiryAi(inf), 0},
+		{"AiryAi(-Inf)", bigfloat.AiryAi(negInf), 0},
+		{"AiryBi(+Inf)", bigfloat.AiryBi(inf), math.Inf(+1)},
+		{"AiryBi(-Inf)", bigfloat.AiryBi(negInf), 0},
+		{"AiryAiPrime(+Inf)", bigfloat.AiryAiPrime(inf), 0},
+		{"AiryBiPrime(+Inf)", bigfloat.AiryBiPrime(inf), math.Inf(+1)},
+
+		// exp(∓(2/3)x^(3/2)) is out of big.Float's exponent range
+		{"AiryAi(1e7)", bigfloat.AiryAi(big.NewFloat(1e7)), 0},
+		{"AiryBi(1e7)", bigfloat.AiryBi(big.NewFloat(1e7)), math.Inf(+1)},
+		{"AiryAiPrime(1e7)", bigfloat.AiryAiPrime(big.NewFloat(1e7)), 0},
+		{"AiryBiPrime(1e7)", bigfloat.AiryBiPrime(big.NewFloat(1e7)), math.Inf(+1)},
+	} {
+		x, _ := test.got.Float64()
+		if x != test.want {
+			t.Errorf("%s = %g; want %g", test.name, x, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkAiryAi(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		x := big.NewFloat(-5.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.AiryAi(x)
+			}
+		})
+	}
+}
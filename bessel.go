@@ -0,0 +1,586 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// BesselJ returns a big.Float representation of Jν(x), the Bessel
+// function of the first kind of order ν. Precision is the same as
+// the one of x. The function returns 0 when x = ±Inf, and panics if
+// ν is infinite, or if x < 0 and ν is not an integer.
+func BesselJ(nu, x *big.Float) *big.Float {
+
+	prec := x.Prec()
+	besselCheck(nu, x, true, "BesselJ")
+
+	// J(-n, x) = (-1)^n·J(n, x) and J(n, -x) = (-1)^n·J(n, x)
+	nu, neg := besselReflect(nu, x, true)
+
+	switch {
+	// J(ν, ±Inf) = 0
+	case x.IsInf():
+		return new(big.Float).SetPrec(prec)
+
+	case x.Sign() == 0:
+		return besselZero(nu, prec)
+	}
+
+	r := besselJY(nu, new(big.Float).Abs(x), false, prec+64)
+	if neg {
+		r.Neg(r)
+	}
+	return r.SetPrec(prec)
+}
+
+// BesselY returns a big.Float representation of Yν(x), the Bessel
+// function of the second kind of order ν. Precision is the same as
+// the one of x. The function returns 0 when x = +Inf, and panics if ν
+// is infinite or if x < 0.
+func BesselY(nu, x *big.Float) *big.Float {
+
+	prec := x.Prec()
+	besselCheck(nu, x, false, "BesselY")
+
+	// Y(-n, x) = (-1)^n·Y(n, x)
+	nu, neg := besselReflect(nu, x, true)
+
+	var r *big.Float
+	switch {
+	// Y(ν, +Inf) = 0
+	case x.IsInf():
+		return new(big.Float).SetPrec(prec)
+
+	case x.Sign() == 0:
+		r = besselYZero(nu, prec)
+
+	default:
+		r = besselJY(nu, x, true, prec+64)
+	}
+
+	if neg {
+		r.Neg(r)
+	}
+	return r.SetPrec(prec)
+}
+
+// BesselI returns a big.Float representation of Iν(x), the modified
+// Bessel function of the first kind of order ν. Precision is the
+// same as the one of x. The function returns +Inf when x = +Inf, and
+// panics if ν is infinite, or if x < 0 and ν is not an integer.
+func BesselI(nu, x *big.Float) *big.Float {
+
+	prec := x.Prec()
+	besselCheck(nu, x, true, "BesselI")
+
+	// I(-n, x) = I(n, x) and I(n, -x) = (-1)^n·I(n, x)
+	nu, neg := besselReflect(nu, x, false)
+
+	var r *big.Float
+	switch {
+	// I(ν, +Inf) = +Inf
+	case x.IsInf():
+		r = new(big.Float).SetInf(false)
+
+	case x.Sign() == 0:
+		r = besselZero(nu, prec)
+
+	default:
+		r = besselIK(nu, new(big.Float).Abs(x), false, prec+64)
+	}
+
+	if neg {
+		r.Neg(r)
+	}
+	return r.SetPrec(prec)
+}
+
+// BesselK returns a big.Float representation of Kν(x), the modified
+// Bessel function of the second kind of order ν. Precision is the
+// same as the one of x. The function returns +Inf when x = 0 and 0
+// when x = +Inf, and panics if ν is infinite or if x < 0.
+func BesselK(nu, x *big.Float) *big.Float {
+
+	prec := x.Prec()
+	besselCheck(nu, x, false, "BesselK")
+
+	switch {
+	// K(ν, 0) = +Inf
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInf(false)
+
+	// K(ν, +Inf) = 0
+	case x.IsInf():
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// K(-ν, x) = K(ν, x)
+	return besselIK(new(big.Float).Abs(nu), x, true, prec+64).SetPrec(prec)
+}
+
+// besselCheck panics if ν is infinite, or if x < 0 and either negArg
+// is false or ν is not an integer, using fname in the panic message.
+func besselCheck(nu, x *big.Float, negArg bool, fname string) {
+	switch {
+	case nu.IsInf():
+		panic(fname + ": order is infinite")
+	case x.Signbit() && x.Sign() != 0 && !negArg:
+		panic(fname + ": argument is negative")
+	case x.Signbit() && x.Sign() != 0 && !nu.IsInt():
+		panic(fname + ": argument is negative and order is not an integer")
+	}
+}
+
+// besselReflect returns |ν| when ν is an integer, and ν otherwise,
+// and whether the sign of the result must be changed, given that
+// the function is multiplied by (-1)^n when the argument changes
+// sign, and also when the integer order n changes sign if negOrder
+// is true.
+func besselReflect(nu, x *big.Float, negOrder bool) (*big.Float, bool) {
+	if !nu.IsInt() {
+		return nu, false
+	}
+	n, _ := nu.Int(nil)
+	odd := n.Bit(0) == 1
+	neg := odd && x.Sign() < 0
+	if negOrder && odd && nu.Sign() < 0 {
+		neg = !neg
+	}
+	return new(big.Float).Abs(nu), neg
+}
+
+// besselZero returns Jν(0) = Iν(0), with prec bits of precision. ν
+// must not be a negative integer.
+func besselZero(nu *big.Float, prec uint) *big.Float {
+
+	switch {
+	case nu.Sign() == 0:
+		return big.NewFloat(1).SetPrec(prec)
+	case nu.Sign() > 0:
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// (x/2)^ν/Γ(ν+1) diverges, with the sign of Γ(ν+1)
+	one := big.NewFloat(1)
+	v := new(big.Float).SetPrec(exactSumPrec(nu, one)).Add(nu, one)
+	_, sign, _ := logGamma(v, 64)
+	return new(big.Float).SetPrec(prec).SetInf(sign < 0)
+}
+
+// besselYZero returns Yν(0), with prec bits of precision. ν must not
+// be a negative integer.
+func besselYZero(nu *big.Float, prec uint) *big.Float {
+
+	if nu.Sign() >= 0 {
+		return new(big.Float).SetPrec(prec).SetInf(true)
+	}
+
+	// Yν = (Jν·cos(νπ) - J−ν)/sin(νπ), where Jν diverges and J−ν
+	// vanishes, so the result is ±Inf unless cos(νπ) = 0.
+	sin, cos := sincosPi(new(big.Float).Set(nu))
+	if cos.Sign() == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+	j := besselZero(nu, prec)
+	return j.SetInf(j.Signbit() != (sin.Sign() != cos.Sign()))
+}
+
+// besselSinCosPi returns sin(νπ) and cos(νπ) computed to prec bits
+// of precision, without rounding ν.
+func besselSinCosPi(nu *big.Float, prec uint) (*big.Float, *big.Float) {
+	p := prec
+	if m := nu.MinPrec(); m > p {
+		p = m
+	}
+	sin, cos := sincosPi(new(big.Float).SetPrec(p).Set(nu))
+	return sin.SetPrec(prec), cos.SetPrec(prec)
+}
+
+// besselJY returns Jν(x), or Yν(x) if second is true, for x > 0
+// finite and ν >= 0 if ν is an integer, computed to prec bits of
+// precision.
+func besselJY(nu, x *big.Float, second bool, prec uint) *big.Float {
+
+	// The power series for Jν(x) has terms of about exp(x) for a
+	// result of about 1/√x, and close to the zeros of Jν and Yν the
+	// sums cancel down to the size of the result.
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		var r *big.Float
+		var scale int
+		if b := besselAsymptotic(nu, x, wprec); b != nil {
+			r, scale = besselHankel(nu, x, b, second, wprec)
+		} else if !second {
+			r, scale = besselSeries(nu, x, true, wprec)
+		} else if nu.IsInt() {
+			n, _ := nu.Int64()
+			r, scale = besselLogSeries(int(n), x, false, wprec)
+		} else {
+			// Yν(x) = (Jν(x)·cos(νπ) - J−ν(x)) / sin(νπ)
+			j1, s1 := besselSeries(nu, x, true, wprec)
+			j2, s2 := besselSeries(new(big.Float).Neg(nu), x, true, wprec)
+			sin, cos := besselSinCosPi(nu, wprec)
+			r = j1.Mul(j1, cos)
+			r.Sub(r, j2)
+			scale = s1 + cos.MantExp(nil)
+			if s2 > scale {
+				scale = s2
+			}
+			scale -= sin.MantExp(nil)
+			r.Quo(r, sin)
+		}
+
+		if lost := lostBits(r, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec)
+	}
+}
+
+// besselIK returns Iν(x), or Kν(x) if second is true, for x > 0
+// finite, ν >= 0 if ν is an integer, and ν >= 0 if second is true,
+// computed to prec bits of precision.
+func besselIK(nu, x *big.Float, second bool, prec uint) *big.Float {
+
+	// Kν(x) is about exp(-x), while the series involved are about
+	// exp(x), which costs about 2x·log2(e) bits.
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		var r *big.Float
+		var scale int
+		if b := besselAsymptotic(nu, x, wprec); b != nil {
+			r, scale = besselExpAsymptotic(x, b, second, wprec)
+
+			// For large x, exp(±x) overflows to +Inf or underflows
+			// to 0.
+			if r.Sign() == 0 || r.IsInf() {
+				return r.SetPrec(prec)
+			}
+		} else if !second {
+			r, scale = besselSeries(nu, x, false, wprec)
+		} else if nu.IsInt() {
+			n, _ := nu.Int64()
+			r, scale = besselLogSeries(int(n), x, true, wprec)
+		} else {
+			// Kν(x) = (π/2)·(I−ν(x) - Iν(x)) / sin(νπ)
+			i1, s1 := besselSeries(nu, x, false, wprec)
+			i2, s2 := besselSeries(new(big.Float).Neg(nu), x, false, wprec)
+			sin, _ := besselSinCosPi(nu, wprec)
+			r = i2.Sub(i2, i1)
+			r.Mul(r, pi(wprec))
+			r.SetMantExp(r, -1)
+			scale = s1
+			if s2 > scale {
+				scale = s2
+			}
+			scale += 1 - sin.MantExp(nil)
+			r.Quo(r, sin)
+		}
+
+		if lost := lostBits(r, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec)
+	}
+}
+
+// besselSeries returns
+//
+//	(x/2)^ν Σ (∓x²/4)^k / (k!·Γ(ν+k+1))
+//
+// that is Jν(x) if alt is true and Iν(x) otherwise, computed to prec
+// bits of precision, and the binary exponent of the largest quantity
+// that was involved in the computation. x must be positive, and ν+1
+// must not be a negative integer or zero.
+func besselSeries(nu, x *big.Float, alt bool, prec uint) (*big.Float, int) {
+
+	one := big.NewFloat(1)
+	h := new(big.Float).SetPrec(prec).Set(x)
+	h.SetMantExp(h, -1)
+	y := new(big.Float).SetPrec(prec).Mul(h, h)
+	if alt {
+		y.Neg(y)
+	}
+
+	// 1/Γ(ν+1), with ν+1 computed exactly
+	p := exactSumPrec(nu, one)
+	if p < prec {
+		p = prec
+	}
+	t := Gamma(new(big.Float).SetPrec(p).Add(nu, one))
+	t.Quo(one, t).SetPrec(prec)
+
+	s := new(big.Float).Set(t)
+	scale := t.MantExp(nil)
+	d := new(big.Float).SetPrec(prec)
+	for k := int64(1); ; k++ {
+		// t_k = t_(k-1)·(∓x²/4) / (k(ν+k))
+		d.SetInt64(k)
+		d.Add(d, nu)
+		d.Mul(d, big.NewFloat(float64(k)))
+		t.Mul(t, y)
+		t.Quo(t, d)
+		s.Add(s, t)
+
+		e := t.MantExp(nil)
+		if e > scale {
+			scale = e
+		}
+		if new(big.Float).Abs(d).Cmp(new(big.Float).Abs(y)) > 0 &&
+			(t.Sign() == 0 || e-s.MantExp(nil) < -int(prec)) {
+			break
+		}
+	}
+
+	f := Pow(h, nu)
+	return s.Mul(s, f), scale + f.MantExp(nil)
+}
+
+// besselLogSeries returns Yn(x), or Kn(x) if modified is true, for
+// n >= 0 and x > 0, computed to prec bits of precision, and the
+// binary exponent of the largest quantity that was involved in the
+// computation.
+func besselLogSeries(n int, x *big.Float, modified bool, prec uint) (*big.Float, int) {
+
+	// Following Abramowitz and Stegun, 9.1.11 and 9.6.11, and
+	// combining the log(x/2)·Jn(x) and log(x/2)·In(x) terms with the
+	// ones involving ψ(k+1) + ψ(n+k+1), we have
+	//     Yn(x) = -(1/π)(x/2)^(-n) Σ_(k<n) (n-k-1)!/k! (x²/4)^k
+	//           + (1/π)(x/2)^n Σ w_k (-x²/4)^k / (k!(n+k)!)
+	//     Kn(x) = ½(x/2)^(-n) Σ_(k<n) (n-k-1)!/k! (-x²/4)^k
+	//           + (-1)^(n+1) ½(x/2)^n Σ w_k (x²/4)^k / (k!(n+k)!)
+	// where w_k = 2(log(x/2) + γ) - H_k - H_(n+k), and H_k is the
+	// k-th harmonic number.
+	h := new(big.Float).SetPrec(prec).Set(x)
+	h.SetMantExp(h, -1)
+	y := new(big.Float).SetPrec(prec).Mul(h, h)
+	ny := new(big.Float).Neg(y)
+	ya, ys := y, ny
+	if modified {
+		ya, ys = ny, y
+	}
+	hn := powInt(h, n)
+	d := new(big.Float).SetPrec(prec)
+
+	// the finite sum
+	a := new(big.Float).SetPrec(prec)
+	scale := math.MinInt32
+	if n > 0 {
+		t := new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(1, int64(n-1)))
+		a.Set(t)
+		scale = t.MantExp(nil)
+		for k := 1; k < n; k++ {
+			t.Mul(t, ya)
+			t.Quo(t, d.SetInt64(int64(k)*int64(n-k)))
+			a.Add(a, t)
+			if e := t.MantExp(nil); e > scale {
+				scale = e
+			}
+		}
+		a.Quo(a, hn)
+		scale -= hn.MantExp(nil)
+	}
+
+	// the infinite sum
+	l := Log(new(big.Float).Set(h))
+	l.Add(l, EulerGamma(prec))
+	l.SetMantExp(l, 1)
+	hk := new(big.Float).SetPrec(prec)
+	hnk := new(big.Float).SetPrec(prec)
+	for j := 1; j <= n; j++ {
+		hnk.Add(hnk, d.Quo(big.NewFloat(1), d.SetInt64(int64(j))))
+	}
+
+	u := new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(1, int64(n)))
+	u.Quo(big.NewFloat(1), u) // (±x²/4)^k / (k!(n+k)!)
+	w := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	s := new(big.Float).SetPrec(prec)
+	for k := int64(0); ; k++ {
+		if k > 0 {
+			u.Mul(u, ys)
+			u.Quo(u, d.SetInt64(k*(int64(n)+k)))
+			hk.Add(hk, d.Quo(big.NewFloat(1), d.SetInt64(k)))
+			hnk.Add(hnk, d.Quo(big.NewFloat(1), d.SetInt64(int64(n)+k)))
+		}
+		w.Sub(l, hk)
+		w.Sub(w, hnk)
+		t.Mul(u, w)
+		s.Add(s, t)
+
+		// w_k may suffer from cancellation, so we use the largest
+		// of its parts to bound the error.
+		e := u.MantExp(nil) + l.MantExp(nil)
+		if eh := u.MantExp(nil) + hnk.MantExp(nil); eh > e {
+			e = eh
+		}
+		if e > scale {
+			scale = e
+		}
+		if d.SetInt64(k*(int64(n)+k)).Cmp(y) > 0 && (t.Sign() == 0 || t.MantExp(nil)-s.MantExp(nil) < -int(prec)) {
+			break
+		}
+	}
+	s.Mul(s, hn)
+	scale += hn.MantExp(nil)
+
+	if modified {
+		if n%2 == 0 {
+			s.Neg(s)
+		}
+		s.Add(s, a)
+		return s.SetMantExp(s, -1), scale - 1
+	}
+	s.Sub(s, a)
+	return s.Quo(s, pi(prec)), scale - 1
+}
+
+// besselAsymptotic returns the terms a_k(ν)/x^k of the Hankel
+// asymptotic expansions, where
+//
+//	a_k(ν) = (4ν²-1)(4ν²-9)···(4ν²-(2k-1)²) / (k!·8^k)
+//
+// up to the first one that is less than 2**(-prec), or nil if x is
+// too small for the expansions to reach prec bits of precision.
+func besselAsymptotic(nu, x *big.Float, prec uint) []*big.Float {
+
+	// The terms decrease down to about exp(-2x), for k close to 2x.
+	if xf, _ := x.Float64(); xf < float64(prec+8)*math.Ln2/2 {
+		return nil
+	}
+
+	mu := new(big.Float).SetPrec(prec).Mul(nu, nu)
+	mu.SetMantExp(mu, 2)
+	t := big.NewFloat(1).SetPrec(prec)
+	terms := []*big.Float{t}
+	c := new(big.Float).SetPrec(prec)
+	for k := int64(1); ; k++ {
+		c.SetInt64((2*k - 1) * (2*k - 1))
+		c.Sub(mu, c)
+		past := c.Sign() < 0
+		next := new(big.Float).SetPrec(prec).Mul(t, c)
+		next.Quo(next, x)
+		next.Quo(next, c.SetInt64(8*k))
+
+		// the expansion terminates when ν is half an odd integer
+		if next.Sign() == 0 {
+			return terms
+		}
+		// once (2k-1)² > 4ν², the terms start growing again only
+		// after their smallest one
+		if past && next.MantExp(nil) > t.MantExp(nil) {
+			return nil
+		}
+		terms = append(terms, next)
+		if next.MantExp(nil) < -int(prec) {
+			return terms
+		}
+		t = next
+	}
+}
+
+// besselHankel returns Jν(x), or Yν(x) if second is true, computed to
+// prec bits of precision from the terms b of the Hankel asymptotic
+// expansions, and the binary exponent of the largest quantity that
+// was involved in the computation.
+func besselHankel(nu, x *big.Float, b []*big.Float, second bool, prec uint) (*big.Float, int) {
+
+	// Following Abramowitz and Stegun, 9.2.5 and 9.2.6,
+	//     Jν(x) = √(2/(πx))·(P·cos(χ) - Q·sin(χ))
+	//     Yν(x) = √(2/(πx))·(P·sin(χ) + Q·cos(χ))
+	// where χ = x - (ν/2 + 1/4)π, P = b0 - b2 + b4 - ..., and
+	// Q = b1 - b3 + b5 - ...
+	p := new(big.Float).SetPrec(prec)
+	q := new(big.Float).SetPrec(prec)
+	scale := 1
+	for k, t := range b {
+		switch k % 4 {
+		case 0:
+			p.Add(p, t)
+		case 1:
+			q.Add(q, t)
+		case 2:
+			p.Sub(p, t)
+		case 3:
+			q.Sub(q, t)
+		}
+		if e := t.MantExp(nil) + 1; e > scale {
+			scale = e
+		}
+	}
+
+	// sin(χ) and cos(χ) from sin(x), cos(x), and sin(θ), cos(θ) with
+	// θ = (ν/2 + 1/4)π computed exactly, since χ would lose about
+	// log2(x) bits.
+	sx, cx := sincos(new(big.Float).SetPrec(prec).Set(x))
+	th := new(big.Float).SetMantExp(nu, -1)
+	quarter := big.NewFloat(0.25)
+	th = new(big.Float).SetPrec(exactSumPrec(th, quarter)).Add(th, quarter)
+	st, ct := besselSinCosPi(th, prec)
+
+	t := new(big.Float).SetPrec(prec)
+	cc := new(big.Float).SetPrec(prec).Mul(cx, ct)
+	cc.Add(cc, t.Mul(sx, st))
+	sc := new(big.Float).SetPrec(prec).Mul(sx, ct)
+	sc.Sub(sc, t.Mul(cx, st))
+
+	r := new(big.Float).SetPrec(prec)
+	if second {
+		r.Mul(p, sc)
+		r.Add(r, t.Mul(q, cc))
+	} else {
+		r.Mul(p, cc)
+		r.Sub(r, t.Mul(q, sc))
+	}
+
+	f := new(big.Float).SetPrec(prec).Mul(pi(prec), x)
+	f.Quo(big.NewFloat(2), f)
+	f.Sqrt(f)
+	return r.Mul(r, f), scale + f.MantExp(nil)
+}
+
+// besselExpAsymptotic returns Iν(x), or Kν(x) if second is true,
+// computed to prec bits of precision from the terms b of the Hankel
+// asymptotic expansions, and the binary exponent of the largest
+// quantity that was involved in the computation.
+func besselExpAsymptotic(x *big.Float, b []*big.Float, second bool, prec uint) (*big.Float, int) {
+
+	// Following Abramowitz and Stegun, 9.7.1 and 9.7.2,
+	//     Iν(x) = exp(x)/√(2πx) · Σ (-1)^k b_k
+	//     Kν(x) = √(π/(2x))·exp(-x) · Σ b_k
+	// where the exponentially small contribution of Kν to Iν for
+	// non-integer ν is below 2**(-prec).
+	s := new(big.Float).SetPrec(prec)
+	scale := 0
+	for k, t := range b {
+		if k%2 == 1 && !second {
+			s.Sub(s, t)
+		} else {
+			s.Add(s, t)
+		}
+		if e := t.MantExp(nil); e > scale {
+			scale = e
+		}
+	}
+
+	// √(1/(2πx)) or √(π/(2x))
+	f := new(big.Float).SetPrec(prec).Set(x)
+	f.SetMantExp(f, 1)
+	e := new(big.Float).SetPrec(prec + uint(x.MantExp(nil))).Set(x)
+	if second {
+		f.Quo(pi(prec), f)
+		e.Neg(e)
+	} else {
+		f.Mul(f, pi(prec))
+		f.Quo(big.NewFloat(1), f)
+	}
+	f.Sqrt(f)
+	f.Mul(f, Exp(e))
+	return s.Mul(s, f), scale + f.MantExp(nil)
+}
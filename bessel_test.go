@@ -0,0 +1,241 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestBesselJ(t *testing.T) {
+	for _, test := range []struct {
+		nu, x string
+		want  string
+	}{
+		{"0", "1", "7.65197686557966551449717526102663220909274289755325241861547549119278912215272440167180600098915633974929259827603576204084876855208878622462801194635043000621684026314734672618256151707142493145589701261231902194162816319453039821557896720677305893243788545259984194414064569924052713471270496393921490949691240492940714722262068207664503239916300481138484501e-1"},
+		{"0", "2.40625", "-7.39276482217001927565771145118130033112416341526256238530052912714993398611233775684133384250000323445328110097609233934570267668071618092393219201540396264187716741890570264648466247808485775295142477313391641970262160876615599684666411434959350371157400972112466138322710727598870816255665314912429835325366666331961494281565408425221908974352603380618074173e-4"},
+		{"1", "10", "4.34727461688614366697487680258592883062728671185942081359143226009802310599868434785916613951528309605988003902132990476999982213892203705173580083985987165544118626273485222208136869884870837653540139879379419492541744273404710885860640480681992486281564261270867867152342890826861830537745004381365182515818370805368652684147861086383103329136771049849747097e-2"},
+		{"2.5", "0.5", "9.23640781937972449993274876435520302112816876838085866618680022621401989524544375661946025053659045144911584393246352458580242811694674356554305520901957489512146253185309663322272822769683929600960282264290054363155185144011412310667698296410178662660903323717513986776030584612206582407534300994900563634653656417990096009066685493726471431784077895352693458e-3"},
+		{"-0.5", "3", "-4.56048820794633178846833260211615345917892440818622780102308192955113943812220170814997279965228801515138494187337135516668141602880851838760713410482341755006488393019717673104689561365950385417661264133765161005626731878772428761516483167620118201809956752797454249010013569701405181389738432054238404726689944220970557243396083241062298992580550295994296401e-1"},
+		{"0.375", "1.5", "6.43066107805381706160895911747498310418560166262641419148078364005745514524112165877658929232971730477077361214408244988044211971290285744921172759244326953078616583670557018129905314253587461849060870757130425199168566317339731731030566950827333666734464754195401738660728050629561214791933273404901206077279428185360859679493570339699826704741157479208812348e-1"},
+		{"-1.75", "2", "-6.27705708713737587331851020333795601449581131929471897383407833790059661941589545675676316587440498214887009920808870621251790072441153744755675560593261473577689234889492065616357546652697425100773501044080188005523009431040523555841156919416503998042304659320926906952392411978404745828972660005030628560311734650883842408967527105008069663957021998555631649e-2"},
+		{"5", "1p-10", "2.31296454272678740741301754579231428960674871961077458016251777624192440196413485003189145984786649661748583176969673057849818647115360311530654922747515677669222150872290089423031081182985382246318595200258053476001963125564015853805569028281270822067597140861641612557320298299541393799347752509165405007643936383930393036628326188630726265413491572434252364e-19"},
+		{"-3", "7", "1.67555587995334236031511112634201776733489571048396589285025757948689565247275193545784881764465095533127291887569566535898273163641448500077195361525699968841839301877843947601913966955282316918928885612583476712238736405280308745262799615074501796085053106222700738673510455865071963073098427808586925003612811742861247825829376178256588599503273095388866477e-1"},
+		{"3", "-7", "1.67555587995334236031511112634201776733489571048396589285025757948689565247275193545784881764465095533127291887569566535898273163641448500077195361525699968841839301877843947601913966955282316918928885612583476712238736405280308745262799615074501796085053106222700738673510455865071963073098427808586925003612811742861247825829376178256588599503273095388866477e-1"},
+		{"0", "100", "1.99858503042231224242283909508489906806335788590279295586421144472257627225740137854729774002696249725866908265411840797254686306635784197218252180488005464409917422014944870291548020595704618135199381018136481991662080961310878642191079231791078917828908519132996479846801239232433358998125183340181298375653548540284800119578280195519709208486132678256972286e-2"},
+		{"1", "1000", "4.72831190708952391757607190121691628541802420205963686871972153612986853073539711833160494518656851826513977877027043580979117027876954674855054183904563692233460101169412926884234951105157608624585676363377570185185550896236574381647919566085618265441344556838396676021262998446090278998095740564727253193352876943678331205323287980529329479247255035679177281e-3"},
+		{"100", "50", "1.11592736908380927800560964541020752839099044977413526335104804171538797345309350553989859237838067898436880700864509931690896802490302891699519160194403253348723159158490820769300823178472206002322488102114796377203252827770681980345641604877772747110086683884547179172008464862190774195016823067105226944975308159702366885496393617511684220152807013466300249e-21"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			nu := new(big.Float).SetPrec(prec)
+			nu.Parse(test.nu, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.BesselJ(nu, x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, BesselJ(%v, %v) =\ngot  %g;\nwant %g", prec, test.nu, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestBesselY(t *testing.T) {
+	for _, test := range []struct {
+		nu, x string
+		want  string
+	}{
+		{"0", "1", "8.82569642156769579829267660235151628278175230906755467110438476119997893235133713010772003592199368022027613671581283734881243255759086588153772150808166389939460984480658447405075374088857392799123385933396668254337414274634270589604863020312962965308024411733863939390969132655691389756321731062408716595200539355138934509977372940920737210690277365588716382e-2"},
+		{"1", "2.5", "1.45918137966785798878759940535877571276080196546700999845103368768479827869868273396946944959989040892881276985486954207395923012853412999846889915477006423482927002963458982278911292329429498346064419930697876162560669267395441274629201700127175663424743639722280969002433410744437240708809242401568312045290510199835963731568308071187152270445714821548716673e-1"},
+		{"0.5", "3", "4.56048820794633178846833260211615345917892440818622780102308192955113943812220170814997279965228801515138494187337135516668141602880851838760713410482341755006488393019717673104689561365950385417661264133765161005626731878772428761516483167620118201809956752797454249010013569701405181389738432054238404726689944220970557243396083241062298992580550295994296401e-1"},
+		{"2", "0.125", "-8.18092971754125594061927764028026004277250774143885053473637669142729856346543496073850504406458828199218082965552655873974089748213541746518810411490396684502667138198898686645985661818552573148527446112615404320886959001515836341008434662899661136013889095774116935034995840074555204798932228845162125582615452790816131700043755463940582517229736899568797267e+1"},
+		{"-0.75", "4", "-3.84972467887447858885879888459709566793911256920380053022657922944485057964733383935692996164456429870272257444656052121871256273559205994462724128978571982322346326618680544719593621485525015996989806093982402253250473952217523715529836598954956120138505152606572579276473568845705059242721917886862818800982061207925338958045561363869315544902407618008761791e-1"},
+		{"-2", "5", "3.67662882605524517994069254407261493535733148991534289116130479701733222013367694590456337706505489136083022172038695966883335201959211656845766529222112462836941495970784384660734459445381573596530170866114629668987843052915515644070417838191237074273471684093133309599988295372807878387697642593653693932903596879724801968239658628885592328165365450800074565e-1"},
+		{"10", "3", "-2.58260712948429966911012385910164031167512381014398627897553161038376010036960079129725306256498891847464233218930952941919672062726150462630542663630076748445009229933499565600503916738776727505357548213624670866259926914014789405493946107449686097351327769759376501952860611014976677785431217507226942507738227148576306152461636826913351452911536783012512017e+3"},
+		{"0.0009765625", "2", "5.10031700575638661996427093599290162815602332594373601216225820327216555371248115303348009483655931833839554422272157676042036223130273266313608773293705349242350244026740906540380706103104360398013764702398823263295804830415724923257583104437558201271799754463525786302277841884388916399937876133007880283228697669838820406674236934124306709130685520318965667e-1"},
+		{"0", "100", "-7.72443133650831522542282213671987705056989836566356547719427776076541575011372321068444524706301986002658450059701039704613612574013173816994785053761251018885935800895414583949037272282015895066369507446648508371462790772173737971924404940962444894599520503302311182680466562338931572917841610572623547205002812744613009363830212025490335714570171004508675138e-2"},
+		{"3", "1000", "2.47652693457909488470319441524298527882649097904444018853778331372875982443387296389196714355551912144266558467128570021526412492163098613998367347039395685408731712926204228399235041417828998989242073057570863818320129211847718987162029862249197483036440962181910148519791928318610179714102159414953703806300445973914455716832930285986552552483172341902889834e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			nu := new(big.Float).SetPrec(prec)
+			nu.Parse(test.nu, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.BesselY(nu, x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, BesselY(%v, %v) =\ngot  %g;\nwant %g", prec, test.nu, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestBesselI(t *testing.T) {
+	for _, test := range []struct {
+		nu, x string
+		want  string
+	}{
+		{"0", "1", "1.26606587775200833559824462521471753760767031135496220680813533121357501612277547039481835714728010187103613468905613878660443623930335158523080353204087479038254091424413629537343957256009955459264383832271986915605884922065483773045588820758713779340668166031658685993963388324811071086075143611342158518928968325342912820570954739468471021803405322457484729e+0"},
+		{"1", "10", "2.67098830370125465434103196677215254914574515378753771310848931619454827507986633965574509664585521554883820292198394182767504202938043878559712267563705743958044620020614368336574257506681499456700498964932115242667723105579094821597148521722774489903389051730197053034014227477640225289958561371400500401769265079362471001530729426381147086060731065625874747e+3"},
+		{"2.5", "0.5", "9.57224378631588027109987950147679142901434600635433330518919568836506767372804836281795824852957696420424628359855057729163416279697232500481489937952185076621014927013367092385442266160330739260062604645328280165488390159297284158072626651178777776148580572352811376543270247143868546457369426973676649811992701341538980758515506217714063257476740664696960828e-3"},
+		{"-0.5", "3", "4.63775775786150279273006174767836901201697203151637597627834216167716583883345570229453913413382651131968666631239425442646995370212913004688682780323379632493272107059415967393736865354978425716336430249140090859738433815945366596390725200579246923972792324831237137294951704020825668230638477764220941674908132210599185850763186358842424760251067510055846831e+0"},
+		{"-1.75", "2", "7.85077922747651347941937033452590307852883636860876871125994866617040136608614074174063664473828040418773392119110069484695877206131885852921302593999814244019060600165746979570912074343000000956586823074163396386153208040421298105578515288989776790053275260064529245053471041317761868075657252970431050993498675718616897338628292041116193889447990401996215208e-1"},
+		{"3", "-2", "-2.12739959239852655272354393375932037291752272915691833255184450497024426140730869889332265669714977744078199239165475350285751838055206903053683494156020652500710382754275844301923932087970665303079082897832031996026303072075918444297949920755458499211787852181774532997817806215741618710773871798365660566597409115317502170762093032771796127672543104012489159e-1"},
+		{"-4", "1.5", "1.47381662713115152509137102372344909756892637789324592442078312213126207208772524124688821435134998990873485740156806880502809515368784543566164274182957839044202328491339957183062879763492990158619051329738523272623622108864591699906569268651254535201984929738594538500845087976091745310668357002961563414093310903720509493150291814961806533453059996855036268e-2"},
+		{"0", "400", "1.04185845035214631726729960365948992598943283241882989902354627524564144691225504998765810614915235295688519039169018053011120441723845072382409446317285344021611024550529155324759372751347899383705404621867987852419176726538990627339929475320678401506501187992851951483147327557610976495030945743196499648003783933268683976830902006578742145184826010094009163e+172"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			nu := new(big.Float).SetPrec(prec)
+			nu.Parse(test.nu, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.BesselI(nu, x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, BesselI(%v, %v) =\ngot  %g;\nwant %g", prec, test.nu, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestBesselK(t *testing.T) {
+	for _, test := range []struct {
+		nu, x string
+		want  string
+	}{
+		{"0", "1", "4.21024438240708333335627379212609036136219748226660472298969551455212678138101839092125139547365304510934609680375446803967705609011352631000647192647615224217839968037435378358643432658494547938418263866092752245362949296379496877979320818011909818677561479815534116536186084195709388952783543778642009394037448543754955795070951470625072971110797290784062093e-1"},
+		{"1", "10", "1.86487734538255845968168581223716746816668801026340541215150986731613802407285203172030795648871427020527681467942930480648393574523959924657674836188897192558657795794992239398320609196386837372593918197317921767191790988508762268047200994147450327037901642718506081988488800400033466432896939202248787229670520029284777272642758902835330588327889747175936882e-5"},
+		{"0.5", "2", "1.19937771968061447368036501636793516219450451910229090756240857002065176440358584462562716441357720909255665202849296729061578356147982533591229613472473262013046359819997708983414218238450016657912132426281962865309965018628410202485798657366281341400696912645043829464804975868951911759576040979549116105639539947964056295739474052542514915738780492521293986e-1"},
+		{"2.5", "0.5", "2.04259044664984845357323613447159617237276038782697896446616775080092470492470003388379513909958435410362242767129637944736706846375391192865478307468767261665972966020499604426583987727840393613049058343870135560998000287261709215462035917319796736448657845436715432005375663099342609329667839279775498570304308305366906282173257310163609929930685266031559409e+1"},
+		{"-1.5", "3", "4.80346468423527900873472752064298657547468088614136147113693031670614923902605464005173317155140155603841776481798785227289827928101010424927578583435385399010005542203948948872965021055287368742802133335700277852478102316987407700913730090761732610731853798904818540175570494497926464104072643689456555254030836312908674203418619803344038028602237940403500517e-2"},
+		{"4", "0.25", "1.22242487238945655086832133868262299786467976646649165502096828345962998908695770898487261378512276433661745770240867171897104575344629567910182306266899782348680641668602979541841086562928921551607932005343705767321754531056368397898033404404378798930938081472765954745697427090612867363989226637893658673638627725250109271637282220041852941068153997035263990e+4"},
+		{"0.25", "30", "2.13466418330903548380233982663108777737040514755037429009845475905143137721267337000164663776907104829353184799172759389112301868101684142763435139145929142410819054895416738585192819208377743587678810898686666899121912690660028427225276680056674364188333009222928082647719774137712462369843700726020861824464004747175919485524850375056131936224102166850400612e-14"},
+		{"0", "400", "1.19978004320097600031151302968673078553960768545422132236115081395056170688902091079222493042195080063640238129980619338507805582033260357606226844025056584763568646293480683226386133744996061685952311516159714108745600614590801915633661671026472868290343281499319609424844645027639649068774322469210624031846674564762147402023967569676937116723233896256663794e-175"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			nu := new(big.Float).SetPrec(prec)
+			nu.Parse(test.nu, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.BesselK(nu, x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, BesselK(%v, %v) =\ngot  %g;\nwant %g", prec, test.nu, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestBesselWronskian(t *testing.T) {
+	// J(ν+1, x)Y(ν, x) - J(ν, x)Y(ν+1, x) = 2/(πx)
+	// I(ν, x)K(ν+1, x) + I(ν+1, x)K(ν, x) = 1/x
+	const prec = 200
+	pi := bigfloat.Gamma(big.NewFloat(0.5).SetPrec(prec))
+	pi.Mul(pi, pi)
+	for _, test := range []struct{ nu, x float64 }{
+		{0, 0.5}, {1, 3}, {0.25, 2}, {-1.5, 7}, {3.75, 20}, {0, 150},
+	} {
+		nu := big.NewFloat(test.nu).SetPrec(prec)
+		nu1 := new(big.Float).Add(nu, big.NewFloat(1))
+		x := big.NewFloat(test.x).SetPrec(prec)
+
+		w := new(big.Float).Mul(bigfloat.BesselJ(nu1, x), bigfloat.BesselY(nu, x))
+		w.Sub(w, new(big.Float).Mul(bigfloat.BesselJ(nu, x), bigfloat.BesselY(nu1, x)))
+		w.Mul(w, x)
+		w.Mul(w, pi)
+		if d := w.Sub(w, big.NewFloat(2)); d.Sign() != 0 && d.MantExp(nil) > -prec+8 {
+			t.Errorf("J/Y Wronskian at ν = %v, x = %v is off by %g", test.nu, test.x, d)
+		}
+
+		w.Mul(bigfloat.BesselI(nu, x), bigfloat.BesselK(nu1, x))
+		w.Add(w, new(big.Float).Mul(bigfloat.BesselI(nu1, x), bigfloat.BesselK(nu, x)))
+		w.Mul(w, x)
+		if d := w.Sub(w, big.NewFloat(1)); d.Sign() != 0 && d.MantExp(nil) > -prec+8 {
+			t.Errorf("I/K Wronskian at ν = %v, x = %v is off by %g", test.nu, test.x, d)
+		}
+	}
+}
+
+func TestBesselSpecialValues(t *testing.T) {
+	zero, inf := big.NewFloat(0), big.NewFloat(math.Inf(+1))
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"BesselJ(0, 0)", bigfloat.BesselJ(big.NewFloat(0), zero), 1},
+		{"BesselJ(1, 0)", bigfloat.BesselJ(big.NewFloat(1), zero), 0},
+		{"BesselJ(-0.5, 0)", bigfloat.BesselJ(big.NewFloat(-0.5), zero), math.Inf(+1)},
+		{"BesselJ(-1.5, 0)", bigfloat.BesselJ(big.NewFloat(-1.5), zero), math.Inf(-1)},
+		{"BesselJ(0, +Inf)", bigfloat.BesselJ(big.NewFloat(0), inf), 0},
+		{"BesselY(0, 0)", bigfloat.BesselY(big.NewFloat(0), zero), math.Inf(-1)},
+		{"BesselY(-1, 0)", bigfloat.BesselY(big.NewFloat(-1), zero), math.Inf(+1)},
+		{"BesselY(-0.25, 0)", bigfloat.BesselY(big.NewFloat(-0.25), zero), math.Inf(-1)},
+		{"BesselY(-0.5, 0)", bigfloat.BesselY(big.NewFloat(-0.5), zero), 0},
+		{"BesselY(0, +Inf)", bigfloat.BesselY(big.NewFloat(0), inf), 0},
+		{"BesselI(0, 0)", bigfloat.BesselI(big.NewFloat(0), zero), 1},
+		{"BesselI(2, 0)", bigfloat.BesselI(big.NewFloat(2), zero), 0},
+		{"BesselI(1, +Inf)", bigfloat.BesselI(big.NewFloat(1), inf), math.Inf(+1)},
+		{"BesselK(0, 0)", bigfloat.BesselK(big.NewFloat(0), zero), math.Inf(+1)},
+		{"BesselK(1, +Inf)", bigfloat.BesselK(big.NewFloat(1), inf), 0},
+
+		// exp(±x) is out of big.Float's exponent range
+		{"BesselK(0, 1e10)", bigfloat.BesselK(big.NewFloat(0), big.NewFloat(1e10)), 0},
+		{"BesselI(0, 1e10)", bigfloat.BesselI(big.NewFloat(0), big.NewFloat(1e10)), math.Inf(+1)},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkBesselJ(b *testing.B) {
+	nu := big.NewFloat(0.5)
+	for _, prec := range []uint{1e2, 1e3} {
+		x := big.NewFloat(10).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.BesselJ(nu, x)
+			}
+		})
+	}
+}
+
+func BenchmarkBesselK(b *testing.B) {
+	nu := big.NewFloat(1)
+	for _, prec := range []uint{1e2, 1e3} {
+		x := big.NewFloat(10).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.BesselK(nu, x)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package bigfloat
+
+import "math/big"
+
+// Beta returns a big.Float representation of the beta function
+// B(a, b) = Γ(a)Γ(b)/Γ(a+b). Precision is the same as the one of the
+// first argument. The function returns 0 when a+b is a non-positive
+// integer, and panics if a or b is zero, a negative integer or
+// infinite.
+func Beta(a, b *big.Float) *big.Float {
+
+	prec := a.Prec()
+
+	if x := betaSpecial(a, b, "Beta"); x != nil {
+		return x.SetPrec(prec)
+	}
+
+	// B(a, b) = ±exp(log|B(a, b)|)
+	//
+	// The absolute error in log|B(a, b)| becomes a relative error in
+	// the result, so we need as many extra bits as its magnitude.
+	guard := uint(64)
+	for {
+		lb, sign, scale := logBeta(a, b, prec+guard)
+		if scale <= int(guard-64) {
+			x := Exp(lb)
+			if sign < 0 {
+				x.Neg(x)
+			}
+			return x.SetPrec(prec)
+		}
+		guard = 64 + uint(scale)
+	}
+}
+
+// LogBeta returns a big.Float representation of the natural
+// logarithm of |B(a, b)|, and the sign of B(a, b), as -1 or +1.
+// Precision is the same as the one of the first argument. The
+// function returns -Inf when a+b is a non-positive integer, and
+// panics if a or b is zero, a negative integer or infinite.
+func LogBeta(a, b *big.Float) (*big.Float, int) {
+
+	prec := a.Prec()
+
+	if x := betaSpecial(a, b, "LogBeta"); x != nil {
+		sign := 1
+		if x.Signbit() {
+			sign = -1
+		}
+		if x.Sign() == 0 {
+			return x.SetInf(true).SetPrec(prec), sign
+		}
+		return Log(x.Abs(x).SetPrec(prec + 64)).SetPrec(prec), sign
+	}
+
+	// When a or b are large, log|Γ(a)| + log|Γ(b)| - log|Γ(a+b)|
+	// has terms much larger than the result.
+	guard := uint(64)
+	for {
+		lb, sign, scale := logBeta(a, b, prec+guard)
+		if lost := lostBits(lb, scale, prec+guard); lost > int(guard-64) {
+			guard = 64 + uint(lost)
+			continue
+		}
+		return lb.SetPrec(prec), sign
+	}
+}
+
+// betaSpecial returns B(a, b), exactly, when a and b are small
+// positive integers, and (a signed) 0 when a+b is a non-positive
+// integer. It returns nil otherwise, and panics if a or b is not in
+// the domain of the beta function.
+func betaSpecial(a, b *big.Float, fname string) *big.Float {
+
+	for _, x := range []*big.Float{a, b} {
+		if x.IsInf() || x.IsInt() && x.Sign() <= 0 {
+			panic(fname + ": argument is a non-positive integer or infinite")
+		}
+	}
+
+	// B(m, n) = (m-1)!(n-1)!/(m+n-1)!
+	if fa, fb := factorial(a), factorial(b); fa != nil && fb != nil {
+		m, _ := a.Int64()
+		n, _ := b.Int64()
+		x := new(big.Rat).SetFrac(fa.Mul(fa, fb), new(big.Int).MulRange(1, m+n-1))
+		return new(big.Float).SetPrec(a.Prec() + 64).SetRat(x)
+	}
+
+	// 1/Γ(a+b) = 0 when a+b is a non-positive integer
+	c := new(big.Float).SetPrec(exactSumPrec(a, b)).Add(a, b)
+	if c.IsInt() && c.Sign() <= 0 {
+		_, sa, _ := logGamma(a, 64)
+		_, sb, _ := logGamma(b, 64)
+		return setSign(new(big.Float), sa*sb < 0)
+	}
+
+	return nil
+}
+
+// logBeta returns log|B(a, b)| computed to prec bits of precision,
+// the sign of B(a, b), and the binary exponent of the largest
+// quantity that was involved in the computation.
+func logBeta(a, b *big.Float, prec uint) (*big.Float, int, int) {
+
+	// log|B(a, b)| = log|Γ(a)| + log|Γ(b)| - log|Γ(a+b)|
+	c := new(big.Float).SetPrec(exactSumPrec(a, b)).Add(a, b)
+	la, sa, scale := logGamma(a, prec)
+	lb, sb, sc := logGamma(b, prec)
+	if sc > scale {
+		scale = sc
+	}
+	lc, sg, sc := logGamma(c, prec)
+	if sc > scale {
+		scale = sc
+	}
+
+	la.Add(la, lb)
+	la.Sub(la, lc)
+	return la, sa * sb * sg, scale
+}
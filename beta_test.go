@@ -0,0 +1,89 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestBeta(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want string
+	}{
+		{"0.5", "0.5", "3.14159265358979323846264338327950288419716939937510582097494459230781640628620899862803482534211706798214808651328230664709384460955058223172535940812848111745028410270193852110555964462294895493038196442881097566593344612847564823378678316527120190914564856692346034861045432664821339360726024914127372458700660631558817488152092096282925409171536436789259036e+0"},
+		{"2.5", "1.5", "1.96349540849362077403915211454968930262323087460944113810934037019238525392888062414252176583882316748884255407080144165443365288096911389482834963008030069840642756418871157569097477788934309683148872776800685979120840383029728014611673947829450119321603035432716271788153395415513337100453765571329607786687912894724260930095057560176828380732210272993286898e-1"},
+		{"1000.5", "0.25", "6.44714592181081564711365871349388912544062544689876351128368039794996209166365127396899520459169246245837619286646940913007233736544553515340630360906919858930459243549420255981150230348314980794763549570307457072332572282334385438357951402762495457806208322020437458044712652965113295413051646927125454071868935459058486330180071623266477724602846495076700713e-1"},
+		{"-0.5", "2.25", "-4.37009592382019968410806598315186568947125825238603860469470283966798431178402375038382588826904848907689292111963869675934826150232322615783519905795872640070440249527484491989483329988691012063853565135034370316238266809425756628717561591618381481387587747836888168682923472188094751954958704869049448738418994742742589905480235896888622518068204385355412778e+0"},
+		{"-2.75", "-0.5", "6.64027562450601770182654181855543228140437942245670800973091210702797616206143868564815102503219056132462950351945100676420450124378983714891841934780741544003136483048255656659344800112686343006115157412974302948050353463672902929350061119731826406783737227232674230336390210987105012710781408697127084446688602401310169077158280518648945904077661208916666169e+0"},
+		{"1e5", "1e5", "1.12324030467808284147094825782902073719906063367440963715709508227048602760778149405973846547951834078393339923780462253952122117261754682799122675508078789417144831275014491890028661355978737209549240559352134642269135961090548564694477401711157897546235987833639480175987127681581402530115068870118126449295627263428225335886591213150642203479311034501929112e-60208"},
+		{"3", "4", "0.016666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666667"},
+		{"2.5", "-2.5", "0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			x := bigfloat.Beta(a, b)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Beta(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, x, want)
+			}
+		}
+	}
+}
+
+func TestLogBeta(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want string
+	}{
+		{"0.5", "0.5", "1.14472988584940017414342735135305871164729481291531157151362307147213776988482607978362327027548970770200981222869798915904820552792345658727908107881028682527639391426634590290248477335886993778920311963082475679401191602821722737988812656317804982369731331069500360006440548726388022327009643350495951181506623725246834339126989657975140477703857799539982584e+0"},
+		{"1000.5", "0.25", "-4.38947552857676939863880690108371183374614440112597509930358911821133341235485282391374223021260926447724608878479142264306476980893538241337172111255817836750319652678668513842725573125291698352671244227668893979466527404831741812749333361220318747702892853241363951616823898808918219943228077930918285562723790150650407643360995081989779942198348474237650118e-1"},
+		{"1e5", "1e5", "-1.38633927061348062352265188657808066903028475669824065068440473983313541011995573505274245862651156451939222769182617594520925634168823666729408854731258201247747960038591241291544104707474606201110194448199277647097773849562497733482459925320062165417566201925649941665843644248215052362983541146898503068726897852705318048733138153114659955976727208360829196e+5"},
+		{"-2.75", "-0.5", "1.89315347234159981642585471288136330343342579555987836045057237179874766903981348917215209695974971715898749725739231211181477204475765209860079204027729963708305027398315705590245660108615643963850986020947349093702465595274026057300713808884972994785872310251376284663638784823716227049294945182772493032138817246535567094571086952538554767259320071958928438e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			x, _ := bigfloat.LogBeta(a, b)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LogBeta(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, x, want)
+			}
+		}
+	}
+}
+
+func TestBetaSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		a, b float64
+		sign int
+	}{
+		{2.5, -2.5, -1},
+		{-0.5, -1.5, -1},
+	} {
+		a, b := big.NewFloat(test.a), big.NewFloat(test.b)
+		x, sign := bigfloat.LogBeta(a, b)
+		if !x.IsInf() || x.Sign() > 0 || sign != test.sign {
+			t.Errorf("LogBeta(%g, %g) = %g, %d; want -Inf, %d", test.a, test.b, x, sign, test.sign)
+		}
+		x64, _ := bigfloat.Beta(a, b).Float64()
+		if x64 != 0 || math.Signbit(x64) != (test.sign < 0) {
+			t.Errorf("Beta(%g, %g) = %g; want %g", test.a, test.b, x64, math.Copysign(0, float64(test.sign)))
+		}
+	}
+}
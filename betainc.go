@@ -0,0 +1,305 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// BetaInc returns a big.Float representation of the regularized
+// incomplete beta function Iₓ(a, b) = B(x; a, b)/B(a, b). Precision
+// is the same as the one of x. The function panics if a or b are not
+// positive and finite, or if x is not in [0, 1].
+func BetaInc(a, b, x *big.Float) *big.Float {
+
+	prec := x.Prec()
+	betaIncCheck(a, b, x, "BetaInc")
+
+	// I_0(a, b) = 0, I_1(a, b) = 1
+	if x.Sign() == 0 || x.Cmp(big.NewFloat(1)) == 0 {
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
+
+	r, _ := betaInc(a, b, x, false, prec+64)
+	return r.SetPrec(prec)
+}
+
+// BetaIncInv returns a big.Float representation of the value x such
+// that Iₓ(a, b) = y. Precision is the same as the one of y. The
+// function panics if a or b are not positive and finite, or if y is
+// not in [0, 1].
+func BetaIncInv(a, b, y *big.Float) *big.Float {
+
+	prec := y.Prec()
+	betaIncCheck(a, b, y, "BetaIncInv")
+
+	// I_0(a, b) = 0, I_1(a, b) = 1
+	one := big.NewFloat(1)
+	if y.Sign() == 0 || y.Cmp(one) == 0 {
+		return new(big.Float).SetPrec(prec).Set(y)
+	}
+
+	// We solve for the smaller of I and 1 - I, which is known with
+	// full relative precision, and since
+	//     x(1-x)·I'ₓ(a, b) = x^a·(1-x)^b/B(a, b)
+	// we have
+	//     f(t)/f'(t) = ±(Iₜ(a, b) - y)·t(1-t) / (t^a·(1-t)^b/B(a, b))
+	upper := y.Cmp(big.NewFloat(0.5)) > 0
+	if upper {
+		y = new(big.Float).SetPrec(exactSumPrec(y, one)).Sub(one, y)
+	}
+
+	// When x is close to 1 we solve for 1 - x instead, using
+	//     I₁₋ₓ(b, a) = 1 - Iₓ(a, b)
+	// so that the iterates never round to 1.
+	t, s := betaIncInvGuess(a, b, y, upper)
+	swap := t.Cmp(s) > 0
+	if swap {
+		a, b, upper, t = b, a, !upper, s
+	}
+
+	// Where the factor underflows to 0 the quotient is ±Inf, with the
+	// sign of the step, and the polishing below bisects.
+	f := func(t *big.Float) *big.Float {
+		p := t.Prec()
+		x, g := betaInc(a, b, t, upper, p+16)
+		x.Sub(x, y)
+		x.Mul(x, t)
+		u := new(big.Float).SetPrec(exactSumPrec(t, one)).Sub(one, t)
+		x.Mul(x, u)
+		if x.Sign() != 0 {
+			x.Quo(x, g)
+		}
+		if upper {
+			x.Neg(x)
+		}
+		return x.SetPrec(p)
+	}
+
+	// newton expects a guess that is accurate to its precision, so
+	// we polish the estimate with a few low precision steps, going
+	// halfway to the ends of (0, 1) whenever a step would leave it.
+	for i := 0; i < 100; i++ {
+		d := f(t)
+		u := new(big.Float).Sub(t, d)
+		switch {
+		case u.Sign() <= 0:
+			t.SetMantExp(t, -1)
+		case u.Cmp(one) >= 0:
+			t.SetPrec(exactSumPrec(t, one)).Add(t, one)
+			t.SetMantExp(t, -1)
+		default:
+			t = u
+		}
+		if d.Sign() == 0 || !d.IsInf() && d.MantExp(nil)-t.MantExp(nil) < -50 {
+			break
+		}
+	}
+
+	t = newton(f, t, prec+64)
+	if swap {
+		t.SetPrec(exactSumPrec(t, one)).Sub(one, t)
+	}
+	return t.SetPrec(prec)
+}
+
+// betaIncCheck panics if a or b are not positive and finite, or if x
+// is not in [0, 1], using fname in the panic message.
+func betaIncCheck(a, b, x *big.Float, fname string) {
+	switch {
+	case a.Sign() <= 0 || a.IsInf() || b.Sign() <= 0 || b.IsInf():
+		panic(fname + ": parameter is not positive and finite")
+	case x.Sign() < 0 || x.Cmp(big.NewFloat(1)) > 0:
+		panic(fname + ": argument is not in [0, 1]")
+	}
+}
+
+// betaInc returns Iₓ(a, b), or 1 - Iₓ(a, b) if upper is true, for
+// a, b > 0 and 0 < x < 1, computed to prec bits of precision; and the
+// factor x^a·(1-x)^b/B(a, b), which is x(1-x) times the derivative
+// of Iₓ(a, b).
+func betaInc(a, b, x *big.Float, upper bool, prec uint) (*big.Float, *big.Float) {
+
+	// Following Numerical Recipes, 6.4, the continued fraction
+	// converges quickly for x < (a+1)/(a+b+2), and otherwise we use
+	//     Iₓ(a, b) = 1 - I₁₋ₓ(b, a)
+	// Computing 1 - I loses as many bits as the result is smaller
+	// than 1, so we need that many extra bits.
+	one := big.NewFloat(1)
+	y := new(big.Float).SetPrec(exactSumPrec(x, one)).Sub(one, x)
+	t := new(big.Float).SetPrec(64).Add(a, b)
+	t.Add(t, big.NewFloat(2))
+	t.Mul(t, x)
+	swap := t.Cmp(new(big.Float).Add(a, one)) > 0
+	if swap {
+		a, b, x, y = b, a, y, x
+	}
+
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		f := betaIncFactor(a, b, x, y, wprec)
+		r := new(big.Float).SetPrec(wprec).Mul(f, betaIncFrac(a, b, x, wprec))
+		r.Quo(r, a)
+
+		if swap == upper {
+			return r.SetPrec(prec), f.SetPrec(prec)
+		}
+		r.Sub(one, r)
+		if lost := lostBits(r, 1, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec), f.SetPrec(prec)
+	}
+}
+
+// betaIncFactor returns x^a·y^b/B(a, b), for a, b > 0 and x, y > 0,
+// computed to prec bits of precision.
+func betaIncFactor(a, b, x, y *big.Float, prec uint) *big.Float {
+
+	// x^a·y^b/B(a, b) = exp(a·log(x) + b·log(y) - log B(a, b))
+	//
+	// The absolute error on the argument of exp becomes a relative
+	// error in the result, so we need as many extra bits as its
+	// magnitude.
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+		lb, _, scale := logBeta(a, b, wprec)
+		t := Log(new(big.Float).SetPrec(wprec).Set(x))
+		t.Mul(t, a)
+		u := Log(new(big.Float).SetPrec(wprec).Set(y))
+		u.Mul(u, b)
+		for _, e := range []int{t.MantExp(nil), u.MantExp(nil)} {
+			if e > scale {
+				scale = e
+			}
+		}
+		if scale > int(guard) {
+			guard = uint(scale)
+			continue
+		}
+		t.Add(t, u)
+		t.Sub(t, lb)
+		return Exp(t).SetPrec(prec)
+	}
+}
+
+// betaIncFrac returns the continued fraction
+//
+//	1/(1 + d1/(1 + d2/(1 + ...)))
+//
+// with d_(2m+1) = -(a+m)(a+b+m)x/((a+2m)(a+2m+1)) and
+// d_(2m) = m(b-m)x/((a+2m-1)(a+2m)), computed to prec bits of
+// precision, so that Iₓ(a, b) = x^a·(1-x)^b/(a·B(a, b)) times the
+// fraction.
+func betaIncFrac(a, b, x *big.Float, prec uint) *big.Float {
+
+	// modified Lentz's method, where we replace the denominators
+	// that vanish with a tiny number
+	one := big.NewFloat(1)
+	tiny := new(big.Float).SetMantExp(one, -4*int(prec))
+	fix := func(z *big.Float) {
+		if z.Sign() == 0 || z.MantExp(nil) < -4*int(prec) {
+			z.Set(tiny)
+		}
+	}
+
+	ab := new(big.Float).SetPrec(prec).Add(a, b)
+	c := big.NewFloat(1).SetPrec(prec)
+	d := new(big.Float).SetPrec(prec)
+	h := new(big.Float).SetPrec(prec)
+	dn := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	u := new(big.Float).SetPrec(prec)
+	step := func() {
+		d.Mul(d, dn)
+		d.Add(d, one)
+		fix(d)
+		d.Quo(one, d)
+		c.Quo(dn, c)
+		c.Add(c, one)
+		fix(c)
+		t.Mul(c, d)
+		h.Mul(h, t)
+	}
+
+	// the first step, with d1 = -(a+b)x/(a+1)
+	d.Mul(ab, x)
+	d.Quo(d, u.Add(a, one))
+	d.Sub(one, d)
+	fix(d)
+	h.Quo(one, d)
+	d.Set(h)
+
+	for m := int64(1); ; m++ {
+		// d_(2m) = m(b-m)x/((a+2m-1)(a+2m))
+		dn.SetInt64(m)
+		dn.Sub(b, dn)
+		dn.Mul(dn, x)
+		dn.Mul(dn, t.SetInt64(m))
+		u.SetInt64(2*m - 1)
+		u.Add(u, a)
+		dn.Quo(dn, u)
+		u.Add(u, one)
+		dn.Quo(dn, u)
+		step()
+
+		// d_(2m+1) = -(a+m)(a+b+m)x/((a+2m)(a+2m+1))
+		dn.SetInt64(m)
+		dn.Add(dn, a)
+		dn.Mul(dn, x)
+		dn.Mul(dn, t.Add(ab, t.SetInt64(m)))
+		dn.Neg(dn)
+		u.SetInt64(2 * m)
+		u.Add(u, a)
+		dn.Quo(dn, u)
+		u.Add(u, one)
+		dn.Quo(dn, u)
+		step()
+
+		if t.Sub(t, one); t.Sign() == 0 || t.MantExp(nil) < -int(prec) {
+			return h
+		}
+	}
+}
+
+// betaIncInvGuess returns rough estimates of the value x such that
+// Iₓ(a, b) = y, or 1 - Iₓ(a, b) = y if upper is true, for
+// 0 < y <= 1/2, and of 1 - x.
+func betaIncInvGuess(a, b, y *big.Float, upper bool) (*big.Float, *big.Float) {
+
+	af, _ := a.Float64()
+	bf, _ := b.Float64()
+	ly, _ := Log(new(big.Float).SetPrec(64).Set(y)).Float64()
+	lb, _ := LogBeta(new(big.Float).SetPrec(64).Set(a), b)
+	lbf, _ := lb.Float64()
+
+	// Following Numerical Recipes, 6.4, for a, b >= 1 and y not too
+	// small we use the normal approximation of Abramowitz and Stegun,
+	// 26.5.22, with the quantile from 26.2.22.
+	if af >= 1 && bf >= 1 && ly > -600 {
+		s := math.Sqrt(-2 * ly)
+		z := (2.30753+s*0.27061)/(1+s*(0.99229+s*0.04481)) - s
+		if !upper {
+			z = -z
+		}
+		l := (z*z - 3) / 6
+		h := 2 / (1/(2*af-1) + 1/(2*bf-1))
+		w := z*math.Sqrt(h+l)/h - (1/(2*bf-1)-1/(2*af-1))*(l+5.0/6-2/(3*h))
+		e := bf * math.Exp(2*w)
+		if x, s := af/(af+e), e/(af+e); x > 0 && s > 0 {
+			return big.NewFloat(x), big.NewFloat(s)
+		}
+	}
+
+	// Iₓ(a, b) ~ x^a/(a·B(a, b)) for small x, and 1 - Iₓ(a, b) ~
+	// (1-x)^b/(b·B(a, b)) for x close to 1.
+	if !upper {
+		l := math.Min((ly+lbf+math.Log(af))/af, -1.0/64)
+		return Exp(big.NewFloat(l)), big.NewFloat(-math.Expm1(l))
+	}
+	l := math.Min((ly+lbf+math.Log(bf))/bf, -1.0/64)
+	return big.NewFloat(-math.Expm1(l)), Exp(big.NewFloat(l))
+}
@@ -0,0 +1,198 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestBetaInc(t *testing.T) {
+	for _, test := range []struct {
+		a, b, x string
+		want    string
+	}{
+		{"1", "2", "0.25", "4.37500000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e-1"},
+		{"0.5", "0.5", "0.875", "7.69946543837384114786219432294857106990088604729285897944125012554473006333070707527801201672307172745686978787766168270102229731178502839453317551939902222499202428068643553167208117212793542763460015791812043216543304689610455719506389257255861761932146688212191466896755592204325653119740594275228907103218443219233119743613022048883962817081712274845680191e-1"},
+		{"3", "4.5", "0.0009765625", "2.49071362238743328497133885796233595217934863833347185919866331356542353146000877902171832472646518125059695570531625594130524542900470188009760110828574333577769557359693265709652528928017833044907795128459341740269472299931513779798898323334209779324527987716156754374516942497124684956872717779294831945512955222913690298151666571617619517417026982346534912e-8"},
+		{"100", "200", "0.375", "9.35141764657422187349963952245692780550365101950794532679215056526343032596728678665481633613339094399933959972749860547643213297087569273654996681543307559893296405300179498066156072446842640994663721963930826686878699317636161808923124956576962438086890888840945634912458479165608365209358583089119121010229515626727419023833776261763382746014864521157794104e-1"},
+		{"2", "3", "0.9990234375", "9.99999996277438185643404722213745117187500000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e-1"},
+		{"0.125", "10", "0.5", "9.99970657929264615541439876446819503421683513005679948650952990524747729052222450544106476810739174127373531490014914758827597555955019560060986806541392818824838512385480711401187539925019822895819396227225899082527706462232748034332194595794945217747905459861759660097396788413323464337139451519141107808938080369862970565906046913519144828017603562528190935e-1"},
+		{"2.5", "0.75", "0.5", "1.24082402581686743031068632220019273011857779810387715071181095187648572432703673009668230262206904431194186469523781738784644844938197094194923053140670397983409400548938350662496291189162978010610877767045231523096253221347603990188180233827330254954720564630827358953326870104536818641391281073159348566631767207951380624325083885058844627806790018739927397e-1"},
+		{"50", "60", "0.375", "4.51655587140023562975679549190584115764586693435689520521499630859107529698537201741230513960364778489294428504589241533534342509414670825710587950762851539713093731605671181009374901839246130767093031545669065793271042034715864522764447385278582360889342373019730886055541080467082976079851164286083076149225234985351562500000000000000000000000000000000000000e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.BetaInc(a, b, x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, BetaInc(%v, %v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestBetaIncInv(t *testing.T) {
+	for _, test := range []struct {
+		a, b, y string
+		want    string
+	}{
+		{"2", "3", "0.5", "3.85727568132389548275502751157353267177904177240698679204590237744513638276557663519962531464965265117812946249013562435983483346408898544269321224253233301951141779354166441109142822912204392138971857372256000755848901393572134278326538208464695509293104739611492294553671208446266713411855713035980077748539495127447836977504996573998496404364744706813636368e-1"},
+		{"0.5", "0.5", "0.125", "3.80602337443566219359084053016058565887916870681787569424511343597324962494488206425800325748277019510184871088760584845654112100478992862333890002210860508030813133536430970283114099927632756971974034681273666183980492883732833620493350800678258914522595296464992884235869627574626714619772666682331173037672869394933362130815578273443889160642461647445463033e-2"},
+		{"10", "20", "1p-3000", "9.13762191062818444147325470054577338931344125553986741839753054498581796672926810305567468431916678790020253043802800665882958719786216727634595723692283468876353741097877212288171608420233678684161238756012364961274705432609267545420381843573773324172811426101950738598028723037526099964949635748652949682329921134150137716839213439605947369545009016505678590e-92"},
+		{"3", "0.5", "0.9375", "9.98887239069489736080837202950244941180457362673291815491345949068881893424836907033083388242875795230626375136820419634873123004471044769074213443467741362983153336868586951891742201487594345024324493167673024474927592163361652569486293812186242742740299591001035719000132929581918982733029647917615470057302821368765047818656586544591811859468203997323433549e-1"},
+		{"50", "60", "0.25", "4.22336001466455112542404016823072371021044213088038019915270015699550986942695291044339966683935450364810541565413557808832387878013391147090323891003220211311252774681582090602219729772491966696234950379886318910990865824895833140693815037534493343079133972892638814484559682898383675144098195178831294573412367307009912717090739845242054457727286988621309692e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			y := new(big.Float).SetPrec(prec)
+			y.Parse(test.y, 10)
+
+			x := bigfloat.BetaIncInv(a, b, y)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, BetaIncInv(%v, %v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, test.y, x, want)
+			}
+		}
+	}
+}
+
+func TestBetaIncFloat64(t *testing.T) {
+	// Iₓ(a, 1) = x^a, Iₓ(1, b) = 1 - (1-x)^b, Iₓ(a, b) + I₁₋ₓ(b, a) = 1
+	for _, x := range []float64{0.0625, 0.25, 0.5, 0.875, 0.9921875} {
+		for _, a := range []float64{0.25, 3, 12.5} {
+			p, _ := bigfloat.BetaInc(big.NewFloat(a), big.NewFloat(1), big.NewFloat(x)).Float64()
+			if want := math.Pow(x, a); math.Abs(p-want) > 1e-15*want {
+				t.Errorf("BetaInc(%g, 1, %g) = %g; want %g", a, x, p, want)
+			}
+			q, _ := bigfloat.BetaInc(big.NewFloat(1), big.NewFloat(a), big.NewFloat(x)).Float64()
+			if want := -math.Expm1(a * math.Log1p(-x)); math.Abs(q-want) > 1e-15*want {
+				t.Errorf("BetaInc(1, %g, %g) = %g; want %g", a, x, q, want)
+			}
+			r, _ := bigfloat.BetaInc(big.NewFloat(a), big.NewFloat(2.5), big.NewFloat(x)).Float64()
+			s, _ := bigfloat.BetaInc(big.NewFloat(2.5), big.NewFloat(a), big.NewFloat(1-x)).Float64()
+			if math.Abs(r+s-1) > 1e-15 {
+				t.Errorf("BetaInc(%g, 2.5, %g) + BetaInc(2.5, %g, %g) = %g; want 1", a, x, a, 1-x, r+s)
+			}
+		}
+	}
+}
+
+func TestBetaIncInvTail(t *testing.T) {
+	// BetaInc(a, b, BetaIncInv(a, b, y)) = y for y far below the
+	// float64 range
+	const prec = 200
+	for _, ab := range [][2]float64{{0.5, 3}, {3, 3}, {40, 0.25}} {
+		a := big.NewFloat(ab[0]).SetPrec(prec)
+		b := big.NewFloat(ab[1]).SetPrec(prec)
+		for _, s := range []string{"1e-400", "1e-5000"} {
+			y, _, _ := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+			x := bigfloat.BetaIncInv(a, b, y)
+			d := new(big.Float).Quo(bigfloat.BetaInc(a, b, x), y)
+			d.Sub(d, big.NewFloat(1))
+			if d.Sign() != 0 && d.MantExp(nil) > -prec+16 {
+				t.Errorf("BetaInc(%v, %v, BetaIncInv(%v, %v, %s)) has relative error %g", a, b, a, b, s, d)
+			}
+		}
+	}
+}
+
+func TestBetaIncInvUpperTail(t *testing.T) {
+	// Iₓ(1, 1/2) = 1 - √(1-x), so BetaIncInv(1, 1/2, 1 - 2**-n) = 1 - 2**-2n
+	one := big.NewFloat(1)
+	for _, n := range []int{40, 500, 5000} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			e := new(big.Float).SetMantExp(one, -n)
+			y := new(big.Float).SetPrec(prec).Sub(one, e)
+			if y.Cmp(one) == 0 {
+				continue
+			}
+			e.SetMantExp(one, -2*n)
+			want := new(big.Float).SetPrec(prec).Sub(one, e)
+			x := bigfloat.BetaIncInv(one, big.NewFloat(0.5), y)
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, BetaIncInv(1, 0.5, 1 - 2**-%d) =\ngot  %g;\nwant %g", prec, n, x, want)
+			}
+		}
+	}
+
+	// 1 - BetaInc(a, b, BetaIncInv(a, b, y)) = 1 - y for y close to 1
+	const prec = 1200
+	for _, test := range []struct {
+		a, b float64
+		y    string
+	}{
+		{2, 0.5, "1e-9"},
+		{2, 2, "1e-300"},
+		{0.25, 40, "1e-100"},
+	} {
+		a := big.NewFloat(test.a).SetPrec(prec)
+		b := big.NewFloat(test.b).SetPrec(prec)
+		s, _, _ := big.ParseFloat(test.y, 10, prec, big.ToNearestEven)
+		y := new(big.Float).SetPrec(prec).Sub(one, s)
+		x := bigfloat.BetaIncInv(a, b, y)
+		d := new(big.Float).Sub(one, bigfloat.BetaInc(a, b, x))
+		d.Quo(d, s)
+		d.Sub(d, one)
+		if d.Sign() != 0 && d.MantExp(nil) > -64 {
+			t.Errorf("1 - BetaInc(%v, %v, BetaIncInv(%v, %v, 1 - %s)) has relative error %g", a, b, a, b, test.y, d)
+		}
+	}
+}
+
+func TestBetaIncSpecialValues(t *testing.T) {
+	one, zero, a := big.NewFloat(1), big.NewFloat(0), big.NewFloat(2.5)
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"BetaInc(2.5, 2.5, 0)", bigfloat.BetaInc(a, a, zero), 0},
+		{"BetaInc(2.5, 2.5, 1)", bigfloat.BetaInc(a, a, one), 1},
+		{"BetaInc(2.5, 2.5, 0.5)", bigfloat.BetaInc(a, a, big.NewFloat(0.5)), 0.5},
+		{"BetaIncInv(2.5, 2.5, 0)", bigfloat.BetaIncInv(a, a, zero), 0},
+		{"BetaIncInv(2.5, 2.5, 1)", bigfloat.BetaIncInv(a, a, one), 1},
+		{"BetaIncInv(2, 0.5, 1 - 1e-9)", bigfloat.BetaIncInv(big.NewFloat(2), big.NewFloat(0.5), big.NewFloat(1-1e-9)), 1},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkBetaInc(b *testing.B) {
+	p, q := big.NewFloat(2.5), big.NewFloat(4)
+	for _, prec := range []uint{1e2, 1e3} {
+		x := big.NewFloat(0.375).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.BetaInc(p, q, x)
+			}
+		})
+	}
+}
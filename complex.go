@@ -0,0 +1,584 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// A Complex represents a multi-precision complex number re + im·i,
+// where both the real and imaginary parts are big.Float values.
+//
+// The precision of a Complex is the largest of the precisions of
+// its parts. As for big.Float, the zero value for a Complex is 0
+// with precision 0, and operations on a Complex with precision 0
+// set it to the largest precision of the operands.
+type Complex struct {
+	re, im big.Float
+}
+
+// NewComplex returns a new Complex with real part re and imaginary
+// part im. The values are copied, and both parts are set to the
+// largest of the two precisions.
+func NewComplex(re, im *big.Float) *Complex {
+	prec := re.Prec()
+	if im.Prec() > prec {
+		prec = im.Prec()
+	}
+	z := new(Complex)
+	z.re.SetPrec(prec).Set(re)
+	z.im.SetPrec(prec).Set(im)
+	return z
+}
+
+// Real returns the real part of z. The result is a reference to
+// z's real part; it may change if a new value is assigned to z.
+func (z *Complex) Real() *big.Float {
+	return &z.re
+}
+
+// Imag returns the imaginary part of z. The result is a reference
+// to z's imaginary part; it may change if a new value is assigned
+// to z.
+func (z *Complex) Imag() *big.Float {
+	return &z.im
+}
+
+// Prec returns the precision of z in bits.
+func (z *Complex) Prec() uint {
+	if z.im.Prec() > z.re.Prec() {
+		return z.im.Prec()
+	}
+	return z.re.Prec()
+}
+
+// SetPrec sets the precision of both parts of z to prec and
+// returns the (possibly) rounded value of z.
+func (z *Complex) SetPrec(prec uint) *Complex {
+	z.re.SetPrec(prec)
+	z.im.SetPrec(prec)
+	return z
+}
+
+// Set sets z to the (possibly rounded) value of x and returns z. If
+// z's precision is 0, it is changed to the precision of x.
+func (z *Complex) Set(x *Complex) *Complex {
+	if z != x {
+		prec := z.resultPrec(x, x)
+		z.re.SetPrec(prec).Set(&x.re)
+		z.im.SetPrec(prec).Set(&x.im)
+	}
+	return z
+}
+
+// Neg sets z to the (possibly rounded) value of x with both parts
+// negated, and returns z.
+func (z *Complex) Neg(x *Complex) *Complex {
+	prec := z.resultPrec(x, x)
+	z.re.SetPrec(prec).Neg(&x.re)
+	z.im.SetPrec(prec).Neg(&x.im)
+	return z
+}
+
+// Conj sets z to the (possibly rounded) complex conjugate of x and
+// returns z.
+func (z *Complex) Conj(x *Complex) *Complex {
+	prec := z.resultPrec(x, x)
+	z.re.SetPrec(prec).Set(&x.re)
+	z.im.SetPrec(prec).Neg(&x.im)
+	return z
+}
+
+// Add sets z to the rounded sum x+y and returns z. If z's precision
+// is 0, it is changed to the larger of x's or y's precision before
+// the operation.
+func (z *Complex) Add(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+	z.re.SetPrec(prec).Add(&x.re, &y.re)
+	z.im.SetPrec(prec).Add(&x.im, &y.im)
+	return z
+}
+
+// Sub sets z to the rounded difference x-y and returns z. Precision
+// is handled as in Add.
+func (z *Complex) Sub(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+	z.re.SetPrec(prec).Sub(&x.re, &y.re)
+	z.im.SetPrec(prec).Sub(&x.im, &y.im)
+	return z
+}
+
+// Mul sets z to the rounded product x*y and returns z. Precision is
+// handled as in Add. Both parts of the result are correctly rounded.
+// A zero part times an infinite one counts as 0, so that for
+// instance (+Inf)·i = +Inf·i; Mul panics with ErrNaN if a part of
+// the result is the sum of two infinities of opposite sign.
+func (z *Complex) Mul(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+
+	// The four partial products are computed exactly, so that the
+	// only rounding happens in the final sums.
+	ep := x.Prec() + y.Prec()
+	ac := mulZeroInf(new(big.Float).SetPrec(ep), &x.re, &y.re)
+	bd := mulZeroInf(new(big.Float).SetPrec(ep), &x.im, &y.im)
+	ad := mulZeroInf(new(big.Float).SetPrec(ep), &x.re, &y.im)
+	bc := mulZeroInf(new(big.Float).SetPrec(ep), &x.im, &y.re)
+
+	z.re.SetPrec(prec).Sub(ac, bd)
+	z.im.SetPrec(prec).Add(ad, bc)
+	return z
+}
+
+// Quo sets z to the rounded quotient x/y and returns z. Precision
+// is handled as in Add. Infinities are handled as in Mul, and x/y is
+// 0 when x is finite and y infinite. Quo panics if y is zero or if
+// both x and y are infinite.
+func (z *Complex) Quo(x, y *Complex) *Complex {
+	xInf, yInf := x.re.IsInf() || x.im.IsInf(), y.re.IsInf() || y.im.IsInf()
+	switch {
+	case y.re.Sign() == 0 && y.im.Sign() == 0:
+		panic("Quo: division by zero")
+	case xInf && yInf:
+		panic("Quo: division of infinity by infinity")
+	}
+
+	prec := z.resultPrec(x, y)
+	if yInf {
+		z.re.SetPrec(prec).SetInt64(0)
+		z.im.SetPrec(prec).SetInt64(0)
+		return z
+	}
+
+	// (a + bi)/(c + di) = ((ac + bd) + (bc - ad)i) / (c² + d²)
+	//
+	// As in Mul, the numerators are computed exactly.
+	ep := x.Prec() + y.Prec()
+	ac := mulZeroInf(new(big.Float).SetPrec(ep), &x.re, &y.re)
+	bd := mulZeroInf(new(big.Float).SetPrec(ep), &x.im, &y.im)
+	ad := mulZeroInf(new(big.Float).SetPrec(ep), &x.re, &y.im)
+	bc := mulZeroInf(new(big.Float).SetPrec(ep), &x.im, &y.re)
+
+	wprec := prec + 64 // guard digits
+	den := new(big.Float).SetPrec(wprec).Mul(&y.re, &y.re)
+	den.Add(den, new(big.Float).SetPrec(wprec).Mul(&y.im, &y.im))
+
+	re := new(big.Float).SetPrec(wprec).Add(ac, bd)
+	im := new(big.Float).SetPrec(wprec).Sub(bc, ad)
+
+	z.re.SetPrec(prec).Quo(re, den)
+	z.im.SetPrec(prec).Quo(im, den)
+	return z
+}
+
+// mulZeroInf sets z to the rounded product x*y and returns z, with
+// 0·Inf taken to be a zero with the sign of the product.
+func mulZeroInf(z, x, y *big.Float) *big.Float {
+	if x.Sign() == 0 || y.Sign() == 0 {
+		return setSign(z.SetInt64(0), x.Signbit() != y.Signbit())
+	}
+	return z.Mul(x, y)
+}
+
+// Abs returns the absolute value (the modulus) of z. Precision is
+// the same as the one of z.
+func (z *Complex) Abs() *big.Float {
+	prec := z.Prec()
+	x := new(big.Float).SetPrec(prec + 64)
+
+	// Abs(±Inf + yi) = Abs(x ± Inf i) = +Inf
+	if z.re.IsInf() || z.im.IsInf() {
+		return x.SetInf(false).SetPrec(prec)
+	}
+
+	x.Mul(&z.re, &z.re)
+	x.Add(x, new(big.Float).SetPrec(prec+64).Mul(&z.im, &z.im))
+	return x.Sqrt(x).SetPrec(prec)
+}
+
+// Arg returns the argument (the phase) of z, in the range [-π, π].
+// Precision is the same as the one of z. As for math/cmplx.Phase,
+// the sign of a zero imaginary part selects between -π and π when z
+// is a negative real number.
+func (z *Complex) Arg() *big.Float {
+	return arg(&z.re, &z.im, z.Prec())
+}
+
+// String formats z like a complex128 value, as (re+imi), with each
+// part formatted as big.Float's String method does.
+func (z *Complex) String() string {
+	s := "(" + z.re.Text('g', 10)
+	if z.im.Sign() >= 0 && !z.im.Signbit() {
+		s += "+"
+	}
+	return s + z.im.Text('g', 10) + "i)"
+}
+
+// Format implements fmt.Formatter. Each part of z is formatted as
+// big.Float's Format method does, and the result is written as
+// (re+imi), as for complex128 values. The width is ignored.
+func (z *Complex) Format(s fmt.State, format rune) {
+	spec := "%"
+	for _, f := range "-# 0" {
+		if s.Flag(int(f)) {
+			spec += string(f)
+		}
+	}
+	if p, ok := s.Precision(); ok {
+		spec += "." + strconv.Itoa(p)
+	}
+	spec += string(format)
+
+	re := fmt.Sprintf(spec, &z.re)
+	if s.Flag('+') {
+		re = fmt.Sprintf("%+"+spec[1:], &z.re)
+	}
+	im := fmt.Sprintf("%+"+spec[1:], &z.im)
+	fmt.Fprint(s, "(", re, im, "i)")
+}
+
+// resultPrec returns the precision of the result of an operation on
+// x and y with receiver z.
+func (z *Complex) resultPrec(x, y *Complex) uint {
+	if prec := z.Prec(); prec != 0 {
+		return prec
+	}
+	if x.Prec() > y.Prec() {
+		return x.Prec()
+	}
+	return y.Prec()
+}
+
+// ExpComplex returns a Complex representation of exp(z). Precision
+// is the same as the one of the argument. As for math/cmplx.Exp,
+// ExpComplex returns 0 when the real part of z is -Inf, and it
+// panics if the imaginary part of z is infinite and its real part is
+// not -Inf, where math/cmplx.Exp returns NaN.
+func ExpComplex(z *Complex) *Complex {
+	prec := z.Prec()
+
+	if z.im.IsInf() {
+		if !z.re.IsInf() || z.re.Sign() > 0 {
+			panic("ExpComplex: imaginary part is infinite")
+		}
+		// exp(-Inf ± Inf i) = 0 ± 0i
+		w := new(Complex).SetPrec(prec)
+		setSign(&w.im, z.im.Signbit())
+		return w
+	}
+
+	// exp(x + yi) = exp(x)·(cos(y) + i·sin(y))
+	x := Exp(new(big.Float).SetPrec(prec + 64).Set(&z.re))
+	if z.im.Sign() == 0 {
+		return NewComplex(x, &z.im).SetPrec(prec)
+	}
+
+	sin, cos := sincos(new(big.Float).SetPrec(prec + 64).Set(&z.im))
+
+	w := new(Complex).SetPrec(prec)
+	w.re.Mul(x, cos)
+	w.im.Mul(x, sin)
+	return w
+}
+
+// LogComplex returns a Complex representation of the principal
+// value of the natural logarithm of z. Precision is the same as the
+// one of the argument. The imaginary part of the result is in the
+// range [-π, π], with the branch cut along the negative real axis
+// as for math/cmplx.Log. LogComplex returns -Inf + 0i when z = 0.
+func LogComplex(z *Complex) *Complex {
+	prec := z.Prec()
+
+	// log(x + yi) = log(|x + yi|) + arg(x + yi)·i
+	w := new(Complex).SetPrec(prec)
+	w.re.Set(logAbs(&z.re, &z.im, prec))
+	w.im.Set(arg(&z.re, &z.im, prec))
+	return w
+}
+
+// logAbs returns log(|x + yi|), to prec bits of precision.
+func logAbs(x, y *big.Float, prec uint) *big.Float {
+
+	if x.IsInf() || y.IsInf() || x.Sign() == 0 && y.Sign() == 0 {
+		return Log(new(Complex).SetPrec(prec).Set(NewComplex(x, y)).Abs())
+	}
+
+	// the squares are computed exactly
+	xx := new(big.Float).SetPrec(2*x.Prec()).Mul(x, x)
+	yy := new(big.Float).SetPrec(2*y.Prec()).Mul(y, y)
+
+	// log|z| = log(x² + y²)/2
+	s := new(big.Float).SetPrec(prec+64).Add(xx, yy)
+	if exp := s.MantExp(nil); exp < 0 || exp > 1 {
+		s = Log(s)
+		return s.SetMantExp(s, -1).SetPrec(prec)
+	}
+
+	// When |z| is close to 1 the logarithm suffers from
+	// cancellation, so we compute it as
+	//     log|z| = log(1 + d)/2,  d = x² + y² - 1
+	// with d computed exactly, and 1 + d rounded with -log2|d|
+	// extra bits so that it keeps all of d.
+	one := big.NewFloat(1)
+	d := new(big.Float).SetPrec(exactSumPrec(xx, one)).Sub(xx, one)
+	d.SetPrec(exactSumPrec(d, yy)).Add(d, yy)
+
+	wprec := prec + 64
+	if exp := d.MantExp(nil); d.Sign() != 0 && exp < 0 {
+		wprec += uint(-exp)
+	}
+	s.SetPrec(wprec).Add(one, d)
+	s = Log(s)
+	return s.SetMantExp(s, -1).SetPrec(prec)
+}
+
+// exactSumPrec returns a precision large enough for the sum of the
+// finite values x and y to be exact.
+func exactSumPrec(x, y *big.Float) uint {
+	if x.Sign() == 0 {
+		return y.MinPrec() + 1
+	}
+	if y.Sign() == 0 {
+		return x.MinPrec() + 1
+	}
+	ex, ey := x.MantExp(nil), y.MantExp(nil)
+	hi, lo := ex, ex-int(x.MinPrec())
+	if ey > hi {
+		hi = ey
+	}
+	if l := ey - int(y.MinPrec()); l < lo {
+		lo = l
+	}
+	return uint(hi-lo) + 1
+}
+
+// PowComplex returns a Complex representation of z**w, using the
+// principal branch of the logarithm. Precision is the same as the
+// one of the first argument. As for math/cmplx.Pow, Pow(0, w) is 1
+// when Re(w) = 0, +Inf when w < 0, +Inf + Inf·i when Re(w) < 0 and
+// Im(w) ≠ 0, and 0 otherwise.
+func PowComplex(z *Complex, w *Complex) *Complex {
+	prec := z.Prec()
+
+	if z.re.Sign() == 0 && z.im.Sign() == 0 {
+		inf := new(big.Float).SetInf(false)
+		switch {
+		case w.re.Sign() == 0:
+			return NewComplex(big.NewFloat(1), big.NewFloat(0)).SetPrec(prec)
+		case w.re.Sign() < 0 && w.im.Sign() == 0:
+			return NewComplex(inf, big.NewFloat(0)).SetPrec(prec)
+		case w.re.Sign() < 0:
+			return NewComplex(inf, inf).SetPrec(prec)
+		default:
+			return new(Complex).SetPrec(prec)
+		}
+	}
+
+	// Pow(z, w) = exp(w·log(z)).
+	//
+	// The absolute error in w·log(z) becomes a relative error in
+	// the result, so we need as many extra bits as the magnitude
+	// of w·log(z).
+	guard := uint(64)
+	for {
+		wprec := prec + guard
+		x := LogComplex(new(Complex).SetPrec(wprec).Set(z))
+		x.Mul(x, w)
+		exp := x.re.MantExp(nil)
+		if e := x.im.MantExp(nil); e > exp {
+			exp = e
+		}
+		if exp <= 0 || uint(exp) <= guard-64 {
+			return new(Complex).SetPrec(prec).Set(ExpComplex(x))
+		}
+		guard = 64 + uint(exp)
+	}
+}
+
+// SqrtComplex returns a Complex representation of the principal
+// square root of z. Precision is the same as the one of the
+// argument. The real part of the result is non-negative, and the
+// branch cut is along the negative real axis, as for
+// math/cmplx.Sqrt, including for infinite parts.
+func SqrtComplex(z *Complex) *Complex {
+	prec := z.Prec()
+	w := new(Complex).SetPrec(prec)
+
+	switch {
+	// Sqrt(0) = 0, with the sign of the imaginary part preserved
+	case z.re.Sign() == 0 && z.im.Sign() == 0:
+		w.im.Set(&z.im)
+		return w
+
+	// Sqrt(x ± Inf i) = +Inf ± Inf i
+	case z.im.IsInf():
+		w.re.SetInf(false)
+		w.im.Set(&z.im)
+		return w
+	}
+
+	// For z = x + yi, let
+	//     t = √((|z| + |x|)/2)
+	// then
+	//     √z = t + (y/2t)i           if x >= 0
+	//     √z = |y|/2t + sign(y)·ti   if x < 0
+	wprec := prec + 64
+	t := new(Complex).SetPrec(wprec).Set(z).Abs()
+	t.Add(t, new(big.Float).Abs(&z.re))
+	t.SetMantExp(t, -1)
+	t.Sqrt(t)
+
+	u := new(big.Float).SetPrec(wprec).Quo(&z.im, t)
+	u.SetMantExp(u, -1)
+
+	if z.re.Sign() >= 0 {
+		w.re.Set(t)
+		w.im.Set(u)
+	} else {
+		w.re.Abs(u)
+		w.im.Set(t)
+		if z.im.Signbit() {
+			w.im.Neg(&w.im)
+		}
+	}
+	return w
+}
+
+// arg returns the argument of x + yi, to prec bits of precision.
+func arg(x, y *big.Float, prec uint) *big.Float {
+
+	// Special values, following math.Atan2
+	switch {
+	case y.Sign() == 0:
+		if x.Signbit() {
+			return setSign(pi(prec), y.Signbit())
+		}
+		return new(big.Float).SetPrec(prec).Set(y)
+	case x.Sign() == 0:
+		halfPi := pi(prec)
+		halfPi.SetMantExp(halfPi, -1)
+		return setSign(halfPi, y.Signbit())
+	case x.IsInf() || y.IsInf():
+		// arg(±Inf ± Inf i) = ±π/4 or ±3π/4
+		// arg(x ± Inf i) = ±π/2
+		// arg(+Inf + yi) = ±0
+		// arg(-Inf + yi) = ±π
+		var q int64
+		switch {
+		case x.IsInf() && y.IsInf():
+			q = 1
+			if x.Sign() < 0 {
+				q = 3
+			}
+		case y.IsInf():
+			q = 2
+		case x.Sign() > 0:
+			return setSign(new(big.Float).SetPrec(prec), y.Signbit())
+		default:
+			q = 4
+		}
+		t := pi(prec + 2)
+		t.Mul(t, big.NewFloat(float64(q)/4))
+		return setSign(t.SetPrec(prec), y.Signbit())
+	}
+
+	// If x < 0 we use
+	//     arg(x + yi) = arg(-x - yi) ± π
+	if x.Sign() < 0 {
+		t := arg(new(big.Float).Neg(x), new(big.Float).Neg(y), prec+64)
+		p := pi(prec + 64)
+		if y.Sign() > 0 {
+			t.Add(t, p)
+		} else {
+			t.Sub(t, p)
+		}
+		return t.SetPrec(prec)
+	}
+
+	// Now x > 0, and we compute arg(x + yi) as the imaginary part
+	// of log(x + yi), using a complex version of the AGM formula
+	// used by Log:
+	//     log(z) = π / (2 * AGM(1, 4/z))
+	// which is valid if |z| >= 2**(prec/2). Since arg(z) does not
+	// change when z is scaled by a positive number, we can scale z
+	// by a power of two instead of squaring it as Log does.
+	//
+	// The AGM gives an absolute error on arg(z), so when |y| is much
+	// smaller than x we need more guard bits to get a good relative
+	// error on the result.
+	ex, ey := x.MantExp(nil), y.MantExp(nil)
+	guard := uint(64)
+	if ex > ey {
+		guard += uint(ex - ey)
+	}
+	wprec := prec + guard
+
+	// scale z so that the larger of x and |y| is 2**(wprec/2+1)
+	scale := int(wprec/2) + 1 - ex
+	if ey > ex {
+		scale = int(wprec/2) + 1 - ey
+	}
+	z := new(Complex).SetPrec(wprec)
+	z.re.SetMantExp(x, scale)
+	z.im.SetMantExp(y, scale)
+
+	one := NewComplex(big.NewFloat(1), big.NewFloat(0)).SetPrec(wprec)
+	four := NewComplex(big.NewFloat(4), big.NewFloat(0)).SetPrec(wprec)
+
+	m := agmComplex(one, z.Quo(four, z)) // m = AGM(1, 4/z)
+
+	// Im(π/2m) = -π·Im(m) / 2|m|²
+	t := new(big.Float).SetPrec(wprec).Mul(&m.re, &m.re)
+	t.Add(t, new(big.Float).SetPrec(wprec).Mul(&m.im, &m.im))
+	t.SetMantExp(t, 1)
+	t.Quo(pi(wprec), t)
+	t.Mul(t, &m.im)
+	return t.Neg(t).SetPrec(prec)
+}
+
+// agmComplex returns the arithmetic-geometric mean of a and b, which
+// must have the same precision and lie in the right half-plane. The
+// principal square root is then always the right choice for the
+// geometric mean.
+func agmComplex(a, b *Complex) *Complex {
+
+	prec := a.Prec()
+
+	// do not overwrite a and b
+	a2 := new(Complex).SetPrec(prec + 64).Set(a)
+	b2 := new(Complex).SetPrec(prec + 64).Set(b)
+
+	half := NewComplex(big.NewFloat(0.5), big.NewFloat(0))
+	t := new(Complex).SetPrec(prec + 64)
+
+	for {
+		// stop when |a2 - b2| < 2**(-prec) |a2|
+		t.Sub(a2, b2)
+		if t.re.Sign() == 0 && t.im.Sign() == 0 {
+			break
+		}
+		lim := a2.re.MantExp(nil)
+		if e := a2.im.MantExp(nil); a2.im.Sign() != 0 && e > lim {
+			lim = e
+		}
+		lim -= int(prec + 1)
+		if (t.re.Sign() == 0 || t.re.MantExp(nil) < lim) &&
+			(t.im.Sign() == 0 || t.im.MantExp(nil) < lim) {
+			break
+		}
+
+		t.Set(a2)
+		a2.Add(a2, b2).Mul(a2, half)
+		b2.Set(SqrtComplex(b2.Mul(b2, t)))
+	}
+
+	return a2.SetPrec(prec)
+}
+
+// setSign sets the sign of x to negative if neg is true, and to
+// positive otherwise, and returns x.
+func setSign(x *big.Float, neg bool) *big.Float {
+	if x.Signbit() != neg {
+		x.Neg(x)
+	}
+	return x
+}
@@ -0,0 +1,304 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func parseComplex(re, im string, prec uint) *bigfloat.Complex {
+	x, _, _ := new(big.Float).SetPrec(prec).Parse(re, 10)
+	y, _, _ := new(big.Float).SetPrec(prec).Parse(im, 10)
+	return bigfloat.NewComplex(x, y)
+}
+
+func TestExpComplex(t *testing.T) {
+	for _, test := range []struct {
+		x, y   string
+		re, im string
+	}{
+		{"1", "2", "-1.13120438375681363843125525551079471062886799582652575021772191041650191661022617604706376531595841696426622521616989577359262364733009371773098714075344682287912166003949881300643364945340022475315864506992343418533343692232098894647028194682655234133712641671422426171995780566502174425203447962962260174429524410870206411638597021721081291299266325546765662e+0", "2.47172667200481892761693089355166453273619036924100818420075883527783966081131120407871935043556923532704426513264987636197909753690690107081466369835555302017248369368228232636190692448867200056561804730636721778666325814414558240473812284083608278807068316088391285167016986831937634328292823119000271845526682471714005880575416247343044004150764493972971506e+0"},
+		{"-0.5", "100", "5.23022834491303024331101116846678774996151564237894986334998605936873939871049827127243841071398975470928728612064205838032328361092985037861512091509069348268681390655204224397927689784648340754973272389348427858093589934302455172548995631777699931143517690885992533554883205255929120661896972721600839651781730880916654107868491299215741655292274520111233719e-1", "-3.07126286358112572866937173609045615672471468650034883635881273144540109520450051680981209383200278580592310844098664251665531903193238969492162464999274138579008011726933952384371976016521048697581722576922520337128209490904432555403352110276308229780576031410043265722462922654765339121428612252113865421069459585456504426262696324574885711045394912040714058e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := parseComplex(test.re, test.im, prec)
+			z := parseComplex(test.x, test.y, prec)
+
+			x := bigfloat.ExpComplex(z)
+
+			if x.Real().Cmp(want.Real()) != 0 || x.Imag().Cmp(want.Imag()) != 0 {
+				t.Errorf("prec = %d, ExpComplex(%v+%vi) =\ngot  %g;\nwant %g", prec, test.x, test.y, x, want)
+			}
+		}
+	}
+}
+
+func TestLogComplex(t *testing.T) {
+	for _, test := range []struct {
+		x, y   string
+		re, im string
+	}{
+		{"1", "2", "8.04718956217050187300379666613093819762800677134258860956323945737089493853828882315066939046589805399983151510857781449862002614662338099816808308731852863776089818748591622826746428101170762528635077596800439888694862844096770356383077365610904763974260646410679029861283836142643620230794724089182335664336999212318879796594711921967176725525487527227097370e-1", "1.10714871779409050301706546017853704007004764540143264667653920743371033897736279401341712868617064143454419100545031581004110412315027996039114913412013493800580578518608915902027706632354867194833709304692725054642792914622530691740937762679741583947780265015523630215061743124555113959502866134307161962045112270033007874330987658405073055685503349616091717e+0"},
+		{"-3", "0.5", "1.11231177576216691276681571405754701387453865922356282770177633110310125152548581709364743983966564284890299512487714795920526941346384681622243528281043709556834324025374375516084742295747091521454491587740627264393176107671370715416725978426103060433475372598935742695111468393795738720455883363441779557294953779241816399093454712950981880110623960573177953e+0", "2.97644397617516640018351509363555943019878273891007800195691016229667074410640385715314768744341696009215152245952230642422485785833073030966802715559169807718036379873086012288867666583106743025781774469055375358267328545614159798133667523069787105083698316659998649829590478887712319844401041662844861458401153984489345641339426010800873615572199681189389828e+0"},
+		{"1p-100", "-1", "3.11150763893057085357203202689006212029512608436058356655055733924687070468812351608431408997489491592368528257984486912202433894290847579604890933522684890242260714469935610668816496602193249043526194012754623277011032004646071453356415863492121868513700209202030159558764645549340724309397245069363127814618990785597152428388236156368923930309043391065826473e-61", "-1.57079632679489661923132169163896258119336368788214118192218950992423499670799547630924713390403092033433159502650021848975611040394914153251099854111581669956804721664785676963484501786854376531649858785219023268753683519293833823249088294397179061937126711535828977971368313546146890299522591638956252217043323398399981218124280697330388351570358315935393011e+0"},
+		{"2", "1p-166", "6.93147180559945309417232121458176568075500134360255254120680009493393621969694715605863326996418687556288823411599123011382814589574892550181545686920059227119800611173779384024534484566991946723273720526024524773936290250670254950135863967586851981493123355750373752970498231525433639763832901032804169373268737057004283884649825648898767795138006899704273724e-1", "5.34552942018439129228107293430296375763039376021009739592387498432079728197426071734099650711868889624749290224245450529913367677977303833684328765712788893510643484531180622458799237163571274819974619659168589126926877059816356704441871762696655128836736231054671512169888830604909310395684470844743415773983839718035091679767584187453546092358531417539717444e-51"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := parseComplex(test.re, test.im, prec)
+			z := parseComplex(test.x, test.y, prec)
+
+			x := bigfloat.LogComplex(z)
+
+			if x.Real().Cmp(want.Real()) != 0 || x.Imag().Cmp(want.Imag()) != 0 {
+				t.Errorf("prec = %d, LogComplex(%v+%vi) =\ngot  %g;\nwant %g", prec, test.x, test.y, x, want)
+			}
+		}
+	}
+}
+
+func TestPowComplex(t *testing.T) {
+	for _, test := range []struct {
+		x, y   string
+		u, v   string
+		re, im string
+	}{
+		{"1", "2", "0.5", "-1", "4.38256505986335901024328967219167751916065544561038163999102536846150361544532063494476702400525598633222455985286686737163494602540335670101610103869190121048830318559379853122596209643800272817904054780641920163343931276541782433960656345784409639765986799686288740370978493100638158249223728829835819639194714132097359537537529972944839882057017783544310926e+0", "-1.12439747736115494623469966791160779009091001743716721072281610432493840958236518201373793909766365296151326683929737384213595845077800118112583755432800554370533912134133706538766738243111895680953430991832512964331486835744097999066089023563119058369828560073283068750060266968912483189174981294121758087769319939270931143522400353814631407984530797366297562e+0"},
+		{"-1.5", "-3", "2.5", "0.25", "2.43661758297982309509289751060304020165354140023668942629484550392644167339796003235507601492573200592786831775436107484876745820231069217276677081586301699830014590767855493281791806812422542616854098804976037647124487667170369989883636579723169437766181500229335165217854283913802438457040988910812233816306291500303403216362966311101552420862714147884935972e+0", "3.41764248381541533090672351035419409866850164410806541067620182199103631684246500945907419922924470586440575793657257305443381910659740326986822873573829362897591498416166756372608227084405285425071195514603482769853113202157626654719366138096985588211736680036393100448857124469001686491116847060505737923030279838496556321224883529324648346007248145658365144e+1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := parseComplex(test.re, test.im, prec)
+			z := parseComplex(test.x, test.y, prec)
+			w := parseComplex(test.u, test.v, prec)
+
+			x := bigfloat.PowComplex(z, w)
+
+			if x.Real().Cmp(want.Real()) != 0 || x.Imag().Cmp(want.Imag()) != 0 {
+				t.Errorf("prec = %d, PowComplex(%v+%vi, %v+%vi) =\ngot  %g;\nwant %g", prec, test.x, test.y, test.u, test.v, x, want)
+			}
+		}
+	}
+}
+
+func TestSqrtComplex(t *testing.T) {
+	for _, test := range []struct {
+		x, y   string
+		re, im string
+	}{
+		{"-3", "4", "1.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e+0", "2.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e+0"},
+		{"2", "-1", "1.45534669022535480812266183970969706985483030680964399687535344718581196326091263498597572477817829461724874658655291931808372561807733370778446339650611286821158004061776800679374609413459945182448285010460309407566051499418126178537499787109751979246680612572725062569234153189040925647384674779907490925979374223272139348132152975095326526004390230416205533e+0", "-3.43560749722512464138565743914558568472714676815569873720051985110795476539620444626606676663753612639524378422412852566031368463555656281399379276144569914557647296843598050412285592021453067552001601651128348037239481051806073389242714186570555987320683393366606033499777700481542212849426987785654441049898643192587227894126198871992110817332239353026092211e-1"},
+		{"-2", "-0.5", "1.75432056376293832279305373306669944543298362764945282634681826099641748059639974456457392137888995730979961676223533776784883495876733683145732170766531596659014165394808119143469468047150853551916220600341520080750500507706082848986587863355482289344296436078213187570138573394309020849964906735652580996519828984069641317548678557386061980694143500980183015e-1", "-1.42505312406394700603851426819237283255376727560294993757499045540598385893863278856136317468895489756561400644581081424759969718817975710764356946117316620934520158502443115894738674483284483991586325738833982648874042433149172300513954981900290181007136723388715586197245829629837533385934129816420203028028669900474029746389350659464885746228197957319665179e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := parseComplex(test.re, test.im, prec)
+			z := parseComplex(test.x, test.y, prec)
+
+			x := bigfloat.SqrtComplex(z)
+
+			if x.Real().Cmp(want.Real()) != 0 || x.Imag().Cmp(want.Imag()) != 0 {
+				t.Errorf("prec = %d, SqrtComplex(%v+%vi) =\ngot  %g;\nwant %g", prec, test.x, test.y, x, want)
+			}
+		}
+	}
+}
+
+func complex128Of(z *bigfloat.Complex) (complex128, big.Accuracy) {
+	re, acc1 := z.Real().Float64()
+	im, acc2 := z.Imag().Float64()
+	if acc1 != big.Exact {
+		return complex(re, im), acc1
+	}
+	return complex(re, im), acc2
+}
+
+func testComplexFloat64(name string, f func(*bigfloat.Complex) *bigfloat.Complex, g func(complex128) complex128, scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		c := complex((rand.Float64()-0.5)*scale, (rand.Float64()-0.5)*scale)
+
+		z := bigfloat.NewComplex(big.NewFloat(real(c)), big.NewFloat(imag(c)))
+		x, acc := complex128Of(f(z))
+
+		want := g(c)
+
+		// As for the real functions, math/cmplx is not completely
+		// accurate, so just require a relative error smaller than
+		// 1e-14.
+		if cmplx.Abs(x-want)/cmplx.Abs(want) > 1e-14 || acc != big.Exact {
+			t.Errorf("%s(%v) =\n got %v (%s);\nwant %v (Exact)", name, c, x, acc, want)
+		}
+	}
+}
+
+// cmplxLog is cmplx.Log, except that when |z| is close to 1, where
+// cmplx.Log loses accuracy, the real part is computed as log(1 + d)/2,
+// with d = x² + y² - 1 computed exactly.
+func cmplxLog(z complex128) complex128 {
+	x, y := big.NewFloat(real(z)), big.NewFloat(imag(z))
+	d := new(big.Float).SetPrec(2000).Mul(x, x)
+	d.Add(d, new(big.Float).SetPrec(2000).Mul(y, y))
+	df, _ := d.Sub(d, big.NewFloat(1)).Float64()
+	if math.Abs(df) > 0.5 {
+		return cmplx.Log(z)
+	}
+	return complex(math.Log1p(df)/2, imag(cmplx.Log(z)))
+}
+
+func TestComplexFloat64(t *testing.T) {
+	for _, scale := range []float64{0.1, 2, 10, 100} {
+		testComplexFloat64("ExpComplex", bigfloat.ExpComplex, cmplx.Exp, scale, 1e3, t)
+		testComplexFloat64("LogComplex", bigfloat.LogComplex, cmplxLog, scale, 1e3, t)
+		testComplexFloat64("SqrtComplex", bigfloat.SqrtComplex, cmplx.Sqrt, scale, 1e3, t)
+	}
+}
+
+func TestComplexArithmetic(t *testing.T) {
+	for i := 0; i < 1e3; i++ {
+		a := complex(rand.Float64()-0.5, rand.Float64()-0.5)
+		b := complex(rand.Float64()-0.5, rand.Float64()-0.5)
+
+		x := bigfloat.NewComplex(big.NewFloat(real(a)), big.NewFloat(imag(a)))
+		y := bigfloat.NewComplex(big.NewFloat(real(b)), big.NewFloat(imag(b)))
+
+		for _, test := range []struct {
+			op   string
+			got  *bigfloat.Complex
+			want complex128
+		}{
+			{"+", new(bigfloat.Complex).Add(x, y), a + b},
+			{"-", new(bigfloat.Complex).Sub(x, y), a - b},
+			{"*", new(bigfloat.Complex).Mul(x, y), a * b},
+			{"/", new(bigfloat.Complex).Quo(x, y), a / b},
+		} {
+			got, _ := complex128Of(test.got)
+			if cmplx.Abs(got-test.want) > 1e-15*cmplx.Abs(test.want) {
+				t.Errorf("%v %s %v =\n got %v;\nwant %v", a, test.op, b, got, test.want)
+			}
+		}
+
+		abs, _ := x.Abs().Float64()
+		if want := cmplx.Abs(a); math.Abs(abs-want) > 1e-15*want {
+			t.Errorf("Abs(%v) =\n got %v;\nwant %v", a, abs, want)
+		}
+		arg, _ := x.Arg().Float64()
+		if want := cmplx.Phase(a); math.Abs(arg-want) > 1e-15*math.Abs(want) {
+			t.Errorf("Arg(%v) =\n got %v;\nwant %v", a, arg, want)
+		}
+	}
+}
+
+func TestComplexSpecialValues(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	for _, c := range []complex128{
+		complex(0, 0),
+		complex(negZero, 0),
+		complex(-4, 0),
+		complex(-4, negZero),
+		complex(0, 2),
+		complex(0, -2),
+		complex(math.Inf(+1), 1),
+		complex(math.Inf(-1), 1),
+		complex(math.Inf(-1), -1),
+		complex(1, math.Inf(+1)),
+		complex(-1, math.Inf(-1)),
+		complex(math.Inf(-1), math.Inf(-1)),
+		complex(math.Inf(+1), math.Inf(+1)),
+	} {
+		z := bigfloat.NewComplex(big.NewFloat(real(c)), big.NewFloat(imag(c)))
+
+		arg, acc := z.Arg().Float64()
+		if want := cmplx.Phase(c); math.Abs(arg-want) > 1e-15 || math.Signbit(arg) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Arg(%v) =\n got %g (%s);\nwant %g", c, arg, acc, want)
+		}
+
+		x, _ := complex128Of(bigfloat.SqrtComplex(z))
+		if want := cmplx.Sqrt(c); x != want || math.Signbit(imag(x)) != math.Signbit(imag(want)) {
+			t.Errorf("SqrtComplex(%v) =\n got %v;\nwant %v", c, x, want)
+		}
+
+		// math/cmplx.Exp returns NaN where ExpComplex panics
+		want := cmplx.Exp(c)
+		if !cmplx.IsInf(c) || math.IsNaN(real(want)) || math.IsNaN(imag(want)) {
+			continue
+		}
+		x, _ = complex128Of(bigfloat.ExpComplex(z))
+		if x != want || math.Signbit(real(x)) != math.Signbit(real(want)) || math.Signbit(imag(x)) != math.Signbit(imag(want)) {
+			t.Errorf("ExpComplex(%v) =\n got %v;\nwant %v", c, x, want)
+		}
+	}
+
+	inf := math.Inf(+1)
+	for _, test := range []struct {
+		op   string
+		x, y complex128
+		want complex128
+	}{
+		{"*", complex(inf, 0), complex(0, 1), complex(0, inf)},
+		{"*", complex(0, inf), complex(0, 1), complex(-inf, 0)},
+		{"*", complex(inf, 0), complex(2, -1), complex(inf, -inf)},
+		{"/", complex(inf, 0), complex(0, 1), complex(0, -inf)},
+		{"/", complex(1, 2), complex(inf, 0), complex(0, 0)},
+		{"/", complex(1, 2), complex(1, -inf), complex(0, 0)},
+	} {
+		x := bigfloat.NewComplex(big.NewFloat(real(test.x)), big.NewFloat(imag(test.x)))
+		y := bigfloat.NewComplex(big.NewFloat(real(test.y)), big.NewFloat(imag(test.y)))
+		var z *bigfloat.Complex
+		if test.op == "*" {
+			z = new(bigfloat.Complex).Mul(x, y)
+		} else {
+			z = new(bigfloat.Complex).Quo(x, y)
+		}
+		if got, _ := complex128Of(z); got != test.want {
+			t.Errorf("%v %s %v =\n got %v;\nwant %v", test.x, test.op, test.y, got, test.want)
+		}
+	}
+
+	zero := bigfloat.NewComplex(big.NewFloat(0), big.NewFloat(0))
+	for _, c := range []complex128{
+		complex(0, 0),
+		complex(0, 1),
+		complex(2, 1),
+		complex(-2, 0),
+		complex(-2, 1),
+	} {
+		w := bigfloat.NewComplex(big.NewFloat(real(c)), big.NewFloat(imag(c)))
+		x, _ := complex128Of(bigfloat.PowComplex(zero, w))
+		if want := cmplx.Pow(0, c); x != want {
+			t.Errorf("PowComplex(0, %v) =\n got %v;\nwant %v", c, x, want)
+		}
+	}
+
+	x, _ := complex128Of(bigfloat.LogComplex(zero))
+	if want := cmplx.Log(0); x != want {
+		t.Errorf("LogComplex(0) =\n got %v;\nwant %v", x, want)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkExpComplex(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := bigfloat.NewComplex(big.NewFloat(2), big.NewFloat(1.5)).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.ExpComplex(z)
+			}
+		})
+	}
+}
+
+func BenchmarkLogComplex(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := bigfloat.NewComplex(big.NewFloat(2), big.NewFloat(1.5)).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.LogComplex(z)
+			}
+		})
+	}
+}
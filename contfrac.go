@@ -0,0 +1,103 @@
+package bigfloat
+
+import "math/big"
+
+// ContinuedFraction returns the terms a₀, a₁, a₂, ... of the continued
+// fraction expansion x = a₀ + 1/(a₁ + 1/(a₂ + ...)), where a₀ is an
+// integer and the other terms are positive integers.
+//
+// Since x is known only to its precision, the expansion stops at the
+// first convergent that rounds to x, so the number of terms is what
+// the precision of x justifies, and the terms of a rational number
+// with a small denominator are returned exactly. The last term can be
+// affected by the rounding of x. ContinuedFraction panics if x is
+// ±Inf.
+func ContinuedFraction(x *big.Float) []*big.Int {
+	if x.IsInf() {
+		panic("ContinuedFraction: Inf argument")
+	}
+
+	r, _ := x.Rat(nil)
+	y := new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode())
+
+	var a []*big.Int
+	p, q := big.NewInt(1), big.NewInt(0)   // p_(k-1)/q_(k-1)
+	pp, qq := big.NewInt(0), big.NewInt(1) // p_(k-2)/q_(k-2)
+	one := big.NewInt(1)
+	for {
+		// r is positive after the first term, and Rat denominators
+		// are positive, so Div is the floor division
+		ak := new(big.Int).Div(r.Num(), r.Denom())
+
+		// p_k = a_k p_(k-1) + p_(k-2), and the same for q_k. When x
+		// is slightly below a rational number, the last term of the
+		// latter is a_k + 1, and the expansion of x continues with
+		// a_k, 1, so we also try a_k + 1 as the last term.
+		for _, t := range []*big.Int{ak, new(big.Int).Add(ak, one)} {
+			pk := new(big.Int).Add(new(big.Int).Mul(t, p), pp)
+			qk := new(big.Int).Add(new(big.Int).Mul(t, q), qq)
+			if y.SetRat(new(big.Rat).SetFrac(pk, qk)).Cmp(x) == 0 {
+				return append(a, t)
+			}
+		}
+		a = append(a, ak)
+		pp.Add(pp, new(big.Int).Mul(ak, p))
+		qq.Add(qq, new(big.Int).Mul(ak, q))
+		p, pp = pp, p
+		q, qq = qq, q
+
+		r.Sub(r, new(big.Rat).SetInt(ak))
+		r.Inv(r)
+	}
+}
+
+// BestRational returns the best rational approximation to x with
+// denominator at most maxDen, that is the rational number closest to
+// x among those with denominator at most maxDen, computed from the
+// continued fraction expansion of x. The result is one of its
+// convergents or semiconvergents, and if a convergent with denominator
+// at most maxDen rounds to x, it is returned, so BestRational
+// recovers rational numbers with small denominators from their
+// big.Float approximations. BestRational panics if x is ±Inf or if
+// maxDen is less than 1.
+func BestRational(x *big.Float, maxDen *big.Int) *big.Rat {
+	if maxDen.Sign() <= 0 {
+		panic("BestRational: maxDen < 1")
+	}
+
+	a := ContinuedFraction(x)
+	r, _ := x.Rat(nil)
+
+	p, q := big.NewInt(1), big.NewInt(0)
+	pp, qq := big.NewInt(0), big.NewInt(1)
+	for _, ak := range a {
+		pk := new(big.Int).Add(new(big.Int).Mul(ak, p), pp)
+		qk := new(big.Int).Add(new(big.Int).Mul(ak, q), qq)
+		if qk.Cmp(maxDen) > 0 {
+			// the best approximation is either p_(k-1)/q_(k-1) or
+			// the largest semiconvergent with denominator at most
+			// maxDen; q_(k-1) >= 1 since q₀ = 1 <= maxDen
+			best := new(big.Rat).SetFrac(p, q)
+			m := new(big.Int).Sub(maxDen, qq)
+			m.Quo(m, q)
+			if m.Sign() > 0 {
+				ps := new(big.Int).Add(pp, new(big.Int).Mul(m, p))
+				qs := new(big.Int).Add(qq, new(big.Int).Mul(m, q))
+				semi := new(big.Rat).SetFrac(ps, qs)
+				if ratDist(semi, r).Cmp(ratDist(best, r)) < 0 {
+					return semi
+				}
+			}
+			return best
+		}
+		p, pp = pk, p
+		q, qq = qk, q
+	}
+	return new(big.Rat).SetFrac(p, q)
+}
+
+// ratDist returns |x - y|.
+func ratDist(x, y *big.Rat) *big.Rat {
+	d := new(big.Rat).Sub(x, y)
+	return d.Abs(d)
+}
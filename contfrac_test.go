@@ -0,0 +1,114 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// convergent returns the value of the continued fraction with terms a.
+func convergent(a []*big.Int) *big.Rat {
+	r := new(big.Rat).SetInt(a[len(a)-1])
+	for i := len(a) - 2; i >= 0; i-- {
+		r.Inv(r)
+		r.Add(r, new(big.Rat).SetInt(a[i]))
+	}
+	return r
+}
+
+func TestContinuedFraction(t *testing.T) {
+	for _, test := range []struct {
+		x    *big.Float
+		want []int64
+	}{
+		{big.NewFloat(0), []int64{0}},
+		{big.NewFloat(-3), []int64{-3}},
+		{big.NewFloat(-0.5), []int64{-1, 2}},
+		{big.NewFloat(0.3125), []int64{0, 3, 5}},
+		{new(big.Float).SetPrec(100).Quo(big.NewFloat(355), big.NewFloat(113)), []int64{3, 7, 16}},
+		{new(big.Float).SetPrec(200).Quo(big.NewFloat(-1), big.NewFloat(3)), []int64{-1, 1, 2}},
+	} {
+		a := bigfloat.ContinuedFraction(test.x)
+		if fmt.Sprint(a) != fmt.Sprint(test.want) {
+			t.Errorf("ContinuedFraction(%g) = %v; want %v", test.x, a, test.want)
+		}
+	}
+
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		// e = [2; 1, 2, 1, 1, 4, 1, 1, 6, ...]
+		e := bigfloat.Exp(big.NewFloat(1).SetPrec(prec))
+		a := bigfloat.ContinuedFraction(e)
+		if len(a) < int(prec)/8 {
+			t.Errorf("prec = %d, ContinuedFraction(e) has only %d terms", prec, len(a))
+		}
+		for i := range a[:len(a)-1] {
+			want := int64(1)
+			switch {
+			case i == 0:
+				want = 2
+			case i%3 == 2:
+				want = 2 * int64(i+1) / 3
+			}
+			if a[i].Cmp(big.NewInt(want)) != 0 {
+				t.Errorf("prec = %d, ContinuedFraction(e): term %d is %v; want %d", prec, i, a[i], want)
+				break
+			}
+		}
+
+		// the last convergent rounds to e, and the previous does not
+		if y := new(big.Float).SetPrec(prec).SetRat(convergent(a)); y.Cmp(e) != 0 {
+			t.Errorf("prec = %d, ContinuedFraction(e): convergent %g does not round to e", prec, y)
+		}
+		if y := new(big.Float).SetPrec(prec).SetRat(convergent(a[:len(a)-1])); y.Cmp(e) == 0 {
+			t.Errorf("prec = %d, ContinuedFraction(e) has too many terms", prec)
+		}
+	}
+}
+
+func TestBestRational(t *testing.T) {
+	pi := bigfloat.PiInterval(200).Lo()
+	for _, test := range []struct {
+		x      *big.Float
+		maxDen int64
+		want   string
+	}{
+		{pi, 1, "3/1"},
+		{pi, 10, "22/7"},
+		{pi, 100, "311/99"},
+		{pi, 1000, "355/113"},
+		{pi, 16603, "355/113"},
+		{pi, 16604, "52163/16604"},
+		{big.NewFloat(-0.3125), 1000, "-5/16"},
+		{big.NewFloat(-0.3125), 5, "-1/3"},
+		{big.NewFloat(7), 1, "7/1"},
+	} {
+		if r := bigfloat.BestRational(test.x, big.NewInt(test.maxDen)); r.String() != test.want {
+			t.Errorf("BestRational(%g, %d) = %v; want %v", test.x, test.maxDen, r, test.want)
+		}
+	}
+
+	// rational results are recovered from their approximations
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		x := new(big.Float).SetPrec(prec).Quo(big.NewFloat(-22), big.NewFloat(17))
+		x.Add(x, new(big.Float).SetPrec(prec).Quo(big.NewFloat(5), big.NewFloat(9)))
+		if r := bigfloat.BestRational(x, big.NewInt(1e9)); r.String() != "-113/153" {
+			t.Errorf("prec = %d, BestRational(%g) = %v; want -113/153", prec, x, r)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkContinuedFraction(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.ContinuedFraction(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Digamma returns a big.Float representation of ψ(z), the logarithmic
+// derivative of Γ(z). Precision is the same as the one of the
+// argument. The function returns +Inf when z = +Inf, and panics if z
+// is zero, a negative integer or -Inf.
+func Digamma(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// Digamma(+Inf) = +Inf
+	case z.IsInf() && z.Sign() > 0:
+		return new(big.Float).SetPrec(prec).SetInf(false)
+
+	case z.IsInf() || z.IsInt() && z.Sign() <= 0:
+		panic("Digamma: argument is a non-positive integer")
+	}
+
+	// ψ has a zero at about 1.4616, close to which the result is
+	// much smaller than the terms of the asymptotic series.
+	guard := uint(64)
+	for {
+		x, scale := digamma(z, prec+guard)
+		if lost := lostBits(x, scale, prec+guard); lost > int(guard-64) {
+			guard = 64 + uint(lost)
+			continue
+		}
+		return x.SetPrec(prec)
+	}
+}
+
+// Polygamma returns a big.Float representation of ψ⁽ⁿ⁾(z), the n-th
+// derivative of the digamma function. Precision is the same as the
+// one of the argument. The function returns 0 when z = +Inf, and
+// panics if n is negative, or if z is zero, a negative integer or
+// -Inf.
+func Polygamma(n int, z *big.Float) *big.Float {
+
+	if n < 0 {
+		panic("Polygamma: negative order")
+	}
+	if n == 0 {
+		return Digamma(z)
+	}
+
+	prec := z.Prec()
+
+	switch {
+	// Polygamma(n, +Inf) = ±0
+	case z.IsInf() && z.Sign() > 0:
+		return setSign(new(big.Float).SetPrec(prec), n%2 == 0)
+
+	case z.IsInf() || z.IsInt() && z.Sign() <= 0:
+		panic("Polygamma: argument is a non-positive integer")
+	}
+
+	// For negative z the terms of the recurrence have different
+	// signs, and we may need more guard bits.
+	guard := uint(64)
+	for {
+		x, scale := polygamma(n, z, prec+guard)
+		if lost := lostBits(x, scale, prec+guard); lost > int(guard-64) {
+			guard = 64 + uint(lost)
+			continue
+		}
+		return x.SetPrec(prec)
+	}
+}
+
+// maxGuard is the largest number of guard bits the guard loops add
+// to the working precision.
+const maxGuard = 1 << 16
+
+// lostBits returns the number of bits of x lost to cancellation,
+// if scale is the binary exponent of the largest quantity involved
+// in its computation at precision prec, and at most maxGuard, so
+// that the guard loops end even when x stays 0.
+func lostBits(x *big.Float, scale int, prec uint) int {
+	lost := scale - x.MantExp(nil)
+	if x.Sign() == 0 {
+		lost = scale + int(prec)
+	}
+	if lost > maxGuard {
+		return maxGuard
+	}
+	return lost
+}
+
+// digamma returns ψ(z) computed to prec bits of precision, and the
+// binary exponent of the largest quantity that was involved in the
+// computation. z must be finite, and not a negative integer or zero.
+func digamma(z *big.Float, prec uint) (*big.Float, int) {
+
+	if z.Cmp(big.NewFloat(0.5)) >= 0 {
+		return digammaAsymptotic(z, prec)
+	}
+
+	// For z < 1/2 we use the reflection formula
+	//     ψ(1-z) - ψ(z) = π cot(πz)
+	one := big.NewFloat(1)
+	w := new(big.Float).SetPrec(exactSumPrec(z, one)).Sub(one, z)
+	x, scale := digammaAsymptotic(w, prec)
+
+	sin, cos := sincosPi(new(big.Float).SetPrec(prec).Set(z))
+	t := new(big.Float).SetPrec(prec).Quo(cos, sin)
+	t.Mul(t, pi(prec))
+	if t.Sign() != 0 && t.MantExp(nil) > scale {
+		scale = t.MantExp(nil)
+	}
+
+	return x.Sub(x, t), scale
+}
+
+// digammaAsymptotic returns ψ(z) for z >= 1/2, and the binary
+// exponent of the largest quantity that was involved in the
+// computation.
+func digammaAsymptotic(z *big.Float, prec uint) (*big.Float, int) {
+
+	// Shift z to x = z + n >= prec/2, using
+	//     ψ(z) = ψ(z+n) - Σ 1/(z+k),  k = 0, ..., n-1
+	one := big.NewFloat(1)
+	lim := big.NewFloat(float64(prec / 2))
+	x := new(big.Float).SetPrec(prec).Set(z)
+	s := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for x.Cmp(lim) < 0 {
+		s.Add(s, t.Quo(one, x))
+		x.Add(x, one)
+	}
+
+	// Asymptotic expansion:
+	//     ψ(x) = log(x) - 1/2x - Σ B_2k / (2k x^2k)
+	r := Log(x)
+	scale := r.MantExp(nil)
+	t.Quo(big.NewFloat(0.5), x)
+	r.Sub(r, t)
+
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	xk := new(big.Float).SetPrec(prec).Set(x2) // x^2k
+	b := new(big.Float).SetPrec(prec)
+	for k := 1; ; k++ {
+		b.SetRat(bernoulli(2 * k))
+		t.Quo(b, xk)
+		t.Quo(t, b.SetInt64(int64(2*k)))
+		r.Sub(r, t)
+		if t.Sign() == 0 || t.MantExp(nil) < -int(prec) {
+			break
+		}
+		xk.Mul(xk, x2)
+	}
+
+	if s.Sign() != 0 {
+		if e := s.MantExp(nil); e > scale {
+			scale = e
+		}
+		r.Sub(r, s)
+	}
+
+	return r, scale
+}
+
+// polygamma returns ψ⁽ⁿ⁾(z), for n > 0, computed to prec bits of
+// precision, and the binary exponent of the largest quantity that
+// was involved in the computation. z must be finite, and not a
+// negative integer or zero.
+func polygamma(n int, z *big.Float, prec uint) (*big.Float, int) {
+
+	// Shift z to x = z + m >= prec/2 + n, using
+	//     ψ⁽ⁿ⁾(z) = ψ⁽ⁿ⁾(z+m) - (-1)^n n! Σ 1/(z+k)^(n+1)
+	// for k = 0, ..., m-1. For z < 0 this is the only thing we can
+	// do, since the reflection formula for ψ⁽ⁿ⁾ involves the n-th
+	// derivative of cot(πz).
+	one := big.NewFloat(1)
+	lim := big.NewFloat(float64(prec/2) + float64(n))
+	x := new(big.Float).SetPrec(prec).Set(z)
+	s := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	scale := math.MinInt32
+	for x.Cmp(lim) < 0 {
+		t.Quo(one, powInt(x, n+1))
+		if e := t.MantExp(nil); e > scale {
+			scale = e
+		}
+		s.Add(s, t)
+		x.Add(x, one)
+	}
+
+	// Asymptotic expansion:
+	//     ψ⁽ⁿ⁾(x) = (-1)^(n+1) [ (n-1)!/x^n + n!/2x^(n+1)
+	//               + Σ B_2k (2k+n-1)! / ((2k)! x^(2k+n)) ]
+	nf := new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(1, int64(n)))
+	xn := powInt(x, n) // x^n
+
+	r := new(big.Float).SetPrec(prec).Quo(nf, xn)
+	r.Quo(r, t.SetInt64(int64(n))) // (n-1)!/x^n
+	t.Quo(nf, xn)
+	t.Quo(t, x)
+	t.SetMantExp(t, -1)
+	r.Add(r, t) // + n!/2x^(n+1)
+
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	xk := new(big.Float).SetPrec(prec).Mul(xn, x2) // x^(2k+n)
+	b := new(big.Float).SetPrec(prec)
+	c := new(big.Int)
+	for k := 1; ; k++ {
+		c.MulRange(int64(2*k+1), int64(2*k+n-1)) // (2k+n-1)!/(2k)!
+		b.SetRat(bernoulli(2 * k))
+		t.SetInt(c)
+		t.Mul(t, b)
+		t.Quo(t, xk)
+		r.Add(r, t)
+		if t.Sign() == 0 || t.MantExp(nil)-r.MantExp(nil) < -int(prec) {
+			break
+		}
+		xk.Mul(xk, x2)
+	}
+
+	if s.Sign() != 0 {
+		r.Add(r, s.Mul(s, nf))
+		scale += nf.MantExp(nil)
+	}
+	if e := r.MantExp(nil); e > scale {
+		scale = e
+	}
+
+	if n%2 == 0 {
+		r.Neg(r)
+	}
+	return r, scale
+}
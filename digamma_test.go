@@ -0,0 +1,104 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestDigamma(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "-5.77215664901532860606512090082402431042159335939923598805767234884867726777664670936947063291746749514631447249807082480960504014486542836224173997644923536253500333742937337737673942792595258247094916008735203948165670853233151776611528621199501507984793745085705740029921354786146694029604325421519058775535267331399254012967420513754139549111685102807984235e-1"},
+		{"0.5", "-1.96351002602142347944097633299875556719315960466043410704712725387165497071705410214867371728458412459863440929094845394833154448600280395028951550091507546011495547539968020811828802004037860518934161623946419985864391180357851492647565893224896978703655964599171993068265464009435517234523422950899991954653687121974053735600470993943407589268077901686052750e+0"},
+		{"1.5", "3.64899739785765205590236670012444328068403953395658929528727461283450292829458978513262827154158754013655907090515460516684555139971960497104844990849245398850445246003197918817119799596213948106583837605358001413560881964214850735243410677510302129634403540082800693173453599056448276547657704910000804534631287802594626439952900605659241073192209831394725030e-2"},
+		{"10.25", "2.27770479068672396930146996295616381219414106588213047287567998158257213531368228894169658372494281971626930183501020144282092788981885300369903476095772518566682815549005366622739399075458742045398513411444484691656414681330664480515335443720481896016910180782937049494549549521295732731817619702827198404840174792527861540403619106055687404800105483154429669e+0"},
+		{"1000.5", "6.90775532064879642705781860595073163215609470745573770762006452257348679639731223448064695505019352236529931372181396468643486037811502071681171283587448728289033383070476208634110228751903977391917199716426689544706363055096070647430648379609394934962687949394539987418530847368571905315673712755205428716253732232523179004468538886303007285835184606485225908e+0"},
+		{"0.0009765625", "-1.02457561042934062190862209790964458362784746988011463596002966794340516657589605863615982576487351427789658937684563330109915812519384404908005333141852498144761926997845593985174749883220349183855491554116599387005214707697039240629917958363734615743108388085150508415606719673241747773447327761316222852699977735493472243492849484141763859917950148095602502e+3"},
+		{"-0.5", "3.64899739785765205590236670012444328068403953395658929528727461283450292829458978513262827154158754013655907090515460516684555139971960497104844990849245398850445246003197918817119799596213948106583837605358001413560881964214850735243410677510302129634403540082800693173453599056448276547657704910000804534631287802594626439952900605659241073192209831394725030e-2"},
+		{"-2.75", "-1.95905526497799700982113187769841517909884617030997387338688129112068839756145504867028605868366307773344153529976202460729558875813795722491646768821612358250242682918075250559297661257747635785725768017096578737845148694472062234990284727214090661273699848163805893191598004780416783990841090785497894382727257805330699807001541686529040271205460988943482591e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Digamma(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Digamma(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestPolygamma(t *testing.T) {
+	for _, test := range []struct {
+		n    int
+		z    string
+		want string
+	}{
+		{1, "1", "1.64493406684822643647241516664602518921894990120679843773555822937000747040320087383362890061975870530400431896233719067962872468700500778793510294633086627683173330936776260509525100687214005479681155879489036082327776191984075645587696323563670971009694890208593200805163647887833884604444518405982514525068338763142276587939295880632044721979084773409105902e+0"},
+		{1, "0.25", "1.71973291545071107392713191193352240215068944014941677005453343331941489806292433988366255071274730818126212947275927407100595385486369192030633755778344649169530560863931313147238158610581530317081415504212153822436684013401720778413414413458907894403437132686080777094688893362569969954735893052647588485788263141145791798912100214523993515269205922637069947e+1"},
+		{2, "1", "-2.40411380631918857079947632302289998152997258468099776358454311068367641157262618037291174721867051629239831559052143883698399199734656642755279367441580032290788356589872013343838315104448498848792312781932820658231819156193102930255983681021143051197603087421956220407965506513357520704467396988332362211402943155727899947504757055587406191205140370636558001e+0"},
+		{2, "10.25", "-1.04918985340152827913491817043195882317986703433309254693773690866889912454781500740437838275973335113457773586574234705597042009249476103460241007665087231088729577783315090174285889647212210400579589011561837438814192265409576171355762522490265601288517809055029782332250113164453915544937899804171294991550762005846805420537910216213327166936721935811115648e-2"},
+		{5, "0.5", "7.69111354860243549624175554921935919093774022464837292752860949987275130273880170799377127321532588920891068518664446503753025174939825001467444751582928489204912989698088477898387333292550350609937423003450356857532398614374978640408256716081829160263977496257738157638219278247221727635165432677821722267228021412582876977121971897159312010840052587338846140e+3"},
+		{1, "-0.5", "8.93480220054467930941724549993807556765684970362039531320667468811002241120960262150088670185927611591201295688701157203888617406101502336380530883899259883049519992810328781528575302061642016439043467638467108246983328575952226936763088970691012913029084670625779602415490943663501653813333555217947543575205016289426829763817887641896134165937254320227317706e+0"},
+		{3, "-2.75", "1.55848975128329360127426631588662798360845868606728001221106465024340922221155363145140154510154525953903106301075317487530297394828540828345184516316039753871326385728744120340851114389025696801085573460789651838450097411109099116105011699669807528435904406556180296177293163921659898829195024218795059105176497269205435091190161111998561852219686480927723210e+3"},
+		{10, "100.5", "-3.62713755633737300327498390593948433302212548313058602430062954630560918602631274138822938332141715952943652059656484026237088879713210922143981163974886271270002890234228045853534559891165731075237082844471822096106996213301627006413836994659089597371183677353192457247158620255490826447786285048449607417671802493446820654976451351730597314994322693804278764e-15"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Polygamma(test.n, z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Polygamma(%d, %v) =\ngot  %g;\nwant %g", prec, test.n, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestDigammaRecurrence(t *testing.T) {
+	// ψ(z+1) = ψ(z) + 1/z
+	for _, f := range []float64{0.1, 0.5, 1.4616321449683622, 3, 7.5, -0.3, -4.6} {
+		for _, prec := range []uint{53, 100, 500} {
+			z := big.NewFloat(f).SetPrec(prec)
+			z1 := new(big.Float).SetPrec(prec+64).Add(z, big.NewFloat(1))
+
+			want := bigfloat.Digamma(new(big.Float).SetPrec(prec + 64).Set(z))
+			want.Add(want, new(big.Float).SetPrec(prec+64).Quo(big.NewFloat(1), z))
+			want.SetPrec(prec)
+
+			x := bigfloat.Digamma(z1).SetPrec(prec)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Digamma(%g + 1) =\ngot  %g;\nwant %g", prec, f, x, want)
+			}
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkDigamma(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Digamma(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Ei returns a big.Float representation of the exponential integral
+// Ei(z), the Cauchy principal value of the integral of exp(t)/t from
+// -Inf to z. Precision is the same as the one of the argument. The
+// function returns -Inf when z = ±0, +Inf when z = +Inf and -0 when
+// z = -Inf.
+func Ei(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// Ei(±0) = -Inf
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInf(true)
+
+	// Ei(+Inf) = +Inf, Ei(-Inf) = -0
+	case z.IsInf():
+		if z.Sign() > 0 {
+			return new(big.Float).SetPrec(prec).SetInf(false)
+		}
+		return new(big.Float).SetPrec(prec).Neg(new(big.Float))
+
+	// Ei(z) = -E1(-z) for z < 0
+	case z.Sign() < 0:
+		x := e1(new(big.Float).Neg(z), prec+64)
+		return x.Neg(x).SetPrec(prec)
+	}
+
+	return ei(z, prec+64).SetPrec(prec)
+}
+
+// E1 returns a big.Float representation of the exponential integral
+// E₁(z), the integral of exp(-t)/t from z to +Inf. Precision is the
+// same as the one of the argument. The function returns +Inf when
+// z = ±0 and 0 when z = +Inf, and panics if z < 0.
+func E1(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	case z.Sign() < 0:
+		panic("E1: argument is negative")
+
+	// E1(±0) = +Inf
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInf(false)
+
+	// E1(+Inf) = 0
+	case z.IsInf():
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return e1(z, prec+64).SetPrec(prec)
+}
+
+// Li returns a big.Float representation of the logarithmic integral
+// li(z) = Ei(log(z)). Precision is the same as the one of the
+// argument. The function returns 0 when z = 0, -Inf when z = 1 and
+// +Inf when z = +Inf, and panics if z < 0.
+func Li(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	case z.Sign() < 0:
+		panic("Li: argument is negative")
+
+	// Li(0) = 0, Li(+Inf) = +Inf
+	case z.Sign() == 0 || z.IsInf():
+		return new(big.Float).SetPrec(prec).Set(z)
+
+	// Li(1) = -Inf
+	case z.Cmp(big.NewFloat(1)) == 0:
+		return new(big.Float).SetPrec(prec).SetInf(true)
+	}
+
+	// The absolute error on log(z) becomes an error of about
+	// z·2**(-prec) in the result, since Ei'(log(z)) = z/log(z). This
+	// matters for large z, where it costs about log2(z) bits, and
+	// close to the zero of li at z = 1.45, where the result is small.
+	guard := uint(64)
+	for {
+		t := Log(new(big.Float).SetPrec(prec + guard).Set(z))
+		var x *big.Float
+		if t.Sign() < 0 {
+			x = e1(t.Neg(t), prec+guard)
+			x.Neg(x)
+		} else {
+			x = ei(t, prec+guard)
+		}
+		lost := z.MantExp(nil) - x.MantExp(nil)
+		if x.Sign() == 0 {
+			lost += int(prec + guard)
+		}
+		if lost <= int(guard-64) {
+			return x.SetPrec(prec)
+		}
+		guard = 64 + uint(lost)
+	}
+}
+
+// ei returns Ei(x), for x > 0, computed to prec bits of precision.
+func ei(x *big.Float, prec uint) *big.Float {
+
+	// For large x we can use the asymptotic expansion
+	//     Ei(x) = exp(x)/x · Σ k!/x^k
+	// whose smallest term, for k close to x, is about exp(-x).
+	if xf, _ := x.Float64(); xf > float64(prec)*math.Ln2+math.Log(float64(prec))+4 {
+		s := big.NewFloat(1).SetPrec(prec)
+		t := big.NewFloat(1).SetPrec(prec)
+		for k := int64(1); ; k++ {
+			t.Mul(t, big.NewFloat(float64(k)))
+			t.Quo(t, x)
+			s.Add(s, t)
+			if t.MantExp(nil)-s.MantExp(nil) < -int(prec) {
+				break
+			}
+		}
+		e := Exp(new(big.Float).SetPrec(prec + uint(x.MantExp(nil))).Set(x))
+		s.Mul(s, e)
+		return s.Quo(s, x)
+	}
+
+	// Ei(x) = γ + log(x) + Σ x^k/(k·k!)
+	//
+	// The function has a zero at x = 0.3725, close to which γ,
+	// log(x) and the sum cancel.
+	guard := uint(0)
+	for {
+		r, scale := einSeries(x, false, prec+guard)
+		if lost := lostBits(r, scale, prec+guard); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec)
+	}
+}
+
+// e1 returns E1(x), for x > 0, computed to prec bits of precision.
+func e1(x *big.Float, prec uint) *big.Float {
+
+	// For large x we use the continued fraction for E1(x) = Γ(0, x)
+	//     E1(x) = exp(-x) / (x+1 - 1/(x+3 - 4/(x+5 - 9/(x+7 - ...))))
+	// which converges quickly enough when x > prec/4.
+	if xf, _ := x.Float64(); xf > float64(prec)/4 {
+		r := gammaIncFrac(new(big.Float), x, prec)
+		e := new(big.Float).SetPrec(prec + uint(x.MantExp(nil))).Neg(x)
+		return r.Mul(r, Exp(e))
+	}
+
+	// E1(x) = -γ - log(x) - Σ (-x)^k/(k·k!)
+	//
+	// The sum is about exp(x) while the result is about exp(-x),
+	// which costs about 2x·log2(e) bits.
+	guard := uint(0)
+	for {
+		r, scale := einSeries(x, true, prec+guard)
+		if lost := lostBits(r, scale, prec+guard); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec)
+	}
+}
+
+// einSeries returns γ + log(x) + Σ x^k/(k·k!), for k >= 1, computed
+// to prec bits of precision, or its negation with -x in the sum when
+// neg is true; and the binary exponent of the largest quantity that
+// was involved in the computation. x must be positive.
+func einSeries(x *big.Float, neg bool, prec uint) (*big.Float, int) {
+
+	r := EulerGamma(prec)
+	r.Add(r, Log(new(big.Float).SetPrec(prec).Set(x)))
+	scale := r.MantExp(nil)
+
+	s := new(big.Float).SetPrec(prec)
+	t := big.NewFloat(1).SetPrec(prec) // x^k/k!
+	u := new(big.Float).SetPrec(prec)
+	d := new(big.Float)
+	for k := int64(1); ; k++ {
+		t.Mul(t, x)
+		t.Quo(t, d.SetInt64(k))
+		if neg {
+			t.Neg(t)
+		}
+		u.Quo(t, d)
+		s.Add(s, u)
+		if e := u.MantExp(nil); e > scale {
+			scale = e
+		}
+		if d.Cmp(x) > 0 && u.MantExp(nil)-s.MantExp(nil) < -int(prec) {
+			break
+		}
+	}
+
+	r.Add(r, s)
+	if neg {
+		r.Neg(r)
+	}
+	return r, scale
+}
@@ -0,0 +1,169 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestEi(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "1.89511781635593675546652093433163426901706058173270759164622843188251383453380415354890071012613895697181109531794465374258814916416306468808818668253882866963233854509522755525848139221216645993635994854330628545576162522816686811880285663784666568688864642429701909079047289030909933801909174699979183024948075858520888678370504137378784074164602583876283622e+0"},
+		{"0.5", "4.54219904863173579920523812662802365281405554352642045162817786688152896926662256136179658571437313959116677455742754194505386117811165116391546890331754135647241304687293754409725521140909609230443254234230179037916940593895302943720352840318683681471125562111772620399345690497088878409774192354702793156962680010848019591278850892917457696827611789941470986e-1"},
+		{"0.375", "9.69137720934199948304403924312615136110850190092060493603213203812957232678812526311318188788728779507882708676560443853401411920807782868002455252612549842543333089071914701586900333410011453191213830328006723959693883659292907195295443677680294819086869211908573808045795467041285589380029889874695598251797077318608493638528186003126417561686871123635870986e-3"},
+		{"2.5", "7.07376589457860071192355196245101254699632010569037584623617164521973863856009825667633751738823130707202768444581475490873465087461020817585210999328633174507969163716250040359722630757801523762345829767722770485690840069329816926166509302074032390847681994698111913460416476024524833476959269737341327765346439792526299649081168886651306648750832870996915994e+0"},
+		{"10", "2.49222897624187775913844014399852484898964710143094234538818526713774122742888744417794599665663156560488342454657568480015672868779475213684965774390405081358092618085953843034588958898797490413627578284824197470626942871742446957797413219944069699239482753860415457469447685056947542415835774540896085116985087980142226246288598275674611916889198446588313559e+3"},
+		{"100.5", "4.45468642946679108967289591225263881832968829417469725409988899763081610110784347562149145455078244527233951801696977460897534981198835798065423143598426930800562026005150807004680679408294279392956445612339260101840057513729929961802433215359219261399977227421291250527601120986575048610863734376814207237892154384204621140090097906314221281834062451309665666e+41"},
+		{"800", "3.41223886544837704619667674034146612945324692139381527352382547649513106900447413096820081494087158805700361938708750435271078660609003775959389833935797728792158753863359866917902765654989570248416380682879693258180478400883764502467383867846806955982696201050416690931426411363107164032193400526706632272437241511077697536584645447982643037767432488590200835e+344"},
+		{"-1", "-2.19383934395520273677163775460121649031047293406908207577978613073568698559141544722210251035137249954758234630874109590176378520537096009956704487876777412931347260795733865892805139788129537181134360059345012824765585462368324969488073343679827470707634455339786303962657522117753827032411866948006272810957128450245729191746253856173621967265606570630468293e-1"},
+		{"-10.25", "-3.16456387427993202882056849189218415709372181474585292977466812657301796161575690558835085706206640501189496873970437744883263760611140433819290180382458722228761488667563098677176333185519627128387713937804771900777055865420971920632107649220741961001698250206837521931048083366492378979050280530356356640562402536734414794802982761481477440007485647872487851e-6"},
+		{"1p-100", "-6.87375023910929980811167000557344655156026330882801900846969507726494393172281551880430140128890791859553361781497733191653039178524153855398146329791571478483865240704385480459712066016543388549709517440799552949915877037053332800790528949992075956059121560826949644222275527707596851051577052427123216533989261066767405459717263656591519589485752668770406713e+1"},
+		{"-1p-100", "-6.87375023910929980811167000557360432374130751118910135418275163451087857300983732340525545708049527939030886954063930075540403138854733356786974059248410279465553702894369515795209808288305938100739049522458641318981006703036687895058621750267859846128326384285378288331585982826051840601654558411123484958180395357051370767351276822251444309996493920884367749e+1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Ei(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Ei(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestE1(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "2.19383934395520273677163775460121649031047293406908207577978613073568698559141544722210251035137249954758234630874109590176378520537096009956704487876777412931347260795733865892805139788129537181134360059345012824765585462368324969488073343679827470707634455339786303962657522117753827032411866948006272810957128450245729191746253856173621967265606570630468293e-1"},
+		{"0.5", "5.59773594776160811746795939315085235226846890316353515248293219107339898830274268860341752473325112542620188328906843723848760134602129699972539165247510368501993047466793574481701811615375688213061829707083895015321351765152905997362542946228307348353654724048921768345379946589889487210935692563262793624937400870306355915770114681872709130108694035992223090e-1"},
+		{"0.0009765625", "6.35523246483107180261445551935803221293763008553775821607263519514889993878925082762044716595667467646916807508662805023895835094939166940896133826828941391938830544224566776484746479115641257369778928891658672113391960439325491405409160057908728974048050219849181824587959362182552746692604885932973211273054238060946707446548300125888719019378236269834442981e+0"},
+		{"10", "4.15696892968532427740285981027818038434629008241953313262759569712786222819608803586147163177527802101305497591041862309918139192016097135380721450303622430976651606491863764249282463390445986609827087316937335547558469015160801536504137710455673793901059210631008073038414125580536242429879668006154649504531531933616136584416465149955151217068615587645060136e-6"},
+		{"100.5", "2.22320691359262984897628073351717054292070956234695861069339628271778597699585473216247219278193356930110113043777900041304643098505901678762767606716689691483250255862875596515566173538432601348728877821680453899965503893306611310510474658879990796746767135411405338119455675430860710919464350070085596173521959536035509783944898453686821195247668219800713461e-46"},
+		{"300", "1.71038427680451011571887737146939068479978916427642341540430127457069820964114604942470800412112154445897590592371826473070059478373341986202476927923482307145363745131233591847026942185201066951737108540090148041194976673543073024087445453531596197450874765730883034007602565385672393200433308886229354702239141608854277019194972919007804050633623702206450228e-133"},
+		{"1000", "5.07089306023516654992720099968592514466722853749216933556859588954164707737210214774898289532108942457901429169837302470860544207963508356740326509695076670264211757098964307197915936412158921098204152859463470227733812072199949471460291928725498436746879356347826103437399582998120173998361196388078793756032062113987704184559309300229683824766048885862614112e-438"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.E1(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, E1(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestLi(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"2", "1.04516378011749278484458888919461313652261557815120157583290914407501320521035953017271740562638335630602082976887474662228523978521965027902108233455784540551590530032226327482845439051485349662286330547619933030441917094340641835471496672859671701722733827371430012519383675551531944716297652978007814897148779031982188519147113706991634634546145669268395701e+0"},
+		{"10", "6.16559950478729793752298175266952274913060280637658157893233849020558993476018015722827672430914325351109472299903693402245601244219455929606473724078950102374040090054547803364108195831896864159576395041259637970716531872655429493753724360195013419879724965317702645193060009180341330710780065222757554245408805217717520354126653329181166803507638774477938981e+0"},
+		{"1000", "1.77609657990152226687640623948699317978557702564548081068128451185275136257354263036477315719951934292463977557670985140410383256056780875515788441992090282077494586924203648133407509740861875627881509508447266559120897193248778786163608045194881001262587480658514831801304100098452783160142108193607867029490602294990897892173066423865114566482551709746766078e+2"},
+		{"1.5", "1.25064986315296355994350004795512936542088323930992291095616108714233034806237654179345962533546286915713066160014740341807560095651471002943228451048050021864180418000839195643998754500887019526968658230597754937138287871964434344157246890807157204100204849727302694678403759120933539795909519900633627684647756129747636123859799345745438530132251116152368754e-1"},
+		{"0.5", "-3.78671043061087976727207184636560980551234040978213996944420941734554756726674690985829980690797558883936023624234847038208377400662737997999274054942595286490733348954617411889984635897729583335782468869932252249605580251838899865364005677879714728226361549635748079675919663763829759322928033768561121600298566830547883515645079777577835472718504379582010195e-1"},
+		{"1p-100", "-1.12212096509443156629464740946840440082819428615033279042833202333035823589716586820762473949202453004736467695291791538634293803146731702803371692095182322426451005206362123524800080204155803415235000456476058741730269328376088372109379658253110966896181786689111833768078574381132226099046804604415047903476644224568758675985261791632991706528777151255774720e-32"},
+		{"1p100", "1.85601401760924631879576535208624332484923816933465838897816961464956384781941973170325590750501314585750418442871213994952379754855658962555351099107317537699183666328108146254334046419604190898770341148490337642137006749817508539843281252740729414260900123974733361297527282213449093775699635770002725039177002365365310612090595992037797223536859331055249334e+28"},
+		{"1.00048828125", "-7.04715919056535650098294178041669109061239502651151297649486206406661704609571052240817510740660133282478938654641522372951411906781058162402368315100852974159195865169544398717465173542731987427392932954373382495703612028149950204103078194575505182875311551765377809383808235142692907222831176524421534790203435291764295741926558313893555361588113378952952318e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Li(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Li(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestEulerGamma(t *testing.T) {
+	gammaStr := "5.77215664901532860606512090082402431042159335939923598805767234884867726777664670936947063291746749514631447249807082480960504014486542836224173997644923536253500333742937337737673942792595258247094916008735203948165670853233151776611528621199501507984793745085705740029921354786146694029604325421519058775535267331399254012967420513754139549111685102807984235e-1"
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := new(big.Float).SetPrec(prec)
+		want.Parse(gammaStr, 10)
+
+		z := bigfloat.EulerGamma(prec)
+
+		if z.Cmp(want) != 0 {
+			t.Errorf("EulerGamma(%d) =\ngot  %g;\nwant %g", prec, z, want)
+		}
+	}
+}
+
+func TestEiFloat64(t *testing.T) {
+	// E1(x) = -Ei(-x), and li(e**x) = Ei(x)
+	for _, x := range []float64{0.25, 1, 2.5, 10, 40} {
+		ei, _ := bigfloat.Ei(big.NewFloat(x)).Float64()
+		e1, _ := bigfloat.E1(big.NewFloat(x)).Float64()
+		mei, _ := bigfloat.Ei(big.NewFloat(-x)).Float64()
+		li, _ := bigfloat.Li(bigfloat.Exp(big.NewFloat(x).SetPrec(200))).Float64()
+		if e1 != -mei {
+			t.Errorf("E1(%g) = %g; -Ei(%g) = %g", x, e1, -x, -mei)
+		}
+		if math.Abs(li-ei) > 1e-15*math.Abs(ei) {
+			t.Errorf("Li(exp(%g)) = %g; Ei(%g) = %g", x, li, x, ei)
+		}
+	}
+}
+
+func TestEiSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"Ei(+0)", bigfloat.Ei(big.NewFloat(0)), math.Inf(-1)},
+		{"Ei(-0)", bigfloat.Ei(big.NewFloat(math.Copysign(0, -1))), math.Inf(-1)},
+		{"Ei(+Inf)", bigfloat.Ei(big.NewFloat(math.Inf(+1))), math.Inf(+1)},
+		{"Ei(-Inf)", bigfloat.Ei(big.NewFloat(math.Inf(-1))), math.Copysign(0, -1)},
+		{"E1(0)", bigfloat.E1(big.NewFloat(0)), math.Inf(+1)},
+		{"E1(+Inf)", bigfloat.E1(big.NewFloat(math.Inf(+1))), 0},
+		{"Li(0)", bigfloat.Li(big.NewFloat(0)), 0},
+		{"Li(1)", bigfloat.Li(big.NewFloat(1)), math.Inf(-1)},
+		{"Li(+Inf)", bigfloat.Li(big.NewFloat(math.Inf(+1))), math.Inf(+1)},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkEi(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Ei(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,642 @@
+package bigfloat
+
+import "math/big"
+
+// EllipticK returns a big.Float representation of the complete
+// elliptic integral of the first kind K(k), the integral of
+// 1/√(1-k²sin²t) from 0 to π/2, where k is the modulus. Precision is
+// the same as the one of the argument. The function returns +Inf
+// when k = ±1, and panics if |k| > 1.
+func EllipticK(k *big.Float) *big.Float {
+
+	prec := k.Prec()
+
+	switch c := ellipticCheck(k, "EllipticK"); {
+	// EllipticK(0) = π/2
+	case k.Sign() == 0:
+		x := pi(prec)
+		return x.SetMantExp(x, -1)
+
+	// EllipticK(±1) = +Inf
+	case c == 0:
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	return ellipticK(k, prec+64).SetPrec(prec)
+}
+
+// EllipticE returns a big.Float representation of the complete
+// elliptic integral of the second kind E(k), the integral of
+// √(1-k²sin²t) from 0 to π/2, where k is the modulus. Precision is
+// the same as the one of the argument. The function returns 1 when
+// k = ±1, and panics if |k| > 1.
+func EllipticE(k *big.Float) *big.Float {
+
+	prec := k.Prec()
+
+	switch c := ellipticCheck(k, "EllipticE"); {
+	// EllipticE(0) = π/2
+	case k.Sign() == 0:
+		x := pi(prec)
+		return x.SetMantExp(x, -1)
+
+	// EllipticE(±1) = 1
+	case c == 0:
+		return big.NewFloat(1).SetPrec(prec)
+	}
+
+	return ellipticE(k, prec+64).SetPrec(prec)
+}
+
+// EllipticF returns a big.Float representation of the incomplete
+// elliptic integral of the first kind F(φ, k), the integral of
+// 1/√(1-k²sin²t) from 0 to φ, where k is the modulus. Precision is
+// the same as the one of the first argument. The function returns
+// ±Inf when k = ±1 and |φ| >= π/2, and panics if φ is infinite or
+// if |k| > 1.
+func EllipticF(phi, k *big.Float) *big.Float {
+
+	prec := phi.Prec()
+
+	c := ellipticCheck(k, "EllipticF")
+	switch {
+	case phi.IsInf():
+		panic("EllipticF: amplitude is infinite")
+
+	// EllipticF(±0, k) = ±0
+	case phi.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(phi)
+	}
+
+	wprec := prec + 64
+	m := ellipticPeriod(phi)
+
+	// F(φ, ±1) = atanh(sin(φ)), which diverges at ±π/2, and m ≠ 0
+	// exactly when |φ| > π/2
+	if m.Sign() != 0 && c == 0 {
+		return new(big.Float).SetPrec(prec).SetInf(phi.Sign() < 0)
+	}
+
+	x := ellipticIncomplete(phi, m, k, false, wprec)
+
+	// F(φ + mπ, k) = F(φ, k) + 2mK(k). Since |F(φ, k)| <= K(k) there
+	// is no cancellation.
+	if m.Sign() != 0 {
+		t := new(big.Float).SetPrec(wprec).SetInt(m)
+		t.Mul(t, ellipticK(k, wprec))
+		x.Add(x, t.SetMantExp(t, 1))
+	}
+	return x.SetPrec(prec)
+}
+
+// EllipticEInc returns a big.Float representation of the incomplete
+// elliptic integral of the second kind E(φ, k), the integral of
+// √(1-k²sin²t) from 0 to φ, where k is the modulus. Precision is the
+// same as the one of the first argument. The function panics if φ
+// is infinite or if |k| > 1.
+func EllipticEInc(phi, k *big.Float) *big.Float {
+
+	prec := phi.Prec()
+
+	c := ellipticCheck(k, "EllipticEInc")
+	switch {
+	case phi.IsInf():
+		panic("EllipticEInc: amplitude is infinite")
+
+	// EllipticEInc(±0, k) = ±0
+	case phi.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(phi)
+	}
+
+	wprec := prec + 64
+	m := ellipticPeriod(phi)
+
+	x := ellipticIncomplete(phi, m, k, true, wprec)
+
+	// E(φ + mπ, k) = E(φ, k) + 2mE(k), and E(±1) = 1. As for F,
+	// there is no cancellation.
+	if m.Sign() != 0 {
+		t := new(big.Float).SetPrec(wprec).SetInt(m)
+		if c != 0 {
+			t.Mul(t, ellipticE(k, wprec))
+		}
+		x.Add(x, t.SetMantExp(t, 1))
+	}
+	return x.SetPrec(prec)
+}
+
+// CarlsonRF returns a big.Float representation of Carlson's
+// symmetric elliptic integral of the first kind
+//
+//	RF(x, y, z) = 1/2 ∫ 1/√((t+x)(t+y)(t+z)) dt
+//
+// from 0 to +Inf. Precision is the same as the one of the first
+// argument. The function returns 0 when one of the arguments is
+// +Inf, and panics if one of them is negative, or if more than one
+// of them is zero.
+func CarlsonRF(x, y, z *big.Float) *big.Float {
+
+	prec := x.Prec()
+
+	if carlsonCheck("CarlsonRF", x, y, z) {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return rf(x, y, z, prec+64).SetPrec(prec)
+}
+
+// CarlsonRD returns a big.Float representation of Carlson's
+// symmetric elliptic integral of the second kind
+//
+//	RD(x, y, z) = 3/2 ∫ 1/((t+z)√((t+x)(t+y)(t+z))) dt
+//
+// from 0 to +Inf. Precision is the same as the one of the first
+// argument. The function returns 0 when one of the arguments is
+// +Inf, and panics if one of them is negative, if x and y are both
+// zero, or if z is zero.
+func CarlsonRD(x, y, z *big.Float) *big.Float {
+
+	prec := x.Prec()
+
+	if z.Sign() == 0 {
+		panic("CarlsonRD: third argument is zero")
+	}
+	if carlsonCheck("CarlsonRD", x, y, z) {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return rd(x, y, z, prec+64).SetPrec(prec)
+}
+
+// CarlsonRJ returns a big.Float representation of Carlson's
+// symmetric elliptic integral of the third kind
+//
+//	RJ(x, y, z, p) = 3/2 ∫ 1/((t+p)√((t+x)(t+y)(t+z))) dt
+//
+// from 0 to +Inf. Precision is the same as the one of the first
+// argument. The function returns 0 when one of the arguments is
+// +Inf, and panics if one of x, y or z is negative, if more than one
+// of them is zero, or if p is not positive.
+func CarlsonRJ(x, y, z, p *big.Float) *big.Float {
+
+	prec := x.Prec()
+
+	if p.Sign() <= 0 {
+		panic("CarlsonRJ: fourth argument is not positive")
+	}
+	if carlsonCheck("CarlsonRJ", x, y, z) || p.IsInf() {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return rj(x, y, z, p, prec+64).SetPrec(prec)
+}
+
+// ellipticCheck panics if k is infinite or if |k| > 1, using fname
+// in the panic message, and otherwise returns the result of
+// comparing |k| with 1.
+func ellipticCheck(k *big.Float, fname string) int {
+	c := new(big.Float).Abs(k).Cmp(big.NewFloat(1))
+	if c > 0 {
+		panic(fname + ": modulus is out of range")
+	}
+	return c
+}
+
+// carlsonCheck panics if one of x, y or z is negative, or if more
+// than one of them is zero, using fname in the panic message. It
+// reports whether one of them is +Inf.
+func carlsonCheck(fname string, x, y, z *big.Float) bool {
+	zeros, inf := 0, false
+	for _, t := range []*big.Float{x, y, z} {
+		switch {
+		case t.Sign() < 0:
+			panic(fname + ": argument is negative")
+		case t.Sign() == 0:
+			zeros++
+		case t.IsInf():
+			inf = true
+		}
+	}
+	if zeros > 1 {
+		panic(fname + ": more than one argument is zero")
+	}
+	return inf
+}
+
+// ellipticPeriod returns the integer m nearest to φ/π, so that
+// φ = mπ + r with |r| < π/2, as ellipticIncomplete needs. An error
+// of one in m would give F(π - r, k) instead of F(r, k), so we
+// increase the precision until the rounding of φ/π is certain, which
+// happens since φ/π is never a half-integer.
+func ellipticPeriod(phi *big.Float) *big.Int {
+	prec := uint(64)
+	if e := phi.MantExp(nil); e > 0 {
+		prec += uint(e)
+	}
+	half := big.NewFloat(0.5)
+	for {
+		// t is within 2 ulps of φ/π, and t - m ∓ 1/2 is exact
+		t := new(big.Float).SetPrec(prec).Quo(phi, pi(prec))
+		m := roundInt(t, new(big.Int))
+		d := new(big.Float).SetPrec(prec).SetInt(m)
+		d.Sub(t, d)
+		d.Sub(d.Abs(d), half)
+		if d.Sign() != 0 && d.MantExp(nil) > t.MantExp(nil)-int(prec)+2 {
+			return m
+		}
+		prec *= 2
+	}
+}
+
+// ellipticK returns K(k), for |k| < 1, computed to prec bits of
+// precision.
+func ellipticK(k *big.Float, prec uint) *big.Float {
+	// K(k) = π / (2·AGM(1, k')), where k' = √(1-k²)
+	a := AGM(big.NewFloat(1).SetPrec(prec), ellipticComplement(k, prec))
+	a.SetMantExp(a, 1)
+	return a.Quo(pi(prec), a)
+}
+
+// ellipticE returns E(k), for |k| < 1, computed to prec bits of
+// precision.
+func ellipticE(k *big.Float, prec uint) *big.Float {
+
+	// Following Abramowitz and Stegun, 17.6, we run the AGM of 1 and
+	// k' while keeping track of c_n = (a_(n-1) - b_(n-1))/2, and
+	//     E(k) = K(k)·(1 - Σ 2^(n-1) c_n²)
+	// with c_0 = k. We use c_(n+1) = c_n² / (4a_(n+1)), which does
+	// not suffer from cancellation.
+	//
+	// The sum gets close to 1 when k is close to 1, and 1 - Σ
+	// loses the bits it shares with 1.
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		a := big.NewFloat(1).SetPrec(wprec)
+		b := ellipticComplement(k, wprec)
+		c2 := new(big.Float).SetPrec(wprec).Mul(k, k)
+		s := new(big.Float).SetPrec(wprec).SetMantExp(c2, -1)
+		t := new(big.Float).SetPrec(wprec)
+		for n := 0; ; n++ {
+			t.Add(a, b)
+			t.SetMantExp(t, -1) // a_(n+1)
+			b.Sqrt(b.Mul(b, a))
+			a.Set(t)
+
+			// c_(n+1)² = c_n⁴ / (16a_(n+1)²)
+			c2.Mul(c2, c2)
+			c2.Quo(c2, t.Mul(t, t))
+			c2.SetMantExp(c2, -4)
+
+			t.SetMantExp(c2, n)
+			s.Add(s, t)
+			if c2.Sign() == 0 || t.MantExp(nil) < -int(wprec) {
+				break
+			}
+		}
+
+		s.Sub(big.NewFloat(1), s)
+		if lost := lostBits(s, 0, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+
+		a.SetMantExp(a, 1)
+		a.Quo(pi(wprec), a)
+		return a.Mul(a, s).SetPrec(prec)
+	}
+}
+
+// ellipticComplement returns the complementary modulus
+// k' = √(1-k²), for |k| <= 1, computed to prec bits of precision.
+func ellipticComplement(k *big.Float, prec uint) *big.Float {
+	// 1-k² = (1-k)(1+k), where both factors are computed exactly
+	one := big.NewFloat(1)
+	p := new(big.Float).SetPrec(exactSumPrec(k, one)).Sub(one, k)
+	q := new(big.Float).SetPrec(exactSumPrec(k, one)).Add(one, k)
+	x := new(big.Float).SetPrec(prec).Mul(p, q)
+	return x.Sqrt(x)
+}
+
+// ellipticIncomplete returns F(r, k), or E(r, k) if second is true,
+// where φ = mπ + r, computed to prec bits of precision.
+func ellipticIncomplete(phi *big.Float, m *big.Int, k *big.Float, second bool, prec uint) *big.Float {
+
+	// Following B. C. Carlson, Numerical computation of real or
+	// complex elliptic integrals, 1995, Eq. 4.5 and 4.6,
+	//     F(r, k) = sin(r)·RF(cos²(r), 1-k²sin²(r), 1)
+	//     E(r, k) = F(r, k) - (k²/3)sin³(r)·RD(cos²(r), 1-k²sin²(r), 1)
+	// where we compute 1-k²sin²(r) as cos²(r) + k'²sin²(r) to avoid
+	// cancellation.
+	//
+	// When k is close to 1 and r is close to ±π/2 the two terms of
+	// E(r, k) are large and close, and their difference is small.
+	one := big.NewFloat(1)
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		// sin(φ - mπ) = (-1)^m sin(φ), cos(φ - mπ) = (-1)^m cos(φ)
+		sin, cos := sincos(new(big.Float).SetPrec(wprec).Set(phi))
+		if m.Bit(0) == 1 {
+			sin.Neg(sin)
+		}
+
+		s2 := new(big.Float).SetPrec(wprec).Mul(sin, sin)
+		c2 := new(big.Float).SetPrec(wprec).Mul(cos, cos)
+		y := ellipticComplement(k, wprec)
+		y.Mul(y, y)
+		y.Mul(y, s2)
+		y.Add(y, c2)
+
+		x := rf(c2, y, one, wprec)
+		x.Mul(x, sin)
+		if !second {
+			return x.SetPrec(prec)
+		}
+
+		t := rd(c2, y, one, wprec)
+		t.Mul(t, s2)
+		t.Mul(t, sin)
+		t.Mul(t, k)
+		t.Mul(t, k)
+		t.Quo(t, big.NewFloat(3))
+		scale := x.MantExp(nil)
+		x.Sub(x, t)
+
+		if lost := lostBits(x, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return x.SetPrec(prec)
+	}
+}
+
+// The Carlson integrals below are computed following B. C. Carlson,
+// Numerical computation of real or complex elliptic integrals,
+// Numer. Algorithms 10 (1995). The duplication theorems, as in
+//     RF(x, y, z) = RF((x+λ)/4, (y+λ)/4, (z+λ)/4)
+// where λ = √x√y + √y√z + √z√x, bring the arguments closer to their
+// mean A by a factor of 4 at each step, until a truncated Taylor
+// expansion around A is accurate enough. The expansions are accurate
+// to the sixth order, so we need about prec/12 steps.
+
+// carlsonDone reports whether all the xs are close enough to a for
+// the Taylor expansions to be accurate to prec bits.
+func carlsonDone(a *big.Float, prec uint, xs ...*big.Float) bool {
+	lim := a.MantExp(nil) - int(prec/6) - 2
+	t := new(big.Float)
+	for _, x := range xs {
+		if t.Sub(a, x); t.Sign() != 0 && t.MantExp(nil) > lim {
+			return false
+		}
+	}
+	return true
+}
+
+// carlsonLambda returns λ = √x√y + √y√z + √z√x, and the three
+// square roots.
+func carlsonLambda(x, y, z *big.Float, prec uint) (l, sx, sy, sz *big.Float) {
+	sx = new(big.Float).SetPrec(prec).Sqrt(x)
+	sy = new(big.Float).SetPrec(prec).Sqrt(y)
+	sz = new(big.Float).SetPrec(prec).Sqrt(z)
+	l = new(big.Float).SetPrec(prec).Add(sx, sz)
+	l.Mul(l, sy)
+	t := new(big.Float).SetPrec(prec).Mul(sx, sz)
+	return l.Add(l, t), sx, sy, sz
+}
+
+// carlsonStep sets each of the xs to (x+λ)/4.
+func carlsonStep(l *big.Float, xs ...*big.Float) {
+	for _, x := range xs {
+		x.Add(x, l)
+		x.SetMantExp(x, -2)
+	}
+}
+
+// carlsonDist returns 1 - x/a.
+func carlsonDist(x, a *big.Float, prec uint) *big.Float {
+	t := new(big.Float).SetPrec(prec).Quo(x, a)
+	return t.Sub(big.NewFloat(1), t)
+}
+
+// carlsonPoly returns the sum of the coeffs[i]·terms[i], where each
+// coefficient is a fraction n/d given as {n, d}.
+func carlsonPoly(prec uint, coeffs [][2]int64, terms ...*big.Float) *big.Float {
+	s := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for i, c := range coeffs {
+		t.Mul(terms[i], new(big.Float).SetInt64(c[0]))
+		t.Quo(t, new(big.Float).SetInt64(c[1]))
+		s.Add(s, t)
+	}
+	return s
+}
+
+// rf returns RF(x, y, z) computed to prec bits of precision. x, y
+// and z must be finite and non-negative, and at most one of them can
+// be zero.
+func rf(x, y, z *big.Float, prec uint) *big.Float {
+
+	x = new(big.Float).SetPrec(prec).Set(x)
+	y = new(big.Float).SetPrec(prec).Set(y)
+	z = new(big.Float).SetPrec(prec).Set(z)
+	a := new(big.Float).SetPrec(prec)
+	for {
+		a.Add(x, y).Add(a, z).Quo(a, big.NewFloat(3))
+		if carlsonDone(a, prec, x, y, z) {
+			break
+		}
+		l, _, _, _ := carlsonLambda(x, y, z, prec)
+		carlsonStep(l, x, y, z)
+	}
+
+	// RF = A^(-1/2)·(1 - E2/10 + E3/14 + E2²/24 - 3E2E3/44)
+	// where X = 1 - x/A, Y = 1 - y/A, Z = -(X+Y),
+	// E2 = XY - Z² and E3 = XYZ.
+	X := carlsonDist(x, a, prec)
+	Y := carlsonDist(y, a, prec)
+	Z := new(big.Float).SetPrec(prec).Add(X, Y)
+	Z.Neg(Z)
+
+	xy := new(big.Float).SetPrec(prec).Mul(X, Y)
+	e2 := new(big.Float).SetPrec(prec).Mul(Z, Z)
+	e2.Sub(xy, e2)
+	e3 := new(big.Float).SetPrec(prec).Mul(xy, Z)
+	e22 := new(big.Float).SetPrec(prec).Mul(e2, e2)
+	e23 := new(big.Float).SetPrec(prec).Mul(e2, e3)
+
+	r := carlsonPoly(prec, [][2]int64{{-1, 10}, {1, 14}, {1, 24}, {-3, 44}}, e2, e3, e22, e23)
+	r.Add(r, big.NewFloat(1))
+	return r.Quo(r, a.Sqrt(a))
+}
+
+// rd returns RD(x, y, z) computed to prec bits of precision. x and y
+// must be finite and non-negative, and at most one of them can be
+// zero, and z must be finite and positive.
+func rd(x, y, z *big.Float, prec uint) *big.Float {
+
+	x = new(big.Float).SetPrec(prec).Set(x)
+	y = new(big.Float).SetPrec(prec).Set(y)
+	z = new(big.Float).SetPrec(prec).Set(z)
+	a := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	s := new(big.Float).SetPrec(prec) // Σ 4^(-m) / (√z_m (z_m + λ_m))
+	m := 0
+	for ; ; m++ {
+		a.Mul(z, big.NewFloat(3)).Add(a, x).Add(a, y).Quo(a, big.NewFloat(5))
+		if carlsonDone(a, prec, x, y, z) {
+			break
+		}
+		l, _, _, sz := carlsonLambda(x, y, z, prec)
+		t.Add(z, l)
+		t.Mul(t, sz)
+		t.Quo(big.NewFloat(1), t)
+		s.Add(s, t.SetMantExp(t, -2*m))
+		carlsonStep(l, x, y, z)
+	}
+
+	// RD = 3Σ + 4^(-m) A^(-3/2)·(1 - 3E2/14 + E3/6 + 9E2²/88
+	//      - 3E4/22 - 9E2E3/52 + 3E5/26)
+	// where X = 1 - x/A, Y = 1 - y/A, Z = -(X+Y)/3, E2 = XY - 6Z²,
+	// E3 = (3XY - 8Z²)Z, E4 = 3(XY - Z²)Z² and E5 = XYZ³.
+	X := carlsonDist(x, a, prec)
+	Y := carlsonDist(y, a, prec)
+	Z := new(big.Float).SetPrec(prec).Add(X, Y)
+	Z.Quo(Z, big.NewFloat(-3))
+
+	xy := new(big.Float).SetPrec(prec).Mul(X, Y)
+	z2 := new(big.Float).SetPrec(prec).Mul(Z, Z)
+	e2 := new(big.Float).SetPrec(prec).Mul(z2, big.NewFloat(6))
+	e2.Sub(xy, e2)
+	e3 := new(big.Float).SetPrec(prec).Mul(z2, big.NewFloat(8))
+	e3.Sub(t.Mul(xy, big.NewFloat(3)), e3)
+	e3.Mul(e3, Z)
+	e4 := new(big.Float).SetPrec(prec).Sub(xy, z2)
+	e4.Mul(e4, z2)
+	e4.Mul(e4, big.NewFloat(3))
+	e5 := new(big.Float).SetPrec(prec).Mul(xy, z2)
+	e5.Mul(e5, Z)
+	e22 := new(big.Float).SetPrec(prec).Mul(e2, e2)
+	e23 := new(big.Float).SetPrec(prec).Mul(e2, e3)
+
+	r := carlsonPoly(prec, [][2]int64{{-3, 14}, {1, 6}, {9, 88}, {-3, 22}, {-9, 52}, {3, 26}}, e2, e3, e22, e4, e23, e5)
+	r.Add(r, big.NewFloat(1))
+	t.Sqrt(a)
+	r.Quo(r, t.Mul(t, a))
+	r.SetMantExp(r, -2*m)
+	return r.Add(r, s.Mul(s, big.NewFloat(3)))
+}
+
+// rj returns RJ(x, y, z, p) computed to prec bits of precision. x,
+// y and z must be finite and non-negative, and at most one of them
+// can be zero, and p must be finite and positive.
+func rj(x, y, z, p *big.Float, prec uint) *big.Float {
+
+	x = new(big.Float).SetPrec(prec).Set(x)
+	y = new(big.Float).SetPrec(prec).Set(y)
+	z = new(big.Float).SetPrec(prec).Set(z)
+	p = new(big.Float).SetPrec(prec).Set(p)
+	a := new(big.Float).SetPrec(prec)
+	s := new(big.Float).SetPrec(prec) // Σ 4^(-m) RC(α_m, β_m)
+	al := new(big.Float).SetPrec(prec)
+	be := new(big.Float).SetPrec(prec)
+	m := 0
+	for ; ; m++ {
+		a.Add(x, y).Add(a, z).Add(a, p).Add(a, p).Quo(a, big.NewFloat(5))
+		if carlsonDone(a, prec, x, y, z, p) {
+			break
+		}
+		l, sx, sy, sz := carlsonLambda(x, y, z, prec)
+
+		// α = (p(√x+√y+√z) + √x√y√z)², β = p(p+λ)²
+		al.Add(sx, sy).Add(al, sz).Mul(al, p)
+		sx.Mul(sx, sy).Mul(sx, sz)
+		al.Add(al, sx)
+		al.Mul(al, al)
+		be.Add(p, l)
+		be.Mul(be, be).Mul(be, p)
+
+		r := rc(al, be, prec)
+		s.Add(s, r.SetMantExp(r, -2*m))
+		carlsonStep(l, x, y, z, p)
+	}
+
+	// RJ = 3Σ + 4^(-m) A^(-3/2)·(1 - 3E2/14 + E3/6 + 9E2²/88
+	//      - 3E4/22 - 9E2E3/52 + 3E5/26)
+	// where X = 1 - x/A, Y = 1 - y/A, Z = 1 - z/A, P = -(X+Y+Z)/2,
+	// E2 = XY + XZ + YZ - 3P², E3 = XYZ + 2E2P + 4P³,
+	// E4 = (2XYZ + E2P + 3P³)P and E5 = XYZP².
+	X := carlsonDist(x, a, prec)
+	Y := carlsonDist(y, a, prec)
+	Z := carlsonDist(z, a, prec)
+	P := new(big.Float).SetPrec(prec).Add(X, Y)
+	P.Add(P, Z)
+	P.Quo(P, big.NewFloat(-2))
+
+	t := new(big.Float).SetPrec(prec)
+	p2 := new(big.Float).SetPrec(prec).Mul(P, P)
+	p3 := new(big.Float).SetPrec(prec).Mul(p2, P)
+	xyz := new(big.Float).SetPrec(prec).Mul(X, Y)
+	e2 := new(big.Float).SetPrec(prec).Add(X, Y)
+	e2.Mul(e2, Z)
+	e2.Add(e2, xyz)
+	e2.Sub(e2, t.Mul(p2, big.NewFloat(3)))
+	xyz.Mul(xyz, Z)
+	e2p := new(big.Float).SetPrec(prec).Mul(e2, P)
+	e3 := new(big.Float).SetPrec(prec).SetMantExp(e2p, 1)
+	e3.Add(e3, xyz)
+	e3.Add(e3, t.SetMantExp(p3, 2))
+	e4 := new(big.Float).SetPrec(prec).SetMantExp(xyz, 1)
+	e4.Add(e4, e2p)
+	e4.Add(e4, t.Mul(p3, big.NewFloat(3)))
+	e4.Mul(e4, P)
+	e5 := new(big.Float).SetPrec(prec).Mul(xyz, p2)
+	e22 := new(big.Float).SetPrec(prec).Mul(e2, e2)
+	e23 := new(big.Float).SetPrec(prec).Mul(e2, e3)
+
+	r := carlsonPoly(prec, [][2]int64{{-3, 14}, {1, 6}, {9, 88}, {-3, 22}, {-9, 52}, {3, 26}}, e2, e3, e22, e4, e23, e5)
+	r.Add(r, big.NewFloat(1))
+	t.Sqrt(a)
+	r.Quo(r, t.Mul(t, a))
+	r.SetMantExp(r, -2*m)
+	return r.Add(r, s.Mul(s, big.NewFloat(3)))
+}
+
+// rc returns Carlson's degenerate integral RC(x, y) = RF(x, y, y),
+// for x >= 0 and y > 0, computed to prec bits of precision.
+func rc(x, y *big.Float, prec uint) *big.Float {
+
+	x = new(big.Float).SetPrec(prec).Set(x)
+	y = new(big.Float).SetPrec(prec).Set(y)
+	a := new(big.Float).SetPrec(prec)
+	l := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for {
+		a.SetMantExp(y, 1).Add(a, x).Quo(a, big.NewFloat(3))
+		if carlsonDone(a, prec, x, y) {
+			break
+		}
+
+		// λ = 2√x√y + y
+		l.Sqrt(x)
+		l.Mul(l, t.Sqrt(y))
+		l.SetMantExp(l, 1)
+		l.Add(l, y)
+		carlsonStep(l, x, y)
+	}
+
+	// RC = A^(-1/2)·(1 + 3S²/10 + S³/7 + 3S⁴/8 + 9S⁵/22 + 159S⁶/208
+	//      + 9S⁷/8)
+	// where S = y/A - 1.
+	S := carlsonDist(y, a, prec)
+	S.Neg(S)
+	r := new(big.Float).SetPrec(prec)
+	for _, c := range [][2]int64{{9, 8}, {159, 208}, {9, 22}, {3, 8}, {1, 7}, {3, 10}, {0, 1}, {1, 1}} {
+		r.Mul(r, S)
+		t.SetInt64(c[0])
+		r.Add(r, t.Quo(t, new(big.Float).SetInt64(c[1])))
+	}
+	return r.Quo(r, a.Sqrt(a))
+}
@@ -0,0 +1,351 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestEllipticK(t *testing.T) {
+	for _, test := range []struct {
+		k    string
+		want string
+	}{
+		{"0.5", "1.68575035481259604287120365779907698950080089414108904411994829789343370288234676040645097393661257033478937836378483023982782569127267804764837993749528232358219852386166738640704307223845489545346684392635419991254540236816802122276163579313581719628463776379022429298290193874596522811196746901389250395995638768901853774118371529873058875876968071251047023e+0"},
+		{"0.75", "1.91098978075182919655314821876134255925314513167883386266195170819390379953212152280083554182369602080490632540135223940042410718829799508239030805491921457860290549610433594000835306606846101585925577127268981982250807014580797630398625132749604450260755121974234031330519099052502867298165236171036091225619777080882978114228715583350867614454568716932932550e+0"},
+		{"-0.25", "1.59624222213178351014896907149794987950557445789512257722449327328379869967715536536185110470314583691952629940943040208388398167591388057127858178004567176539861100474191955331042258299766207785679011747138471447421412425812689018034059217042942285084006382698629183794940506102302867785678499663477987613603611565514740749135901922363608441452017202857954205e+0"},
+		{"0.875", "2.18548846927822368691308032373015868973042841576637332297477526390691629910335729908172922664460538110292790431010294466931062779746900157648214474910464540852306084694830425566592632661481955203430874636856972807377553515976656039775587039325188314788519337272215708531521775879471740525809485118818410848712554456872697922197675536897056802226878653191589665e+0"},
+		{"0.999999940395355224609375", "9.35748720153295254681553987512811599592799184702060954717819618647125463431432135856891110818562320768917823673240295058772376212871158590136034915503908812459004144232491114704292062934143506516195272881261824513064355286030233926357908013155145910583056068026341887727090462599326152768722886280258733945662675797018393162218943931713631947252347574058985084e+0"},
+		{"1p-40", "1.57079632679489661923132201647281839066069076879439692778337456795063784752075599820832149934473677665138639605432971623221475194075911624212633338426437180986094071753558866028845374647210893300862634815580036139848537870115763533883369685680318290908397337676741123135252688420950804283708725790845947093173081185589084305182286461775591520488220494830277250e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			k := new(big.Float).SetPrec(prec)
+			k.Parse(test.k, 10)
+
+			x := bigfloat.EllipticK(k)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, EllipticK(%v) =\ngot  %g;\nwant %g", prec, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestEllipticE(t *testing.T) {
+	for _, test := range []struct {
+		k    string
+		want string
+	}{
+		{"0.5", "1.46746220933942715545979526699091613602536175232723196050079063649082422727129063565403853073350460218217549572079406740695677032698229425472716604527624233243895279094748440327417827004209184725946430339739593189282296331056304963044751067080969765538439612288672264536170712139967357006374615449223642365048999353923146378107720708046047954714709639352004806e+0"},
+		{"0.75", "1.31847210799462099737184279449793093060267064706260471617686270518446853823919299744950558229471478348518692215317892695219227154676388864250868264540712681735669502390601295719178526367725024893736169145737657081609075977263301460846318918661822181268379005113697659167234405113068564713070972265254963017819885565014850253758650611581553762813058407271629493e+0"},
+		{"-0.25", "1.54595725610546503495041243992061061201697236616309453798914258084289544046349180079089481943814624343735471036905942351531832664536544139181959747497165414280500775135983714999677367152997731285165526130133538085187929658710504570931074401882451697555052161461931316095479271753612602618342438729879844790609679264048104880078034139375032825187474924292479399e+0"},
+		{"0.875", "1.20111063073691469779451871079749205230394577297779851669508715493554242906407107299274210537921550207269575998747245602150757730037181171153465001225123210716225921189594297734679675141391696613648548794190610424788272540052579540784062387701121230734135555542865746498152211077551908553089227023013540717185983479394921791341415264276326772734806478819264375e+0"},
+		{"0.999999940395355224609375", "1.00000052794736971677762138381000614680725549819117254579216789447824357224750805509634955456642684903338730206008718902447984781260870910761466473782377890287585550686737041865471947977837250865965402081715328415802150678761058896343397243009680177455368492735449372282712912013596666690770159792400962265412115408026867291402639325656968559325148956773147387e+0"},
+		{"1p-40", "1.57079632679489661923132136680668449353647863058080965404730602070136137053203961522374927199277278946027863188010963825590158938193928144689054476219636008243920988634990236734930025733716453564696032299573110549801118188406112270362250847949875259493022991660037385671270190118131052083891913406168819787064003094302661023307513630710997336137822990890361139e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			k := new(big.Float).SetPrec(prec)
+			k.Parse(test.k, 10)
+
+			x := bigfloat.EllipticE(k)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, EllipticE(%v) =\ngot  %g;\nwant %g", prec, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestEllipticF(t *testing.T) {
+	for _, test := range []struct {
+		phi, k string
+		want   string
+	}{
+		{"1.25", "0.75", "1.43590488566631073233124692765288466772646970073294942006278656536517224289536307893071484156178172872619377996878354456240284096886163471520253343867319812092298613996727694120388884870805988070355918700125804326695482439098973649333340989995421644953142803446697237326447877759742646564500860938433530520255076917018202577869862757282601010678188231549271300e+0"},
+		{"0.5", "0.5", "5.05088727578648078883108289623605522227775339647974957883843906413915064184773280960237594433957066491278925404784382734933203713021185957267244662493780050622564446329013444371944637229104105106273651658405474300399813623278480222313221603278257129911396757281413048074510205186966651752564960568292536027159642252998388779983387011052667508084624953016047970e-1"},
+		{"-2.5", "0.875", "-3.69361929540434354488347132088406846633448987589221132669718765720444907695974355621970526282233915266292510806194028974787617603769215340575885677250325184395141009813631036743497295577602400486840393372336518161170478171509470421368073503049444217429991875412313562230396884888471312890430738604295011301495989751277350607921263203909713750142842086203098124e+0"},
+		{"10", "0.25", "1.01545467662923546011652736022101623084422790353100196632882436459602737619963236478638009291203146700360325164578893733391265679393130865941979116313065550286050010608547412556275790229978924188597887296892790163376676130892619445910952045214931322317165590175032743525635592025852699769671198897722534743926152588913840091713102068568996872305254719790774961e+1"},
+		{"1.5", "1", "3.34067754279831100332081266903768876035632219977707950293668182395823001428925128400632084293803796848537684084640731021479022175659900392694821151787808126824045116523702650944102991141150335450596783658445590891575955411555901427611815211022071153219719063006685268875462002351318420147892501986665478846219353124540053769092457463050758234861603597981715521e+0"},
+		{"100.5", "0.999999940395355224609375", "5.98848211033722356914660546391053013968077398682924930409827044166030515289434352469409671012266385796830646963922324762026521808104862813736605383067597937025288080989215207261743015441069676486348195154748579727074397726402763745255359054294752787446094974378435505196616745386230004014982859860624256191643746738268322427286330471340525812661627402223933638e+2"},
+		{"1p-40", "0.5", "9.09494701772928237915039093846516021927666879166305390452231354956500784315266225944567514321786137049987876553424128898137661921728626068980114529493227645507818282099129051536260165939268380219431461368059081501168751058187201661275986410465996089343444476494818754609108165957149436869050727225663626729801596409628227855939344032674854647485155030095110388e-13"},
+		{"3", "-0.5", "3.22978998447864778861974293411808609914915649048343048714989657954364956807913882621644388079990980247357901420880813129057547280787861428661563442477589955971201206629487244564062585828384536835802754022735551949049553466481477887451761273286709429783081588244421284436589120106498988848823940610522852944881712853152632516552372395667738409046782107466975292e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			phi := new(big.Float).SetPrec(prec)
+			phi.Parse(test.phi, 10)
+
+			k := new(big.Float).SetPrec(prec)
+			k.Parse(test.k, 10)
+
+			x := bigfloat.EllipticF(phi, k)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, EllipticF(%v, %v) =\ngot  %g;\nwant %g", prec, test.phi, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestEllipticEInc(t *testing.T) {
+	for _, test := range []struct {
+		phi, k string
+		want   string
+	}{
+		{"1.25", "0.75", "1.10178607305426364250976478997128749736874054704078641780601001720658921655137408225060766081582307882496373605372436860332643673028492149354641582547110473175518835285314029560964612044571085480739039109352840041299694893464259780152158975741581924807146444420358794246492175755102764043072435373541898469770327732481537433340114401791365545897604169449368369e+0"},
+		{"0.5", "0.5", "4.95001703016415192887037549959979626000967737945082348103215919707797081540617537371885850565571521387596206011682922478195390082925809707189111219658127370928376347931414983309707258919336167933701565450847126885577445945137773138020604785491275612409700473926703554349006344868906247760272265135024976231725808274804983885829803413267912999052238548668609789e-1"},
+		{"-2.5", "0.875", "-1.79312151151589247434655671578729814336320388942217859103549183853847739758924613007020183760808024445095498518955473291977403485631501869277495716550563489823091993073948391945195995710425085373913972101001870039733572895429988092115734472804920435257260596577541344262710377030217520203711721706137691447352836933435798615587732774585131403955659692262712219e+0"},
+		{"10", "0.25", "9.84910482854034915744330655234851127307150156859246558366844944346823847871846217855521275526917317926487297597281726154853272971506200596575280490555497879090644685106827462923777510877255544345490505763148601238808666856141427450683561273854022278544763456779612017614059632709977414635249253915621129715943587143274856488934446379137379131742248801137856539e+0"},
+		{"1.5", "1", "9.97494986604054430941723371141487322706651425922115821949974824059345209707870648389450997730410980117583621074343777819835255465912644443295462796893238055221606382209840741277965444608501346248177685664364458176353016893082570245882802035010766190433158686135659491073332561966028102340072828909034827043657231713723494444421432289268212547413139309509550460e-1"},
+		{"100.5", "0.999999940395355224609375", "6.39690738218478005858763067483660642365504784601173675158315192178097791281748485876330026108617288203829287075033924879264306081813160895982440971852312102309845119851236879223284213338814744919753365215089722940214437351401289700658192961550283433776887526053494188154140181096933994648759103410165764007662508899453871077924968549332334776767345750695582480e+1"},
+		{"1p-40", "0.5", "9.09494701772928237915039031153483978072333120833696554240042976650282698204271826848213396609124200656150544504674959482821378420149052338165270348944911919295389109718622101266885716839223058029135761063749705371218312877382022124192187051604727145850051153390579478989717813421454654260226297489687152652911451033111975085312338773719829503925396386272089734e-13"},
+		{"3", "-0.5", "2.79344965985675626122886672900509155818443520855864495092968048196063581481055291798077459653005830256566219040067855860531205238233580866802828043465016678104192847024899449345898959781749879712155297472306572597866468754549194940992286286519155854989860018688401317373778231871844797703848756378250043006513470458124068225062841314521080692947287026381126172e+0"},
+		{"5", "1", "3.04107572533686153110684559384400602664753845603539822186832754576489744191344039692300404457046713340346936153833662106280859829887943420982770352546889828907941199543910560214216233983925800618136056638188764317065058692196297612758833737525426362791398452886637714677377566937104733949147454273557899177559982635889741326937138960521309681568781227703471612e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			phi := new(big.Float).SetPrec(prec)
+			phi.Parse(test.phi, 10)
+
+			k := new(big.Float).SetPrec(prec)
+			k.Parse(test.k, 10)
+
+			x := bigfloat.EllipticEInc(phi, k)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, EllipticEInc(%v, %v) =\ngot  %g;\nwant %g", prec, test.phi, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestCarlsonRF(t *testing.T) {
+	for _, test := range []struct {
+		x, y, z string
+		want    string
+	}{
+		{"1", "2", "0", "1.31102877714605990523241979494555970684137747571581158140841085190039529353520712511514776648071454672306787633589160902780447845069696784735055971738761792021132074858245347596844998996607303619156069540510311094871480042827726988615268477485514444416276324351066450604877041656428425586487611460714834621525698422822776971644070769066586755420461315606623833e+0"},
+		{"2", "3", "4", "5.84082841677151706692849168925667892403513596993032161663093753055082951304129196655413308377040504544723793076689930119058790011327844513561786540430699686836799116647730579665887613287320843676794134690810565633529744942203181463473784533063988103016558794255635634690385146171610430855912449422155806928472345907474891919057157956075835770718353035153618583e-1"},
+		{"0.5", "1", "1000.25", "1.36179551220096559714161268210187142528895144671109356351942016798460580561026236049188855906805603285621308462661264949539331870787583693392071704338647513083523281390759517500665512243130408223084282072007225705312946371781386875208616755121439833428347702750079161056918471077582263456619055555388242726046637587728367499079621740707676303541552166760411791e-1"},
+		{"1p-60", "1", "1", "1.57079632586357404529706749149353331577789747602333375075009149040988982855633668636398203592248904553610990993228577777302425802373094873982412237301732065520385456610554501373265008085064628159372309059143412611306725706924439663244835212665512902544150798151855642275224091620977655788515380864177540190676978119146855627490864234620603544693869790021565958e+0"},
+		{"10", "10", "10", "3.16227766016837933199889354443271853371955513932521682685750485279259443863923822134424810837930029518734728415284005514854885603045388001469051959670015390334492165717925994065915015347411333948412408531692957709047157646104436925787906203780860994182837171154840632855299911859682456420332696160469131433612894979189026652954361267617878135006138818627858046e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			y := new(big.Float).SetPrec(prec)
+			y.Parse(test.y, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			r := bigfloat.CarlsonRF(x, y, z)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, CarlsonRF(%v, %v, %v) =\ngot  %g;\nwant %g", prec, test.x, test.y, test.z, r, want)
+			}
+		}
+	}
+}
+
+func TestCarlsonRD(t *testing.T) {
+	for _, test := range []struct {
+		x, y, z string
+		want    string
+	}{
+		{"0", "2", "1", "1.79721035210338831115988373842048581734081899482347733739551242941960782121587350625382833473469008406386083764586564341389331630955529204614840325464745091758058145625783108160334435952981408672824910366330037348453442034029948234863088917293403813690012558963009325050212783116139615854838993678274339969869539312494756806436709238070441959296666933812170652e+0"},
+		{"2", "3", "4", "1.65105272942610533486713418873083345587805041309558589148314322547724899726845397753902119500328613636376411940067999409493319767373808155216124184019292260283931439236416407744857372469325358617180743477523855684959523634043524341807845804191994532797207711918629238140365333492932896326832795320994113528403402870316908734646616990906210903204162385984593555e-1"},
+		{"0.5", "1", "1000.25", "3.13837078074438304103755915568975844286004589085951348755035789878590778549465323128459370127792245479581885719691242312806707493927952980017402555499327977330057493094592832308650368146426982346720120540115860306646387868049471598170957485709037612568181386228093244239528851591901651869151094881165228825843719780776788788398687767797365801774717801927513473e-4"},
+		{"1", "1p-60", "1", "2.35619448739837720806605640943135202908792124269583150371104328788445936740142059972419339523270697119324925913402828571442028203129598810386649341459530911841376628063777914812307739169969752227144500332528984671116556434498904963809769470245878329084772611965921741199084771945172963183317424321588693548652647214824724996153374242651346200944394561058883359e+0"},
+		{"10", "10", "10", "3.16227766016837933199889354443271853371955513932521682685750485279259443863923822134424810837930029518734728415284005514854885603045388001469051959670015390334492165717925994065915015347411333948412408531692957709047157646104436925787906203780860994182837171154840632855299911859682456420332696160469131433612894979189026652954361267617878135006138818627858046e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			y := new(big.Float).SetPrec(prec)
+			y.Parse(test.y, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			r := bigfloat.CarlsonRD(x, y, z)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, CarlsonRD(%v, %v, %v) =\ngot  %g;\nwant %g", prec, test.x, test.y, test.z, r, want)
+			}
+		}
+	}
+}
+
+func TestCarlsonRJ(t *testing.T) {
+	for _, test := range []struct {
+		x, y, z, p string
+		want       string
+	}{
+		{"0", "1", "2", "3", "7.76886237785823320141902826405455011022980642760229527316691183259525638198132582307081773984756436341039908779335996501869263263359397756321966289771599750454754738836291989122457338346071642670804140315801115939936409420652362380551219420923474317074176564405836600489000416411118566116189286918343501042222122157116623947179109642373517507596658024195253257e-1"},
+		{"2", "3", "4", "5", "1.42975796671567538332338794219857748014666478542326263362188898854638001288179761328264439042165464214315283083286757501120239037715249586169700893642803925908018958151349492466584175101928595769008653800571906136151892258594887654310000595483855139808040016209443981734014741492554078440905042255628501226407272018065091786140405869095678487421290042411933099e-1"},
+		{"0.25", "0.5", "1", "0.125", "5.68055729203596327051134396819621471769066140878295285518366804692223855884147387438134442232890700069093360591766755647952129125334599524644416287187735993281730530398363242182805506428783019498647941348042531021948445924302617867186178962345060005828378457143767723999987028257030545302420854847618446202085274986648516067112503084983785485654145855045865016e+0"},
+		{"1", "2", "3", "1000.5", "2.03881901402840439592171491018057076449346237934481158704145138151632701965642094605360843879872816364943559812626550476981906164438054011892834153060815469442186793086898719235843526041406243689524355543682791968528097132955253332053771944331714651391205987505401455873547099811647938912652499269104214417407150624635926165742770864289157597858340413860683208e-3"},
+		{"10", "10", "10", "10", "3.16227766016837933199889354443271853371955513932521682685750485279259443863923822134424810837930029518734728415284005514854885603045388001469051959670015390334492165717925994065915015347411333948412408531692957709047157646104436925787906203780860994182837171154840632855299911859682456420332696160469131433612894979189026652954361267617878135006138818627858046e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			y := new(big.Float).SetPrec(prec)
+			y.Parse(test.y, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			p := new(big.Float).SetPrec(prec)
+			p.Parse(test.p, 10)
+
+			r := bigfloat.CarlsonRJ(x, y, z, p)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, CarlsonRJ(%v, %v, %v, %v) =\ngot  %g;\nwant %g", prec, test.x, test.y, test.z, test.p, r, want)
+			}
+		}
+	}
+}
+
+func TestEllipticLegendre(t *testing.T) {
+	// E(k)K(k') + E(k')K(k) - K(k)K(k') = π/2, where k² + k'² = 1
+	for _, k := range []float64{0.25, 0.5, 0.75, 0.96875} {
+		for _, prec := range []uint{53, 100, 500, 1000} {
+			x := new(big.Float).SetPrec(prec).SetFloat64(k)
+			y := new(big.Float).SetPrec(prec).SetFloat64(1 - k*k)
+			y.Sqrt(y)
+
+			kx, ex := bigfloat.EllipticK(x), bigfloat.EllipticE(x)
+			ky, ey := bigfloat.EllipticK(y), bigfloat.EllipticE(y)
+
+			s := new(big.Float).Mul(ex, ky)
+			s.Add(s, new(big.Float).Mul(ey, kx))
+			s.Sub(s, new(big.Float).Mul(kx, ky))
+			s.Sub(s, bigfloat.EllipticK(new(big.Float).SetPrec(prec))) // K(0) = π/2
+
+			if s.Sign() != 0 && s.MantExp(nil) > 8-int(prec) {
+				t.Errorf("prec = %d, k = %g: Legendre's relation is off by %g", prec, k, s)
+			}
+		}
+	}
+}
+
+func TestCarlsonRJDegenerate(t *testing.T) {
+	// RJ(x, y, z, z) = RD(x, y, z)
+	for _, prec := range []uint{53, 100, 500, 1000} {
+		x := big.NewFloat(0.5).SetPrec(prec)
+		y := big.NewFloat(3).SetPrec(prec)
+		z := big.NewFloat(1.25).SetPrec(prec)
+		rj := bigfloat.CarlsonRJ(x, y, z, z)
+		rd := bigfloat.CarlsonRD(x, y, z)
+		d := new(big.Float).Sub(rj, rd)
+		if d.Sign() != 0 && d.MantExp(nil)-rd.MantExp(nil) > 2-int(prec) {
+			t.Errorf("prec = %d, RJ(x, y, z, z) = %g; RD(x, y, z) = %g", prec, rj, rd)
+		}
+	}
+}
+
+func TestEllipticHalfPeriod(t *testing.T) {
+	// F(φ, 0) = E(φ, 0) = φ, also for φ on either side of ±π/2 and
+	// 3π/2, where the reduction of φ must pick the right period
+	zero, one := big.NewFloat(0), big.NewFloat(1)
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		h := bigfloat.EllipticK(new(big.Float).SetPrec(prec + 64)) // K(0) = π/2
+		for _, n := range []float64{1, -1, 3} {
+			x := new(big.Float).Mul(h, big.NewFloat(n))
+			for _, mode := range []big.RoundingMode{big.ToZero, big.AwayFromZero} {
+				phi := new(big.Float).SetPrec(prec).SetMode(mode).Set(x)
+				if f := bigfloat.EllipticF(phi, zero); f.Cmp(phi) != 0 {
+					t.Errorf("prec = %d, EllipticF(%g, 0) =\ngot  %g;\nwant %g", prec, phi, f, phi)
+				}
+				if e := bigfloat.EllipticEInc(phi, zero); e.Cmp(phi) != 0 {
+					t.Errorf("prec = %d, EllipticEInc(%g, 0) =\ngot  %g;\nwant %g", prec, phi, e, phi)
+				}
+			}
+		}
+
+		// F(φ, 1) = atanh(sin(φ)) is finite for |φ| < π/2
+		phi := new(big.Float).SetPrec(prec).SetMode(big.ToZero).Set(h)
+		if f := bigfloat.EllipticF(phi, one); f.IsInf() {
+			t.Errorf("prec = %d, EllipticF(%g, 1) = %g; want a finite value", prec, phi, f)
+		}
+	}
+}
+
+func TestEllipticSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"EllipticK(1)", bigfloat.EllipticK(big.NewFloat(1)), math.Inf(+1)},
+		{"EllipticK(-1)", bigfloat.EllipticK(big.NewFloat(-1)), math.Inf(+1)},
+		{"EllipticE(1)", bigfloat.EllipticE(big.NewFloat(1)), 1},
+		{"EllipticF(-0, 0.5)", bigfloat.EllipticF(big.NewFloat(math.Copysign(0, -1)), big.NewFloat(0.5)), math.Copysign(0, -1)},
+		{"EllipticF(2, 1)", bigfloat.EllipticF(big.NewFloat(2), big.NewFloat(1)), math.Inf(+1)},
+		{"EllipticF(-2, 1)", bigfloat.EllipticF(big.NewFloat(-2), big.NewFloat(1)), math.Inf(-1)},
+		{"EllipticEInc(0, 0.5)", bigfloat.EllipticEInc(big.NewFloat(0), big.NewFloat(0.5)), 0},
+		{"CarlsonRF(1, 2, +Inf)", bigfloat.CarlsonRF(big.NewFloat(1), big.NewFloat(2), big.NewFloat(math.Inf(+1))), 0},
+		{"CarlsonRD(+Inf, 0, 1)", bigfloat.CarlsonRD(big.NewFloat(math.Inf(+1)), big.NewFloat(0), big.NewFloat(1)), 0},
+		{"CarlsonRJ(1, 2, 3, +Inf)", bigfloat.CarlsonRJ(big.NewFloat(1), big.NewFloat(2), big.NewFloat(3), big.NewFloat(math.Inf(+1))), 0},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkEllipticK(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		k := big.NewFloat(0.75).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.EllipticK(k)
+			}
+		})
+	}
+}
+
+func BenchmarkEllipticF(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		phi := big.NewFloat(1.25).SetPrec(prec)
+		k := big.NewFloat(0.75).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.EllipticF(phi, k)
+			}
+		})
+	}
+}
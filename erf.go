@@ -0,0 +1,335 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Erf returns a big.Float representation of the error function
+// erf(z). Precision is the same as the one of the argument. The
+// function returns ±1 when z = ±Inf.
+func Erf(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// Erf(±0) = ±0
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(z)
+
+	// Erf(±Inf) = ±1
+	case z.IsInf():
+		return big.NewFloat(float64(z.Sign())).SetPrec(prec)
+	}
+
+	x := new(big.Float).Abs(z)
+	wprec := prec + 64
+
+	var r *big.Float
+	switch xf, _ := x.Float64(); {
+	// erf(x) = 1 - erfc(x) rounds to 1 when erfc(x) < exp(-x²) is
+	// less than half an ulp.
+	case xf*xf > float64(prec+2):
+		r = big.NewFloat(1).SetPrec(prec)
+
+	case erfUseSeries(x, wprec):
+		r = erfSeries(x, wprec)
+
+	// erfc(x) < 1/2 here, so the subtraction loses at most one bit.
+	default:
+		r = erfc(x, wprec)
+		r.Sub(big.NewFloat(1), r)
+	}
+
+	if z.Sign() < 0 {
+		r.Neg(r)
+	}
+	return r.SetPrec(prec)
+}
+
+// Erfc returns a big.Float representation of the complementary
+// error function erfc(z) = 1 - erf(z). Precision is the same as the
+// one of the argument. The function returns 0 when z = +Inf, and 2
+// when z = -Inf.
+func Erfc(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// Erfc(0) = 1
+	case z.Sign() == 0:
+		return big.NewFloat(1).SetPrec(prec)
+
+	// Erfc(+Inf) = 0, Erfc(-Inf) = 2
+	case z.IsInf():
+		return big.NewFloat(float64(1 - z.Sign())).SetPrec(prec)
+	}
+
+	x := new(big.Float).Abs(z)
+	r := erfc(x, prec+64)
+
+	// erfc(-x) = 2 - erfc(x), and there's no cancellation since
+	// erfc(x) < 1 for x > 0.
+	if z.Sign() < 0 {
+		r.Sub(big.NewFloat(2), r)
+	}
+	return r.SetPrec(prec)
+}
+
+// Erfcx returns a big.Float representation of the scaled
+// complementary error function exp(z²)·erfc(z). Precision is the same
+// as the one of the argument. The function returns 0 when z = +Inf,
+// and +Inf when z = -Inf.
+//
+// Erfcx(z) behaves like 1/(z√π) for large positive z, so it can be
+// used when erfc(z) itself would underflow.
+func Erfcx(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// Erfcx(0) = 1
+	case z.Sign() == 0:
+		return big.NewFloat(1).SetPrec(prec)
+
+	// Erfcx(+Inf) = 0, Erfcx(-Inf) = +Inf
+	case z.IsInf():
+		if z.Sign() > 0 {
+			return new(big.Float).SetPrec(prec)
+		}
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	x := new(big.Float).Abs(z)
+	r := erfcx(x, prec+64)
+
+	// erfcx(-x) = 2exp(x²) - erfcx(x), and there's no cancellation
+	// since erfcx(x) < 1 for x > 0.
+	if z.Sign() < 0 {
+		e := expSquare(x, false, prec+64)
+		r.Sub(e.SetMantExp(e, 1), r)
+	}
+	return r.SetPrec(prec)
+}
+
+// ErfInv returns a big.Float representation of the inverse error
+// function, i.e. the value x such that erf(x) = z. Precision is the
+// same as the one of the argument. The function returns ±Inf when
+// z = ±1, and panics if |z| > 1.
+func ErfInv(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	y := new(big.Float).Abs(z)
+	switch one := big.NewFloat(1); {
+	case y.Cmp(one) > 0:
+		panic("ErfInv: argument is out of range")
+
+	// ErfInv(±0) = ±0
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(z)
+
+	// ErfInv(±1) = ±Inf
+	case y.Cmp(one) == 0:
+		return new(big.Float).SetPrec(prec).SetInf(z.Signbit())
+	}
+
+	// q = 1 - |z|, exactly
+	one := big.NewFloat(1)
+	q := new(big.Float).SetPrec(exactSumPrec(y, one)).Sub(one, y)
+
+	// We solve erf(t) = |z| with Newton's method. Close to 1, erf(t)
+	// has no bits to spare, so we solve q = erfc(t) instead. In both
+	// cases the derivative is 2exp(-t²)/√π and
+	//     f(t)/f'(t) = (√π/2)(erf(t) - |z|)exp(t²)
+	//     f(t)/f'(t) = (√π/2)(q·exp(t²) - erfcx(t))
+	var f func(t *big.Float) *big.Float
+	var guess float64
+	if y.Cmp(big.NewFloat(0.5)) <= 0 {
+		f = func(t *big.Float) *big.Float {
+			p := t.Prec()
+			x := Erf(t)
+			x.Sub(x, y)
+			x.Mul(x, expSquare(t, false, p))
+			return x.Mul(x, sqrtPiOverTwo(p))
+		}
+		yf, _ := y.Float64()
+		guess = math.Erfinv(yf)
+	} else {
+		f = func(t *big.Float) *big.Float {
+			p := t.Prec()
+			x := new(big.Float).SetPrec(p).Mul(q, expSquare(t, false, p))
+			x.Sub(x, Erfcx(t))
+			return x.Mul(x, sqrtPiOverTwo(p))
+		}
+		guess = erfcInvGuess(q)
+	}
+
+	// newton expects a guess that is accurate to its precision, so
+	// we polish the float64 estimate with a few low precision steps.
+	t := big.NewFloat(guess)
+	for i := 0; i < 100; i++ {
+		d := f(t)
+		t.Sub(t, d)
+		if d.Sign() == 0 || d.MantExp(nil)-t.MantExp(nil) < -50 {
+			break
+		}
+	}
+
+	x := newton(f, t, prec+64)
+	if z.Sign() < 0 {
+		x.Neg(x)
+	}
+	return x.SetPrec(prec)
+}
+
+// erfcInvGuess returns a float64 estimate of the value t > 0 such
+// that erfc(t) = q, for 0 < q < 1/2.
+func erfcInvGuess(q *big.Float) float64 {
+
+	qf, _ := q.Float64()
+	if qf > 1e-8 {
+		return math.Erfinv(1 - qf)
+	}
+
+	// For small q, erfc(t) ~ exp(-t²)/(t√π), so that
+	//     t² = -log(q) - log(t√π)
+	// and we iterate this starting from t = √(-log(q)).
+	var l float64
+	if qf > 0 && !math.IsInf(1/qf, 0) {
+		l = -math.Log(qf)
+	} else {
+		l, _ = Log(new(big.Float).SetPrec(64).Set(q)).Float64()
+		l = -l
+	}
+	t := math.Sqrt(l)
+	for i := 0; i < 4; i++ {
+		t = math.Sqrt(l - math.Log(t*math.SqrtPi))
+	}
+	return t
+}
+
+// sqrtPiOverTwo returns √π/2 to prec bits of precision.
+func sqrtPiOverTwo(prec uint) *big.Float {
+	x := new(big.Float).SetPrec(prec).Sqrt(pi(prec))
+	return x.SetMantExp(x, -1)
+}
+
+// expSquare returns exp(x²), or exp(-x²) if neg is true, computed
+// to prec bits of precision.
+func expSquare(x *big.Float, neg bool, prec uint) *big.Float {
+
+	// x² is computed exactly, and then rounded with as many extra
+	// bits as its exponent, since its absolute error becomes a
+	// relative error in the result.
+	x2 := new(big.Float).SetPrec(2*x.Prec()).Mul(x, x)
+	if neg {
+		x2.Neg(x2)
+	}
+	guard := uint(0)
+	if e := x2.MantExp(nil); e > 0 {
+		guard = uint(e)
+	}
+	return Exp(x2.SetPrec(prec + guard)).SetPrec(prec)
+}
+
+// erfUseSeries reports whether erf(x) and erfc(x) should be computed
+// using the power series (rather than the continued fraction) at
+// precision prec. x must be positive.
+func erfUseSeries(x *big.Float, prec uint) bool {
+	xf, _ := x.Float64()
+	return xf*xf < float64(prec)/4
+}
+
+// erfSeries returns erf(x), for x > 0, computed to prec bits of
+// precision using the power series erf(x) = (2x/√π)exp(-x²)·S, with
+// S = Σ (2x²)^k / (1·3·5···(2k+1)). Since all the terms are positive
+// the sum is stable, but the number of terms grows as 2x², so it
+// should only be used for small x.
+func erfSeries(x *big.Float, prec uint) *big.Float {
+
+	y := new(big.Float).SetPrec(prec).Mul(x, x)
+	y.SetMantExp(y, 1) // 2x²
+
+	s := big.NewFloat(1).SetPrec(prec)
+	t := big.NewFloat(1).SetPrec(prec)
+	d := new(big.Float)
+	for k := int64(1); ; k++ {
+		t.Mul(t, y)
+		t.Quo(t, d.SetInt64(2*k+1))
+		s.Add(s, t)
+
+		// terms decrease after k > x²
+		if t.MantExp(nil)-s.MantExp(nil) < -int(prec) && d.Cmp(y) > 0 {
+			break
+		}
+	}
+
+	s.Mul(s, expSquare(x, true, prec))
+	s.Mul(s, x)
+	return s.Quo(s, sqrtPiOverTwo(prec))
+}
+
+// erfcxFrac returns exp(x²)·erfc(x), for x > 0, computed to prec bits
+// of precision using a continued fraction, which converges quickly
+// for large x.
+func erfcxFrac(x *big.Float, prec uint) *big.Float {
+
+	//     √π exp(x²) erfc(x) = 1/(x + (1/2)/(x + 1/(x + (3/2)/(x + ...))))
+	//
+
+	// modified Lentz's method
+	f := new(big.Float).SetPrec(prec).Set(x)
+	c := new(big.Float).SetPrec(prec).Set(x)
+	d := new(big.Float).SetPrec(prec)
+	a := new(big.Float).SetPrec(prec)
+	one := big.NewFloat(1)
+	for n := 1; ; n++ {
+		a.SetFloat64(float64(n) / 2)
+
+		d.Mul(d, a)
+		d.Add(d, x)
+		d.Quo(one, d)
+
+		c.Quo(a, c)
+		c.Add(c, x)
+
+		a.Mul(c, d)
+		f.Mul(f, a)
+		if a.Sub(a, one); a.Sign() == 0 || a.MantExp(nil) < -int(prec) {
+			break
+		}
+	}
+
+	f.Mul(f, sqrtPiOverTwo(prec))
+	f.SetMantExp(f, 1)
+	return f.Quo(one, f)
+}
+
+// erfc returns erfc(x), for x > 0, computed to prec bits of
+// precision.
+func erfc(x *big.Float, prec uint) *big.Float {
+
+	if !erfUseSeries(x, prec) {
+		r := erfcxFrac(x, prec)
+		return r.Mul(r, expSquare(x, true, prec))
+	}
+
+	// erfc(x) = 1 - erf(x) is about exp(-x²), so the subtraction
+	// loses about x²·log2(e) bits.
+	xf, _ := x.Float64()
+	wprec := prec + uint(xf*xf*math.Log2E) + 2
+	r := erfSeries(x, wprec)
+	r.Sub(big.NewFloat(1), r)
+	return r.SetPrec(prec)
+}
+
+// erfcx returns exp(x²)·erfc(x), for x > 0, computed to prec bits of
+// precision.
+func erfcx(x *big.Float, prec uint) *big.Float {
+	if !erfUseSeries(x, prec) {
+		return erfcxFrac(x, prec)
+	}
+	r := erfc(x, prec)
+	return r.Mul(r, expSquare(x, false, prec))
+}
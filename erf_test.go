@@ -0,0 +1,265 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestErf(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.5", "5.20499877813046537682746653891964528736451575757963700058805725647193521716853570914788218734787757032966124386194391236065414690590890774606218098025036974170019197111861974461665405441098882490188441080500828453049757294736373230522916200753413217037493374603883438860037474305778532879930190345302884982477590381235534394185839733296531911457380507194914395e-1"},
+		{"1", "8.42700792949714869341220635082609259296066997966302908459937897834717254096010841261983325348144888454158261532021694364852339058255206789773439787059295581338613503514696419439293156805899120718638712819448293958693792915460949319560365274681776589159084365902708523255067745071827599313775668060032609439512975209617448342549723090623610086974505592150793585e-1"},
+		{"2.5", "9.99593047982555041060435784260025087279651322596286579860879221230902993970150334358038455921236775741438807571403048765684009335499584725527529722807683629878446462885734647471137887392129303061753667432906165040696754312935897728285364710943425901904693172537430847844968554754752589947863102171505732038930879133194594953330440770468614300315582826883364842e-1"},
+		{"-1.5", "-9.66105146475310727066976261645947858681410479257636780449967846442132854423750726244866549280277188407762774200717661177711249452361534210232734106560903930684081870805333124325940333476189220895812513028308724033516906559166165703589690048294727536889810092637531557606254302742045382132374706238935961857938234670879846221004687010684790984776635150951092716e-1"},
+		{"0.0009765625", "1.10193243007181470417125349611857369383654443045554975822688286233780905822811093902626682695557275993882657517413577794214263964456185595869523990588192776100157341376820779269673806073861751142673959196549822072337433572475666849889972685694073943200008929875144533102056836397691842414225593571007077488702912081025517375771458821171593612607649340713865811e-3"},
+		{"1p-100", "8.90134211187497387391365065260476111176423733740454357714340856257228149021035085174290378151948644907376847713061477693593346722936939828375812086172037458717185292574546385779057064701102004732110272942717947160039898256122928520415499183719905304338363540511254026879439345141579231362095439674666233655772562469004076213811437625251223270490956440961214655e-31"},
+		{"6", "9.99999999999999978480263287501086883406649600812615369522485938311457899472107948943662761515072139615611399180810207071900376917063519701121205244837393687252996234716827523628515333860433755860279291530683474070302937806349069793747872335403571095557609437065539752779076641582480687206628929885505999258102527451683451330649986183947215134931194624727223702e-1"},
+		{"-30", "-1.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Erf(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Erf(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestErfc(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.5", "4.79500122186953462317253346108035471263548424242036299941194274352806478283146429085211781265212242967033875613805608763934585309409109225393781901974963025829980802888138025538334594558901117509811558919499171546950242705263626769477083799246586782962506625396116561139962525694221467120069809654697115017522409618764465605814160266703468088542619492805085605e-1"},
+		{"1", "1.57299207050285130658779364917390740703933002033697091540062102165282745903989158738016674651855111545841738467978305635147660941744793210226560212940704418661386496485303580560706843194100879281361287180551706041306207084539050680439634725318223410840915634097291476744932254928172400686224331939967390560487024790382551657450276909376389913025494407849206415e-1"},
+		{"2.5", "4.06952017444958939564215739974912720348677403713420139120778769097006029849665641961544078763224258561192428596951234315990664500415274472470277192316370121553537114265352528862112607870696938246332567093834959303245687064102271714635289056574098095306827462569152155031445245247410052136897828494267961069120866805405046669559229531385699684417173116635157598e-4"},
+		{"-1.5", "1.96610514647531072706697626164594785868141047925763678044996784644213285442375072624486654928027718840776277420071766117771124945236153421023273410656090393068408187080533312432594033347618922089581251302830872403351690655916616570358969004829472753688981009263753155760625430274204538213237470623893596185793823467087984622100468701068479098477663515095109272e+0"},
+		{"1p-100", "9.99999999999999999999999999999109865788812502612608634934739523888823576266259545642285659143742771850978964914825709621848051355092623152286938522306406653277063060171624187913827962541282814707425453614220942935298897995267889727057282052839960101743877071479584500816280094695661636459488745973120560654858420768637904560325333766344227437530995923786188562e-1"},
+		{"10", "2.08848758376254475700078629495778861156081811932116372701221371393817469583344029061076638428572355398152593923652403986234211502091941145238947648186630731129740495856959261707324695428857609186323589494039160999353570802651170155762623907511868485969322043543588026510296748982334285460792947132343544179917806966588512742398568021712249921580789930808550881e-45"},
+		{"27.5", "7.52668545044657639001942130813685178668424021872045776782364542939924042282445466562178066237938087847962697868805570592951755042274653161181137706402170104711826891106273248849112499259816861780680971342053146367917087300605422343621104891625904724121466305090047481393323160643410749207028748552407577685844578296491661687644599218619998272476749414795280550e-331"},
+		{"-6", "1.99999999999999997848026328750108688340664960081261536952248593831145789947210794894366276151507213961561139918081020707190037691706351970112120524483739368725299623471682752362851533386043375586027929153068347407030293780634906979374787233540357109555760943706553975277907664158248068720662892988550599925810252745168345133064998618394721513493119462472722370e+0"},
+		{"100", "6.40596142492173203902133914858639414821441439946033805776710765024890255482950583122794586657987149709790256472708651289306940645249752351806416471026671278252367403118636018518227635161722662961683610787138255886828641893610189682488447876621411843758740100017157303042092758577423813374167970092530099177467281725143272760955756296563677809421100790483153119e-4346"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Erfc(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Erfc(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestErfcx(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.5", "6.15690344192925874870793422683741936782306391265631605690826589016709915650725745639345218386258125637623877920072964144000229170838005598011963879213907307072902584460231343057657221524819433857167330609205381276562839672118376733646873928707982193261863086212935366805097915801670957604058344993310653114413520081783781578582464847336264482813712027710088444e-1"},
+		{"3", "1.79001151181389950419294815313620987227985364106854215662758839536719675342221102728982897151911745316563063892705570399200082837052342035591027886632938813989938158297276135217303122508128384772366412996992528325882437836219418923861773998368626377019176769972732937531330886205459200813283484590970848203679143122961243029744830857714978506000855473317998412e-1"},
+		{"-2.5", "1.03581484297262290829872993238936404527265840297320922506015990329973754049137263119810343746984485005610612618479748113858127082925264682140803108620597147829188799416248180260191658581725247273361793367781432421605094579578993774205671828267326158381982707940613707700589186611632515992051492748758649559474632068263170212581108627705421349006574881862956838e+3"},
+		{"40", "1.41003359833778136247412860601480947122099298817721603418515306267275990391412585549165051328343187741013645856172636372025233908903211219198742734507789027925095549061201429974333540501305378839367795456957105917647516382900280365259940710896898294543001893907622333310644779923572995285311306330353557589381735863944333424140956354956241438849358796135600628e-2"},
+		{"1000", "5.64189301453387654199745028061695727166402115006965391673638970767186229885814782690983227506072016767266822575462281211230620805882442425518711057849332170597619464024927674528136211644809467361464839938204718879174941800039189711777135024356241663305560237627403107226584376658003520804428073541602107232315880894006564258893307794745180085547169550556169168e-4"},
+		{"1p100", "4.45067105593748693695682532630238055588211866870227178857170381967624111454915481392242529839896775923948180069457395928829886850539642732374892701543078970905443528215690462048901640741692337733129459756999599271972482487703066335848423142694475017346944454824415565543526668873288030534823510209718666263289529941041622080136665123908880145485103100801760775e-31"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Erfcx(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Erfcx(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestErfInv(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.5", "4.76936276204469873381418353643130559808969749059470644703882695919383447774646733488695915869989009948033038673470868618155420075448731790616361246494898282994498741684033901570477983112712561362544702924595945313334539266790889305672572200305573331135520829645570521950340375218932276917315873770472097434245338444189588311924967238754514339513183791855548158e-1"},
+		{"0.25", "2.25312055012178104725014013952277554782118447807246757600782894957738225172138956401564657293050407488636464528219984708595986281391634572046746943684717671946288888055805323041791622132688886131023060105263278191882778609409396531508455178326892382727031890494461100919565764595335769742067386186578675059128050711222391893865352309164176592600718994780785268e-1"},
+		{"0.9375", "1.31715033498613074888392979208444879960261109497866833161061899116439905827833952472983336673415824090799396450363315769029346359629787950429406785602850102522211911035879619519397704750248291482329021297333379781938050945597845218750387927003002853648230322647831222056174676878982632069202452065857935914585182963975086163009646179717538177429805461008352728e+0"},
+		{"0.999969482421875", "2.94833074316755714473350495487617213602521634839876944410116845559330475372547965918771995355896826125650312312388860868163151599675538073571079387069667805359949381399838773843298068221252116075338486690593066631161079748912322160821487351405005850566591325475264675838934262964640503963622475806077540972221938649810716969935160141447346216082190046252774081e+0"},
+		{"-0.75", "-8.13419847597618541690289359893421085324724835957501548147510003331798051560939545334356775714728408569996577684997233944340993095962334053941513089369554375271469076231871046499640994888463084832446386737403454919904987176653024550489225224517201289133285928571258166282154501383332008850681150730974643417364043314130052074312139918904617636595430994179245915e-1"},
+		{"0.0009765625", "8.65456197967137553451212037611710509627982038974251798345369386597911487569862436924793580954311180151714109849967834100662203034018130335887670128138285876819124726924811525357942088688471899615365989502948128298764063413925393236649945922921518774898060711304979676723382860207247240341802670308769812542962037925626705884590089895019755135241550164363670962e-4"},
+		{"1p-100", "6.99109774643896834192017984231471543797450499711944309803483296133407478627320154296904218878188990006728504241480589383584540107577600170316712897476478400917720427213835602717627318550256318961924005435777361031673778126162132599114136056053392154159319330938433596343360687556019826217236493222023730853443216303211036753145823849553792533545699315372744416e-31"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.ErfInv(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, ErfInv(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestErfInvTail(t *testing.T) {
+	for _, test := range []struct {
+		k    int // z = 1 - 2**(-k)
+		want string
+	}{
+		{50, "5.68612844131039098005160412756166735491592560557162354166609343800404128191242228330117605068472217641467271765225718929630962104817375935069099517751501469508050707360034561884141706962304151212915014654052667962241777680428443023938586569452559113594693223082509936248487499554546088502585645394891239453581770149775199487934755029331508560948592890409615444e+0"},
+		{150, "1.00541949288180951993726918244889195359533411067413577335717938102012936626844951611100941463589385822479074630900307981923652250526515565610636109229967625779002810504763924683745798903580057601967159837995009768007889806598346769751092508892428537017721511566650044883693332473018321525516527012886733570869187699206530736924484119686682600104294046264794283e+1"},
+		{600, "2.03052666580935417917266777311461979651103313699936002589098824096557639809332068594579531439353849850950744779696829564853765491293845751652019218323588027933782275522151501989826858114308199277053437495239414822730602094678131664402383766726689446309006255303568749547196254928050923305926304947410663928731668219318188920862247409327502451732835943798309895e+1"},
+	} {
+		for _, prec := range []uint{64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			if prec <= uint(test.k) {
+				continue
+			}
+
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := big.NewFloat(1).SetPrec(prec)
+			z.Sub(z, new(big.Float).SetMantExp(big.NewFloat(1), -test.k))
+
+			x := bigfloat.ErfInv(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, ErfInv(1 - 2**-%d) =\ngot  %g;\nwant %g", prec, test.k, x, want)
+			}
+		}
+	}
+}
+
+func testErfFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := (2*rand.Float64() - 1) * scale
+
+		z := big.NewFloat(r)
+		for _, f := range []struct {
+			name string
+			got  func(*big.Float) *big.Float
+			want func(float64) float64
+		}{
+			{"Erf", bigfloat.Erf, math.Erf},
+			{"Erfc", bigfloat.Erfc, math.Erfc},
+		} {
+			x64, _ := f.got(z).Float64()
+			want := f.want(r)
+
+			// math.Erf and math.Erfc are not correctly rounded, so
+			// we allow a small relative error.
+			if d := math.Abs(x64 - want); d > 1e-15*math.Abs(want) {
+				t.Errorf("%s(%g) =\n got %g;\nwant %g", f.name, r, x64, want)
+			}
+		}
+
+		// ErfInv(erf(r)) = r
+		y := math.Erf(r)
+		if math.Abs(y) > 0.99 {
+			continue
+		}
+		x64, _ := bigfloat.ErfInv(big.NewFloat(y)).Float64()
+		if want := math.Erfinv(y); math.Abs(x64-want) > 1e-14*math.Abs(want) {
+			t.Errorf("ErfInv(%g) =\n got %g;\nwant %g", y, x64, want)
+		}
+	}
+}
+
+func TestErfFloat64(t *testing.T) {
+	for _, scale := range []float64{1e-20, 1e-4, 1, 4, 10, 26} {
+		testErfFloat64(scale, 2e2, t)
+	}
+}
+
+func TestErfSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		-0.0,
+		math.Inf(+1),
+		math.Inf(-1),
+	} {
+		z := big.NewFloat(f)
+		for _, g := range []struct {
+			name string
+			got  func(*big.Float) *big.Float
+			want float64
+		}{
+			{"Erf", bigfloat.Erf, math.Erf(f)},
+			{"Erfc", bigfloat.Erfc, math.Erfc(f)},
+			{"Erfcx", bigfloat.Erfcx, math.Exp(f*f) * math.Erfc(f)},
+			{"ErfInv", bigfloat.ErfInv, math.Erfinv(f)},
+		} {
+			if math.IsNaN(g.want) {
+				// exp(Inf)·erfc(Inf) = Inf·0
+				g.want = 0
+			}
+			if g.name == "ErfInv" && math.IsInf(f, 0) {
+				continue
+			}
+			x64, acc := g.got(z).Float64()
+			if x64 != g.want || math.Signbit(x64) != math.Signbit(g.want) || acc != big.Exact {
+				t.Errorf("%s(%g) = %g (%v); want %g (Exact)", g.name, f, x64, acc, g.want)
+			}
+		}
+	}
+
+	for _, f := range []float64{1, -1} {
+		x64, _ := bigfloat.ErfInv(big.NewFloat(f)).Float64()
+		if want := math.Erfinv(f); x64 != want {
+			t.Errorf("ErfInv(%g) = %g; want %g", f, x64, want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkErf(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Erf(z)
+			}
+		})
+	}
+}
+
+func BenchmarkErfInv(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(0.75).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.ErfInv(z)
+			}
+		})
+	}
+}
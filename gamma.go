@@ -0,0 +1,189 @@
+package bigfloat
+
+import "math/big"
+
+// Values of z up to maxExactGamma for which Gamma and LogGamma
+// compute (z-1)! exactly, as a big.Int, before rounding it.
+const maxExactGamma = 1 << 12
+
+// Gamma returns a big.Float representation of Γ(z). Precision is the
+// same as the one of the argument. The function returns ±Inf when
+// z = ±0, +Inf when z = +Inf, and panics if z is a negative integer
+// or -Inf. The result is exact (or correctly rounded) for positive
+// integers up to 4096.
+func Gamma(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// Gamma(±0) = ±Inf
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInf(z.Signbit())
+
+	// Gamma(+Inf) = +Inf
+	case z.IsInf() && z.Sign() > 0:
+		return new(big.Float).SetPrec(prec).SetInf(false)
+
+	case z.IsInf() || z.IsInt() && z.Sign() < 0:
+		panic("Gamma: argument is a negative integer")
+	}
+
+	// Gamma(n) = (n-1)! for small positive integers
+	if f := factorial(z); f != nil {
+		return new(big.Float).SetPrec(prec).SetInt(f)
+	}
+
+	// Gamma(z) = ±exp(log|Γ(z)|)
+	//
+	// The absolute error in log|Γ(z)| becomes a relative error in
+	// the result, so we need as many extra bits as its magnitude.
+	guard := uint(64)
+	for {
+		lg, sign, scale := logGamma(z, prec+guard)
+		if scale <= int(guard-64) {
+			x := Exp(lg)
+			if sign < 0 {
+				x.Neg(x)
+			}
+			return x.SetPrec(prec)
+		}
+		guard = 64 + uint(scale)
+	}
+}
+
+// LogGamma returns a big.Float representation of the natural
+// logarithm of |Γ(z)|, and the sign of Γ(z), as -1 or +1. Precision
+// is the same as the one of the argument. As for math.Lgamma, the
+// function returns +Inf when z = ±0, +Inf or a negative integer, and
+// -Inf when z = -Inf.
+func LogGamma(z *big.Float) (*big.Float, int) {
+
+	prec := z.Prec()
+
+	switch {
+	// LogGamma(-Inf) = -Inf
+	case z.IsInf() && z.Sign() < 0:
+		return new(big.Float).SetPrec(prec).SetInf(true), 1
+
+	// LogGamma(+Inf) = LogGamma(0) = LogGamma(-n) = +Inf
+	case z.IsInf() || z.Sign() == 0 || z.IsInt() && z.Sign() < 0:
+		return new(big.Float).SetPrec(prec).SetInf(false), 1
+	}
+
+	// LogGamma(n) = log((n-1)!) for small positive integers
+	if f := factorial(z); f != nil {
+		return Log(new(big.Float).SetPrec(prec + 64).SetInt(f)).SetPrec(prec), 1
+	}
+
+	// log|Γ(z)| vanishes at z = 1 and 2, close to which it is much
+	// smaller than the terms of the Stirling series.
+	guard := uint(64)
+	for {
+		lg, sign, scale := logGamma(z, prec+guard)
+		lost := scale
+		if lg.Sign() != 0 {
+			lost -= lg.MantExp(nil)
+		} else {
+			lost += int(prec + guard)
+		}
+		if lost <= int(guard-64) {
+			return lg.SetPrec(prec), sign
+		}
+		guard = 64 + uint(lost)
+	}
+}
+
+// factorial returns (z-1)! if z is a positive integer not larger
+// than maxExactGamma, and nil otherwise.
+func factorial(z *big.Float) *big.Int {
+	if !z.IsInt() || z.Sign() <= 0 || z.Cmp(big.NewFloat(maxExactGamma)) > 0 {
+		return nil
+	}
+	n, _ := z.Int64()
+	return new(big.Int).MulRange(1, n-1)
+}
+
+// logGamma returns log|Γ(z)| computed to prec bits of precision, the
+// sign of Γ(z), and the binary exponent of the largest quantity that
+// was involved in the computation, so that the absolute error on the
+// result is about 2**(scale-prec). z must be finite, and not a
+// negative integer or zero.
+func logGamma(z *big.Float, prec uint) (*big.Float, int, int) {
+
+	if z.Cmp(big.NewFloat(0.5)) >= 0 {
+		lg, scale := logGammaStirling(z, prec)
+		return lg, 1, scale
+	}
+
+	// For z < 1/2 we use the reflection formula
+	//     Γ(z)Γ(1-z) = π / sin(πz)
+	// Γ(1-z) is positive, so the sign of Γ(z) is the one of sin(πz).
+	one := big.NewFloat(1)
+	w := new(big.Float).SetPrec(exactSumPrec(z, one)).Sub(one, z)
+	lg, scale := logGammaStirling(w, prec)
+
+	sin, _ := sincosPi(new(big.Float).SetPrec(prec).Set(z))
+	sign := sin.Sign()
+	logSin := Log(sin.Abs(sin))
+	if e := logSin.MantExp(nil); e > scale {
+		scale = e
+	}
+
+	lg.Sub(Log(pi(prec)), lg.Add(lg, logSin))
+	return lg, sign, scale
+}
+
+// logGammaStirling returns log Γ(z) for z >= 1/2, and the binary
+// exponent of the largest quantity that was involved in the
+// computation.
+func logGammaStirling(z *big.Float, prec uint) (*big.Float, int) {
+
+	// Shift z to x = z + n >= prec/2, using
+	//     Γ(z) = Γ(z+n) / (z(z+1)...(z+n-1))
+	// so that the Stirling series below converges quickly.
+	one := big.NewFloat(1)
+	lim := big.NewFloat(float64(prec / 2))
+	x := new(big.Float).SetPrec(prec).Set(z)
+	p := big.NewFloat(1).SetPrec(prec)
+	for x.Cmp(lim) < 0 {
+		p.Mul(p, x)
+		x.Add(x, one)
+	}
+
+	// Stirling's series:
+	//     log Γ(x) = (x - 1/2)log(x) - x + log(2π)/2
+	//                + Σ B_2k / (2k(2k-1)x^(2k-1))
+	lx := Log(x)
+	lg := new(big.Float).SetPrec(prec).Sub(x, big.NewFloat(0.5))
+	lg.Mul(lg, lx)
+	scale := lg.MantExp(nil)
+	lg.Sub(lg, x)
+
+	t := new(big.Float).SetPrec(prec).SetMantExp(pi(prec), 1)
+	t = Log(t)
+	lg.Add(lg, t.SetMantExp(t, -1))
+
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	xk := new(big.Float).SetPrec(prec).Set(x) // x^(2k-1)
+	b := new(big.Float).SetPrec(prec)
+	for k := 1; ; k++ {
+		b.SetRat(bernoulli(2 * k))
+		t.Quo(b, xk)
+		t.Quo(t, b.SetInt64(int64(2*k*(2*k-1))))
+		lg.Add(lg, t)
+		if t.Sign() == 0 || t.MantExp(nil) < -int(prec) {
+			break
+		}
+		xk.Mul(xk, x2)
+	}
+
+	if p.Cmp(one) != 0 {
+		lp := Log(p)
+		if e := lp.MantExp(nil); e > scale {
+			scale = e
+		}
+		lg.Sub(lg, lp)
+	}
+
+	return lg, scale
+}
@@ -0,0 +1,172 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestGamma(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.25", "3.62560990822190831193068515586767200299516768288006546743337799956991924353872912161836013672338430036147175139242071996589152409402255997742645889036145060641374489685419499920192677303799463089221241231832370799208439736990709390562092923234287027419144860395713683503686548799596836847647585148909040416634076303397180668059577342379085590807145783129763564e+0"},
+		{"0.5", "1.77245385090551602729816748334114518279754945612238712821380778985291128459103218137495065673854466541622682362428257066623615286572442260252509370960278706846203769865310512284992517302895082622893209537926796280017463901535147972051670019018523401858544697449491264031392177552590621640541933250090639840761373347747515343366798978936585183640879545116516174e+0"},
+		{"1.5", "8.86226925452758013649083741670572591398774728061193564106903894926455642295516090687475328369272332708113411812141285333118076432862211301262546854801393534231018849326552561424962586514475413114466047689633981400087319507675739860258350095092617009292723487247456320156960887762953108202709666250453199203806866738737576716833994894682925918204397725582580869e-1"},
+		{"3.75", "4.42298841046025056288783918870043299535369166114006736240871054288982948791547532065291904540070906844503204429504430719776325369709375187061543857685712911033905239002512402163912869033770279037755362279777330950328104385396877927721418547682097936272991981167298576402941080854232908629510294771485765368976657615106630314569835816124065390796634517090924374e+0"},
+		{"10.25", "6.39232598779576794283758401876084967153425284996821146812097993778926038418199917923591586191563983720449982368072263650811759779631523030668202171935067963874397494377929205473094687184512226092281707315044754185620391776236162399509128084335418999245645637047305122186439637806651648395000792460987610268272188485772106031930335556583140146894669305658706500e+5"},
+		{"100.5", "9.32096310408271660834910980914191043790649703816236115401611751941207659776116235522180760538360602236099936763871992206318352563311020298264297847934206379884609456044512373429720239887432013413187016143284546186649528973162476033295303087770631166672750035868437553548413076577028093172903638311514802954460747226901006526445791316099961519991191139675010997e+156"},
+		{"0.0009765625", "1.02342374934556783036949871823993027088896816575115598563850490451210078994021833575756525156327689747403758533062495486602224571052804217230757891380943924145988161265841638525086999734033860561209693189295130407084734924795032104211195502284178694531275794223149095661590062817431415614428031021942587293150959320131453060634044956989325711152547207639428105e+3"},
+		{"-0.5", "-3.54490770181103205459633496668229036559509891224477425642761557970582256918206436274990131347708933083245364724856514133247230573144884520505018741920557413692407539730621024569985034605790165245786419075853592560034927803070295944103340038037046803717089394898982528062784355105181243281083866500181279681522746695495030686733597957873170367281759090233032348e+0"},
+		{"-2.75", "-1.00449798323031225958252748907156280602463520218322160136682334187218541812328425880335518939522335594430386185764037263124267334206685644396230895663693869614926265540549125519014421417502881548528828739555288879434372914144612125523696740636339263007901606343401193697991078455299556529218378569394712496383467027781037068206982467152647090093754675845475619e+0"},
+		{"-10.125", "-1.68483126205251745621688232788989547739415699913329841386130391375446117024644411865070149132855323691280842829701925650404402756491496880650658271711243058014640934380241444495515680239847559205730296377945265939292027320015454173636133808222156866113564903757904933995815965275138740550054518157254629963896991299536373151765489690434210042936552423520298483e-6"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Gamma(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Gamma(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestGammaIntegers(t *testing.T) {
+	f := big.NewInt(1)
+	for n := int64(1); n <= 200; n++ {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec).SetInt(f)
+			x := bigfloat.Gamma(new(big.Float).SetPrec(prec).SetInt64(n))
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Gamma(%d) =\ngot  %g;\nwant %g", prec, n, x, want)
+			}
+		}
+		f.Mul(f, big.NewInt(n))
+	}
+}
+
+func TestLogGamma(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.25", "1.28802252469807745737061044021971729592537756511286055049998702253396126267569883621607381641761386618678876040807516222611115631569418371589095629394839247181888273749285519158672355321087103025880188122907457983509855604356809219745067024507964179004532955295713873561579509917177866436859880069716605255724831314537937299721522621026238287090507850592621738e+0"},
+		{"3.75", "1.48681557859341705554058180144420502541294865016307493876254818943015936625972125547163011678181146722996035536743106057401401355776364886726549800760126281440113868118577906492016622350736128442452502059458218848947653293582311159526898400122670891728931075252253585182452839331300248956315596245572853170157566363857604209251568985471749156322412408274821369e+0"},
+		{"1.0009765625", "-5.62903179991204631702149921685145027728795413902576274798513272425213754963160789554980479744903914044584785470419007532286812661053752033952302248328170992197766783680073582477534184086787907368567064149490835307427525620063682959526061236147557296551884995597271816384880151016226809406067706092581364331335161294716932180077012564600630582492543250649659695e-4"},
+		{"2.125", "5.77598515303438716073882662630915907902612616184169838772734829939418138295320459343108641752920829639813686591211485287509006033396702416816598503934970184743032708010745993232578992413499721290821197563355588032665353737157335446590053259086897557219139065894133826077214717601711956993639043656666380539599142654755265596142937824764405973008634404618777842e-2"},
+		{"1000.5", "5.90867417584867748868387473406262488049701546825861975468913636578363629160282029532290785374628062038057953819563557929881968074369370586304541260423889420041557355503604738186368043657541792690291779508662845819115219476772860020945606765380834409345885075886361187553188620510103143849916764010188513495884856733747519753109117669688963366589400059802723345e+3"},
+		{"1e10", "2.20258509288810581470041923123460126556427276020288743189382264192899836495443642734572758188014269647068122543522224971187517898899858023481168395836601745917157363365990035913195645616434100747960189455977837430732253212338189156573002197923027819685447364516525600934893937031864597156731054473404325449173548336883575142202781623898856442158897142600850995e+11"},
+		{"-2.75", "4.48789753595577331146161063794197027209622993236425981141488678867521460995218211492481699188758424305019737155227145187695208803887299852991844702656199184061901849475255557747206916345449010023977409392481728215494332998045657208517513971370797617094403339897129590306041527792985328584794707110119530624097458597762213451352908145389729970672739116474797113e-3"},
+		{"-10.125", "-1.32938451403895384842367850898231917030033948118343738538907952813834970515424604562180690716349430224482877489317336765254540278881863938629906664437936551543338869957917607100268293937095235559053674237392723205621981921534692825022882309185054622014869635983072285925970521170148114501882978635910177597091773464418333637169196763982351720549696887018075469e+1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x, _ := bigfloat.LogGamma(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LogGamma(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func testGammaFloat64(scale float64, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		r := rand.Float64() * scale
+
+		z := big.NewFloat(r)
+		x64, acc := bigfloat.Gamma(z).Float64()
+		want := math.Gamma(r)
+
+		// math.Gamma is only accurate to a few ulps, and less than
+		// that for large arguments.
+		if math.Abs(x64-want)/math.Abs(want) > 1e-13 || acc != big.Exact {
+			t.Errorf("Gamma(%g) =\n got %g (%s);\nwant %g (Exact)", z, x64, acc, want)
+		}
+
+		lg, sign := bigfloat.LogGamma(z)
+		lg64, acc := lg.Float64()
+		lwant, lsign := math.Lgamma(r)
+
+		// LogGamma's relative error is large near its zeros, so use
+		// an absolute error there.
+		if math.Abs(lg64-lwant) > 1e-14*math.Max(1, math.Abs(lwant)) || sign != lsign || acc != big.Exact {
+			t.Errorf("LogGamma(%g) =\n got %g, %d (%s);\nwant %g, %d (Exact)", z, lg64, sign, acc, lwant, lsign)
+		}
+	}
+}
+
+func TestGammaFloat64Small(t *testing.T) {
+	testGammaFloat64(1, 5e2, t)
+	testGammaFloat64(-1, 5e2, t)
+}
+
+func TestGammaFloat64Medium(t *testing.T) {
+	testGammaFloat64(10, 5e2, t)
+	testGammaFloat64(-10, 5e2, t)
+}
+
+func TestGammaFloat64Big(t *testing.T) {
+	testGammaFloat64(100, 5e2, t)
+	testGammaFloat64(-100, 5e2, t)
+}
+
+func TestGammaSpecialValues(t *testing.T) {
+	for _, f := range []float64{
+		+0.0,
+		math.Copysign(0, -1),
+		math.Inf(+1),
+	} {
+		z := big.NewFloat(f)
+		x64, acc := bigfloat.Gamma(z).Float64()
+		want := math.Gamma(f)
+		if x64 != want || acc != big.Exact {
+			t.Errorf("Gamma(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+
+	for _, f := range []float64{
+		+0.0,
+		-2,
+		math.Inf(+1),
+		math.Inf(-1),
+	} {
+		z := big.NewFloat(f)
+		lg, _ := bigfloat.LogGamma(z)
+		x64, acc := lg.Float64()
+		want, _ := math.Lgamma(f)
+		if x64 != want || acc != big.Exact {
+			t.Errorf("LogGamma(%g) =\n got %g (%s);\nwant %g (Exact)", f, x64, acc, want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkGamma(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Gamma(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// GammaP returns a big.Float representation of the regularized lower
+// incomplete gamma function P(a, x) = γ(a, x)/Γ(a). Precision is the
+// same as the one of x. The function returns 0 when x = 0 and 1 when
+// x = +Inf, and panics if a <= 0, if a is infinite, or if x < 0.
+func GammaP(a, x *big.Float) *big.Float {
+	if r := gammaIncSpecial(a, x, false, "GammaP"); r != nil {
+		return r
+	}
+	r, _ := gammaInc(a, x, false, x.Prec()+64)
+	return r.SetPrec(x.Prec())
+}
+
+// GammaQ returns a big.Float representation of the regularized upper
+// incomplete gamma function Q(a, x) = Γ(a, x)/Γ(a) = 1 - P(a, x).
+// Precision is the same as the one of x. The function returns 1 when
+// x = 0 and 0 when x = +Inf, and panics if a <= 0, if a is infinite,
+// or if x < 0.
+func GammaQ(a, x *big.Float) *big.Float {
+	if r := gammaIncSpecial(a, x, true, "GammaQ"); r != nil {
+		return r
+	}
+	r, _ := gammaInc(a, x, true, x.Prec()+64)
+	return r.SetPrec(x.Prec())
+}
+
+// GammaPInv returns a big.Float representation of the value x such
+// that P(a, x) = p. Precision is the same as the one of p. The
+// function returns 0 when p = 0 and +Inf when p = 1, and panics if
+// a <= 0, if a is infinite, or if p is not in [0, 1].
+func GammaPInv(a, p *big.Float) *big.Float {
+	return gammaIncInv(a, p, false, "GammaPInv")
+}
+
+// GammaQInv returns a big.Float representation of the value x such
+// that Q(a, x) = q. Precision is the same as the one of q. The
+// function returns +Inf when q = 0 and 0 when q = 1, and panics if
+// a <= 0, if a is infinite, or if q is not in [0, 1].
+func GammaQInv(a, q *big.Float) *big.Float {
+	return gammaIncInv(a, q, true, "GammaQInv")
+}
+
+// gammaIncCheck panics if a <= 0 or if a is infinite, using fname in
+// the panic message.
+func gammaIncCheck(a *big.Float, fname string) {
+	if a.Sign() <= 0 || a.IsInf() {
+		panic(fname + ": parameter is not positive and finite")
+	}
+}
+
+// gammaIncSpecial panics if P(a, x) and Q(a, x) are not defined,
+// using fname in the panic message. When x = 0 or x = +Inf it
+// returns P(a, x), or Q(a, x) if upper is true, with the precision
+// of x, and it returns nil otherwise.
+func gammaIncSpecial(a, x *big.Float, upper bool, fname string) *big.Float {
+
+	gammaIncCheck(a, fname)
+	if x.Sign() < 0 {
+		panic(fname + ": argument is negative")
+	}
+
+	// P(a, 0) = 0, P(a, +Inf) = 1
+	if x.Sign() == 0 || x.IsInf() {
+		r := new(big.Float).SetPrec(x.Prec())
+		if x.IsInf() != upper {
+			r.SetInt64(1)
+		}
+		return r
+	}
+	return nil
+}
+
+// gammaInc returns P(a, x), or Q(a, x) if upper is true, for a > 0
+// and x > 0 finite, computed to prec bits of precision; and the
+// factor x^a·exp(-x)/Γ(a), which is x times their derivative.
+func gammaInc(a, x *big.Float, upper bool, prec uint) (*big.Float, *big.Float) {
+
+	// Following Numerical Recipes, 6.2, we use the series for P when
+	// x < a+1, and the continued fraction for Q otherwise, where it
+	// converges quickly. Computing the other function as 1 - P or
+	// 1 - Q loses as many bits as the result is smaller than 1,
+	// so we need that many extra bits.
+	one := big.NewFloat(1)
+	frac := new(big.Float).Sub(x, one).Cmp(a) > 0
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		f := gammaIncFactor(a, x, wprec)
+		r := new(big.Float).SetPrec(wprec)
+		if frac {
+			r.Mul(f, gammaIncFrac(a, x, wprec))
+		} else {
+			r.Mul(f, gammaIncSeries(a, x, wprec))
+			r.Quo(r, a)
+		}
+
+		if frac == upper {
+			return r.SetPrec(prec), f.SetPrec(prec)
+		}
+		r.Sub(one, r)
+		if lost := lostBits(r, 1, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec), f.SetPrec(prec)
+	}
+}
+
+// gammaIncFactor returns x^a·exp(-x)/Γ(a), for a > 0 and x > 0,
+// computed to prec bits of precision.
+func gammaIncFactor(a, x *big.Float, prec uint) *big.Float {
+
+	// x^a·exp(-x)/Γ(a) = exp(a·log(x) - x - log Γ(a))
+	//
+	// The absolute error on the argument of exp becomes a relative
+	// error in the result, so we need as many extra bits as its
+	// magnitude.
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+		lg, _, scale := logGamma(a, wprec)
+		t := Log(new(big.Float).SetPrec(wprec).Set(x))
+		t.Mul(t, a)
+		for _, e := range []int{t.MantExp(nil), x.MantExp(nil)} {
+			if e > scale {
+				scale = e
+			}
+		}
+		if scale > int(guard) {
+			guard = uint(scale)
+			continue
+		}
+		t.Sub(t, x)
+		t.Sub(t, lg)
+		return Exp(t).SetPrec(prec)
+	}
+}
+
+// gammaIncSeries returns
+//
+//	Σ x^k / ((a+1)(a+2)···(a+k))
+//
+// for k >= 0, computed to prec bits of precision, so that
+// P(a, x) = x^a·exp(-x)/Γ(a+1) times the sum. a and x must be
+// positive.
+func gammaIncSeries(a, x *big.Float, prec uint) *big.Float {
+
+	s := big.NewFloat(1).SetPrec(prec)
+	t := big.NewFloat(1).SetPrec(prec)
+	d := new(big.Float).SetPrec(prec)
+	for k := int64(1); ; k++ {
+		d.SetInt64(k)
+		d.Add(d, a)
+		t.Mul(t, x)
+		t.Quo(t, d)
+		s.Add(s, t)
+		if d.Cmp(x) > 0 && t.MantExp(nil)-s.MantExp(nil) < -int(prec) {
+			return s
+		}
+	}
+}
+
+// gammaIncFrac returns exp(x)·x^(-a)·Γ(a, x), for x > 0 and
+// x+1-a > 0, computed to prec bits of precision using a continued
+// fraction.
+func gammaIncFrac(a, x *big.Float, prec uint) *big.Float {
+
+	// modified Lentz's method, for
+	//     b0 + a1/(b1 + a2/(b2 + ...))
+	// with b_n = x + 2n + 1 - a and a_n = -n(n - a).
+	one := big.NewFloat(1)
+	b := new(big.Float).SetPrec(prec).Add(x, one)
+	b.Sub(b, a)
+	f := new(big.Float).SetPrec(prec).Set(b)
+	c := new(big.Float).SetPrec(prec).Set(b)
+	d := new(big.Float).SetPrec(prec)
+	an := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for n := int64(1); ; n++ {
+		an.SetInt64(n)
+		an.Sub(a, an)
+		an.Mul(an, t.SetInt64(n))
+		b.Add(b, t.SetInt64(2))
+
+		d.Mul(d, an)
+		d.Add(d, b)
+		d.Quo(one, d)
+
+		c.Quo(an, c)
+		c.Add(c, b)
+
+		t.Mul(c, d)
+		f.Mul(f, t)
+		if t.Sub(t, one); t.Sign() == 0 || t.MantExp(nil) < -int(prec) {
+			break
+		}
+	}
+
+	return f.Quo(one, f)
+}
+
+// gammaIncInv returns the value x such that P(a, x) = y, or
+// Q(a, x) = y if upper is true, with the precision of y, using fname
+// in the panic messages.
+func gammaIncInv(a, y *big.Float, upper bool, fname string) *big.Float {
+
+	prec := y.Prec()
+	gammaIncCheck(a, fname)
+
+	one := big.NewFloat(1)
+	switch {
+	case y.Sign() < 0 || y.Cmp(one) > 0:
+		panic(fname + ": argument is not in [0, 1]")
+
+	// P(a, 0) = 0, P(a, +Inf) = 1
+	case y.Sign() == 0 || y.Cmp(one) == 0:
+		r := new(big.Float).SetPrec(prec)
+		if (y.Sign() == 0) == upper {
+			r.SetInf(false)
+		}
+		return r
+	}
+
+	// We solve for the smaller of P and Q, which is known with full
+	// relative precision, and since x·P'(a, x) = x^a·exp(-x)/Γ(a)
+	//     f(t)/f'(t) = ±(P(a, t) - p)·t / (t^a·exp(-t)/Γ(a))
+	if y.Cmp(big.NewFloat(0.5)) > 0 {
+		y = new(big.Float).SetPrec(exactSumPrec(y, one)).Sub(one, y)
+		upper = !upper
+	}
+	f := func(t *big.Float) *big.Float {
+		p := t.Prec()
+		x, g := gammaInc(a, t, upper, p+16)
+		x.Sub(x, y)
+		x.Mul(x, t)
+		x.Quo(x, g)
+		if upper {
+			x.Neg(x)
+		}
+		return x.SetPrec(p)
+	}
+
+	// newton expects a guess that is accurate to its precision, so
+	// we polish the estimate with a few low precision steps, halving
+	// t whenever a step would make it negative.
+	t := gammaIncInvGuess(a, y, upper)
+	for i := 0; i < 100; i++ {
+		d := f(t)
+		if u := new(big.Float).Sub(t, d); u.Sign() > 0 {
+			t = u
+		} else {
+			t.SetMantExp(t, -1)
+		}
+		if d.Sign() == 0 || d.MantExp(nil)-t.MantExp(nil) < -50 {
+			break
+		}
+	}
+
+	return newton(f, t, prec+64).SetPrec(prec)
+}
+
+// gammaIncInvGuess returns a rough estimate of the value x such that
+// P(a, x) = y, or Q(a, x) = y if upper is true, for 0 < y <= 1/2.
+func gammaIncInvGuess(a, y *big.Float, upper bool) *big.Float {
+
+	af, _ := a.Float64()
+	ly, _ := Log(new(big.Float).SetPrec(64).Set(y)).Float64()
+	g, _ := LogGamma(new(big.Float).SetPrec(64).Set(a))
+	lg, _ := g.Float64()
+
+	// Wilson–Hilferty approximation, for a > 1 and y not too small,
+	//     x = a·(1 - 1/(9a) + z/(3√a))³
+	// where z is the y-quantile of the normal distribution, as long
+	// as 2y-1 is not too close to -1 in float64.
+	if af > 1 && ly > -30 {
+		yf, _ := y.Float64()
+		z := math.Sqrt2 * math.Erfinv(2*yf-1)
+		if upper {
+			z = -z
+		}
+		w := 1 - 1/(9*af) + z/(3*math.Sqrt(af))
+		if w > 0 {
+			return big.NewFloat(af * w * w * w)
+		}
+	}
+
+	if !upper {
+		// P(a, x) ~ x^a/Γ(a+1) for small x
+		l := (ly + lg + math.Log(af)) / af
+		return Exp(big.NewFloat(l))
+	}
+
+	// Q(a, x) ~ x^(a-1)·exp(-x)/Γ(a) for large x, so that
+	//     x = -log(y) + (a-1)·log(x) - log Γ(a)
+	// and we iterate this starting from x = max(1, -log(y)).
+	x := math.Max(1, -ly)
+	for i := 0; i < 10; i++ {
+		if v := -ly + (af-1)*math.Log(x) - lg; v > 0 {
+			x = v
+		}
+	}
+	return big.NewFloat(x)
+}
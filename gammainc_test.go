@@ -0,0 +1,234 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestGammaP(t *testing.T) {
+	for _, test := range []struct {
+		a, x string
+		want string
+	}{
+		{"1", "2", "8.64664716763387308106000505027515596592368454090424118531841127345926625898512310062901877509342951244922712810366447787550653128107146961841104865003293994408749772441317417695161579424154615319964005916553975187128646249843356466004063914986099504705782941423980514288776029040091164049094282354717487206204619777625596149317308687045406341131956333937686308e-1"},
+		{"0.5", "2", "9.54499736103641585599434725666933125056447552596643132032667999739047419294448503303461695848420770154976925636115409175104155957095571425952408638944835043393175406461528619918753177595069872605295863277453195042611826360699542311805409721757562878744859887234066319222145195939630202150433809449439421014270172983669971942479663603153904193969728937190466514e-1"},
+		{"3", "1.5", "1.91153169461941870116858293480454610134627843566087433303596969493821270961485150731331477544406233438451461611210246371619222866338808275589196235361776672498692397234388009303896565488317865387247464775433638929425556048517002313097282294428101153782280279161856191773103990372236929398951226651104601470420349829944289548283675555365555859245079994513018319e-1"},
+		{"10", "30", "9.99992878249137184422908353339165659709406464536369342531451471402792543338913309382628188632992546532916450795249088408558013907855420173118955823277457958607003748409250562912222776907798109189061530275384383684853355770384192870723818064569143731476266074627772152792998825213870819242553194995235121844174650055642375442028168367207026019750537268052845134e-1"},
+		{"0.0009765625", "0.5", "9.99453066606330996964499385772412144320199216154326855389031414975742383789577940534063051641054589396689810227830979442371295216755498945032655107943802974014222178949593370087560948433809185123210747448034943008362850941243253387427270045913472565463883174784854990344244013981665283950748179770210383651183975773770823996572584119979397510002297659160139224e-1"},
+		{"100", "50", "3.20006532458512529377866106603165082425956184302149654863199308848010396805653572900521039741400001138450529761836694573234700851422802142555869201352685283345976279176388041015843538362287390474309369840219669407143028602630375455460692579264731901950724120968968695399299690726567070005859607877236585584175802324951605211376764385567643497020601761424179029e-10"},
+		{"1000", "1000", "5.04205244180215508503777843602118799189241188704881710672303005919871901184466451338377709719846311993345874643682907225218975589371198290713124046595246304942154919734189360747158523514713303626205364726743206109082386110409644955081785957677318703409235127067954187776247287076120559845256812985254935472629762439956218024641018892262668672310165622597158549e-1"},
+		{"0.25", "1p-20", "3.44769512778200637351114187371355219987372694865426563638925741355593015634825854766862193672946793936684085915947922389904811742831696319336962391169083076965234167424645930217040886899467664664788327565939217519914182320653888624165788556040118502838210481468112941012485806577197547814485915927140921065318207674841585451729836756080027370699842505537006421e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.GammaP(a, x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, GammaP(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestGammaQ(t *testing.T) {
+	for _, test := range []struct {
+		a, x string
+		want string
+	}{
+		{"1", "2", "1.35335283236612691893999494972484403407631545909575881468158872654073374101487689937098122490657048755077287189633552212449346871892853038158895134996706005591250227558682582304838420575845384680035994083446024812871353750156643533995936085013900495294217058576019485711223970959908835950905717645282512793795380222374403850682691312954593658868043666062313692e-1"},
+		{"0.5", "0.25", "4.79500122186953462317253346108035471263548424242036299941194274352806478283146429085211781265212242967033875613805608763934585309409109225393781901974963025829980802888138025538334594558901117509811558919499171546950242705263626769477083799246586782962506625396116561139962525694221467120069809654697115017522409618764465605814160266703468088542619492805085605e-1"},
+		{"3", "1.5", "8.08846830538058129883141706519545389865372156433912566696403030506178729038514849268668522455593766561548538388789753628380777133661191724410803764638223327501307602765611990696103434511682134612752535224566361070574443951482997686902717705571898846217719720838143808226896009627763070601048773348895398529579650170055710451716324444634444140754920005486981681e-1"},
+		{"10", "30", "7.12175086281557709164666083434029059353546363065746854852859720745666108669061737181136700745346708354920475091159144198609214457982688104417672254204139299625159074943708777722309220189081093846972461561631514664422961580712927618193543085626852373392537222784720700117478612918075744680500476487815582534994435762455797183163279297398024946273194715486582832e-6"},
+		{"0.0009765625", "0.5", "5.46933393669003035500614227587855679800783845673144610968585024257616210422059465936948358945410603310189772169020557628704783244501054967344892056197025985777821050406629912439051566190814876789252551965056991637149058756746612572729954086527434536116825215145009655755986018334716049251820229789616348816024226229176003427415880020602489997702340839860776315e-4"},
+		{"100", "150", "5.92454033548391582941139743668203026436828546846834460493676885879920411051377650236875604131962608694405543448289230127264727029009711469438054447730693545058378496209378674754678525480156915370720842532601988972593609250919952344681393445699798967620154612600053532376496520693569895581949373302247244122097320690093057137685146369903822782868458086325450398e-6"},
+		{"2.5", "1e4", "8.54299024169270913958377326851456556629522500689614096901550488633835786695201722809108230544108843466739388015094262206044177569928855924915013886182980685351646352572943712467155408927227025454814856887510741045498762427716405974674617852923589045516879287543895410203255484429410183121884162792570293295528098786920587630379679131165391462110045242481269076e-4338"},
+		{"20", "0.5", "9.99999999999999999999999756453457007468568411616345788532757665407071969711852100055437244864788121062231403068110624304009626041380141368729828039477537428745636699281005164873202916296066345783339299341708762735203024845297452559602749028225005434646681383241594015650265196007126959077908503159733391815945768440820305423174438275644358862994570650956313993e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.GammaQ(a, x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, GammaQ(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestGammaPInv(t *testing.T) {
+	for _, test := range []struct {
+		a, p string
+		want string
+	}{
+		{"3", "0.5", "2.67406031372356031791345726459169498962278779502782227808771881813756554916991857644521749538358247064791039794659707302195843830268629258912410695418738451948543526215880290371822536983357543044622972143932025395200774970929196940172867316149064955113319001702396895596747833382663838969115247499733690249153451975416838446054475428348087926600228503496308154e+0"},
+		{"0.5", "0.25", "5.07655221338107726033445410094660642490502640829200061613235875929411703464037326821876984417100866392925258479423530110258970856371989100904703964620063877335525109066320445332268760204965173084091611814169025686838112646381210869996590259871940362059938807181561291404860021632909667802550600863411054097797667870172394939712641411553175490600232270686760631e-2"},
+		{"10", "1p-1000", "3.57253701240815629956438759390441889588123067866707204155380570172400792716128150705137951690025779009236585423365825706352649076433249288220683332192084358778166765771213125968813863299683948014400649697317279146156973500277475489300459951018252492120861813060974955365335811344427480829288978854876134370081759140523704311627674386880901818375015545657977561e-30"},
+		{"100", "0.875", "1.11593138272778846263003178885229029606790453797840637710648968363683577541238840169136010611829987222201529625392554013821708403354874646444201544915232385972653050479641958362781548758057291719771272698375197097237086157998423759405265423278406594242240451185633660423227641491220496659945376555159224116099968602090840757426003609513225620997445481564764113e+2"},
+		{"0.125", "0.75", "6.56039812638558188239659319295010659314337661502060559523210869367950181037515235367692666523211167746342417097883283761203277689123348582996782805316277230716836887723494677257406343714620514321009607214035642313631811526479309716164078131879234315055974971791613241093020918482696601594498616057443377411271340720797238489651436667829840684199975871346074598e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			p := new(big.Float).SetPrec(prec)
+			p.Parse(test.p, 10)
+
+			x := bigfloat.GammaPInv(a, p)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, GammaPInv(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.p, x, want)
+			}
+		}
+	}
+}
+
+func TestGammaQInv(t *testing.T) {
+	for _, test := range []struct {
+		a, q string
+		want string
+	}{
+		{"3", "0.5", "2.67406031372356031791345726459169498962278779502782227808771881813756554916991857644521749538358247064791039794659707302195843830268629258912410695418738451948543526215880290371822536983357543044622972143932025395200774970929196940172867316149064955113319001702396895596747833382663838969115247499733690249153451975416838446054475428348087926600228503496308154e+0"},
+		{"0.5", "0.75", "5.07655221338107726033445410094660642490502640829200061613235875929411703464037326821876984417100866392925258479423530110258970856371989100904703964620063877335525109066320445332268760204965173084091611814169025686838112646381210869996590259871940362059938807181561291404860021632909667802550600863411054097797667870172394939712641411553175490600232270686760631e-2"},
+		{"20", "0.125", "2.52117922846022265933000047976345584298667036008928911965910123648990939589639885529956622130907542980737331403601064628883065311361939488741217289511555864825739037018881385741152135389121876655276191221677122093307778636925607189142351261350979601281546165857284693232483637804493531044254305903241379648420315626876693847642890986147125430464262773318352472e+1"},
+		{"3", "1p-16000", "1.11082928162420761373529627462497433814765451197963858810022942648156898455885705529119553684471122176505147223001206629789736303073319239131014764725243667782747610640100799710547407858903310365151254464076054312820345853567707965992815064940491769610450317801149752257446866341964951169447727555017821853468708129133127190535403977324810570224460537945899332e+4"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			q := new(big.Float).SetPrec(prec)
+			q.Parse(test.q, 10)
+
+			x := bigfloat.GammaQInv(a, q)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, GammaQInv(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.q, x, want)
+			}
+		}
+	}
+}
+
+func TestGammaIncFloat64(t *testing.T) {
+	// P(1, x) = 1 - exp(-x), P(1/2, x) = erf(√x), P(a, x) + Q(a, x) = 1
+	for _, x := range []float64{0.125, 0.5, 1, 2.5, 10, 40} {
+		p1, _ := bigfloat.GammaP(big.NewFloat(1), big.NewFloat(x)).Float64()
+		if want := -math.Expm1(-x); math.Abs(p1-want) > 1e-15*want {
+			t.Errorf("GammaP(1, %g) = %g; want %g", x, p1, want)
+		}
+		ph, _ := bigfloat.GammaP(big.NewFloat(0.5), big.NewFloat(x)).Float64()
+		if want := math.Erf(math.Sqrt(x)); math.Abs(ph-want) > 1e-15*want {
+			t.Errorf("GammaP(0.5, %g) = %g; want %g", x, ph, want)
+		}
+		for _, a := range []float64{0.25, 3, 12.5} {
+			p, _ := bigfloat.GammaP(big.NewFloat(a), big.NewFloat(x)).Float64()
+			q, _ := bigfloat.GammaQ(big.NewFloat(a), big.NewFloat(x)).Float64()
+			if math.Abs(p+q-1) > 1e-15 {
+				t.Errorf("GammaP(%g, %g) + GammaQ(%g, %g) = %g; want 1", a, x, a, x, p+q)
+			}
+		}
+	}
+}
+
+func TestGammaIncInvTail(t *testing.T) {
+	// Q(a, GammaQInv(a, q)) = q and P(a, GammaPInv(a, p)) = p for
+	// probabilities far below the float64 range
+	const prec = 200
+	for _, a := range []float64{0.5, 3, 40} {
+		for _, s := range []string{"1e-400", "1e-5000"} {
+			y, _, _ := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+			a := big.NewFloat(a).SetPrec(prec)
+			for _, f := range []struct {
+				name string
+				inv  func(a, y *big.Float) *big.Float
+				fn   func(a, x *big.Float) *big.Float
+			}{
+				{"GammaP", bigfloat.GammaPInv, bigfloat.GammaP},
+				{"GammaQ", bigfloat.GammaQInv, bigfloat.GammaQ},
+			} {
+				x := f.inv(a, y)
+				d := new(big.Float).Quo(f.fn(a, x), y)
+				d.Sub(d, big.NewFloat(1))
+				if d.Sign() != 0 && d.MantExp(nil) > -prec+16 {
+					t.Errorf("%s(%v, %sInv(%v, %s)) has relative error %g", f.name, a, f.name, a, s, d)
+				}
+			}
+		}
+	}
+}
+
+func TestGammaIncSpecialValues(t *testing.T) {
+	one, zero, inf := big.NewFloat(1), big.NewFloat(0), big.NewFloat(math.Inf(+1))
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"GammaP(1, 0)", bigfloat.GammaP(one, zero), 0},
+		{"GammaP(1, +Inf)", bigfloat.GammaP(one, inf), 1},
+		{"GammaQ(1, 0)", bigfloat.GammaQ(one, zero), 1},
+		{"GammaQ(1, +Inf)", bigfloat.GammaQ(one, inf), 0},
+		{"GammaPInv(1, 0)", bigfloat.GammaPInv(one, zero), 0},
+		{"GammaPInv(1, 1)", bigfloat.GammaPInv(one, one), math.Inf(+1)},
+		{"GammaQInv(1, 0)", bigfloat.GammaQInv(one, zero), math.Inf(+1)},
+		{"GammaQInv(1, 1)", bigfloat.GammaQInv(one, one), 0},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkGammaP(b *testing.B) {
+	a := big.NewFloat(2.5)
+	for _, prec := range []uint{1e2, 1e3} {
+		x := big.NewFloat(3.75).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.GammaP(a, x)
+			}
+		})
+	}
+}
+
+func BenchmarkGammaPInv(b *testing.B) {
+	a := big.NewFloat(2.5)
+	for _, prec := range []uint{1e2, 1e3} {
+		p := big.NewFloat(0.25).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.GammaPInv(a, p)
+			}
+		})
+	}
+}
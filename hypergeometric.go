@@ -0,0 +1,331 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Hyp0F1 returns a big.Float representation of the confluent
+// hypergeometric limit function ₀F₁(; b; z) = Σ z^k/((b)_k·k!), for
+// k >= 0, where (b)_k = b(b+1)···(b+k-1). Precision is the same as
+// the one of z. The function panics if b is a non-positive integer,
+// or if b or z are infinite.
+func Hyp0F1(b, z *big.Float) *big.Float {
+	return hyper(nil, []*big.Float{b}, z, "Hyp0F1")
+}
+
+// Hyp1F1 returns a big.Float representation of Kummer's confluent
+// hypergeometric function ₁F₁(a; b; z) = Σ (a)_k/(b)_k · z^k/k!, for
+// k >= 0, where (x)_k = x(x+1)···(x+k-1). Precision is the same as
+// the one of z. The function panics if b is a non-positive integer
+// and the series does not terminate before it, or if any of the
+// arguments is infinite.
+func Hyp1F1(a, b, z *big.Float) *big.Float {
+	return hyper([]*big.Float{a}, []*big.Float{b}, z, "Hyp1F1")
+}
+
+// Hyp2F1 returns a big.Float representation of the Gauss
+// hypergeometric function ₂F₁(a, b; c; z) = Σ (a)_k(b)_k/(c)_k ·
+// z^k/k!, for k >= 0, where (x)_k = x(x+1)···(x+k-1), analytically
+// continued to z < -1. Precision is the same as the one of z. The
+// function panics if c is a non-positive integer and the series does
+// not terminate before it, if z > 1 or z = 1 and c-a-b <= 0 and the
+// series does not terminate, or if any of the arguments is infinite.
+func Hyp2F1(a, b, c, z *big.Float) *big.Float {
+	return hyper([]*big.Float{a, b}, []*big.Float{c}, z, "Hyp2F1")
+}
+
+// HypPFQ returns a big.Float representation of the generalized
+// hypergeometric function
+//
+//	ₚF_q(a₁, ..., aₚ; b₁, ..., b_q; z) = Σ (a₁)_k···(aₚ)_k/((b₁)_k···(b_q)_k) · z^k/k!
+//
+// for k >= 0, where (x)_k = x(x+1)···(x+k-1), with p = len(a) and
+// q = len(b). Precision is the same as the one of z. Unless the
+// series terminates, because one of the a is a non-positive integer,
+// it must converge: when p = q+1, |z| must be less than 1 (for p = 2
+// and q = 1, the range is the one of Hyp2F1), and p must not be
+// larger than q+1. The function panics if one of the b is a
+// non-positive integer and the series does not terminate before it,
+// if the series does not converge, or if any of the arguments is
+// infinite.
+func HypPFQ(a, b []*big.Float, z *big.Float) *big.Float {
+	return hyper(a, b, z, "HypPFQ")
+}
+
+// hyper returns ₚF_q(a; b; z) with the precision of z, or panics if
+// it is not defined, using fname in the panic messages.
+func hyper(a, b []*big.Float, z *big.Float, fname string) *big.Float {
+
+	prec := z.Prec()
+
+	if z.IsInf() {
+		panic(fname + ": argument is infinite")
+	}
+	for _, x := range append(append([]*big.Float{}, a...), b...) {
+		if x.IsInf() {
+			panic(fname + ": parameter is infinite")
+		}
+	}
+
+	// The series terminates after the term for k = n if a_i = -n,
+	// and (b_j)_k vanishes for k > m if b_j = -m.
+	n, term := hypTerminates(a...)
+	for _, x := range b {
+		if m, ok := nonPositiveInt(x); ok && (!term || m < n) {
+			panic(fname + ": parameter is a non-positive integer")
+		}
+	}
+
+	// pFq(a; b; 0) = 1
+	if z.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(prec)
+	}
+
+	one := big.NewFloat(1)
+	p, q := len(a), len(b)
+	switch {
+	case term:
+		return hypSum(a, b, z, prec+64).SetPrec(prec)
+
+	case p > q+1:
+		panic(fname + ": series does not converge")
+
+	case p == 2 && q == 1:
+		switch z.Cmp(one) {
+		case 1:
+			panic(fname + ": argument is greater than 1")
+		case 0:
+			s := new(big.Float).SetPrec(exactSumPrec(b[0], a[0])).Sub(b[0], a[0])
+			if s.Cmp(a[1]) <= 0 {
+				panic(fname + ": series does not converge")
+			}
+		}
+		return hyp2f1(a[0], a[1], b[0], z, prec+64).SetPrec(prec)
+
+	case p == q+1 && new(big.Float).Abs(z).Cmp(one) >= 0:
+		panic(fname + ": argument is not in (-1, 1)")
+
+	// Kummer's transformation
+	//     ₁F₁(a; b; z) = exp(z)·₁F₁(b-a; b; -z)
+	// gives a series with no cancellation when z < 0.
+	case p == 1 && q == 1 && z.Sign() < 0:
+		wprec := prec + 64
+		ba := new(big.Float).SetPrec(exactSumPrec(b[0], a[0])).Sub(b[0], a[0])
+		x := hypSum([]*big.Float{ba}, b, new(big.Float).Neg(z), wprec)
+		e := new(big.Float).SetPrec(wprec)
+		if exp := z.MantExp(nil); exp > 0 {
+			e.SetPrec(wprec + uint(exp))
+		}
+		x.Mul(x, Exp(e.Set(z)))
+		return x.SetPrec(prec)
+	}
+
+	return hypSum(a, b, z, prec+64).SetPrec(prec)
+}
+
+// nonPositiveInt returns m, and true, if x = -m for a non-negative
+// integer m. Values of m that do not fit in an int32 are returned as
+// math.MaxInt32.
+func nonPositiveInt(x *big.Float) (int, bool) {
+	if !x.IsInt() || x.Sign() > 0 {
+		return 0, false
+	}
+	m, acc := x.Int64()
+	if acc != big.Exact || m < math.MinInt32 {
+		return math.MaxInt32, true
+	}
+	return int(-m), true
+}
+
+// hypTerminates returns the smallest n, and true, such that one of
+// xs is -n for a non-negative integer n.
+func hypTerminates(xs ...*big.Float) (int, bool) {
+	n, term := 0, false
+	for _, x := range xs {
+		if m, ok := nonPositiveInt(x); ok && (!term || m < n) {
+			n, term = m, true
+		}
+	}
+	return n, term
+}
+
+// hypSum returns ₚF_q(a; b; z) computed to prec bits of precision
+// using its series, which must converge or terminate.
+func hypSum(a, b []*big.Float, z *big.Float, prec uint) *big.Float {
+
+	// When the terms have different signs the sum can be much
+	// smaller than its largest term.
+	guard := uint(0)
+	for {
+		x, scale := hypSeries(a, b, z, prec+guard)
+		if lost := lostBits(x, scale, prec+guard); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return x.SetPrec(prec)
+	}
+}
+
+// hypSeries returns the series for ₚF_q(a; b; z) computed to prec
+// bits of precision, and the binary exponent of its largest term.
+func hypSeries(a, b []*big.Float, z *big.Float, prec uint) (*big.Float, int) {
+
+	// The ratio of consecutive terms
+	//     (a₁+k)···(aₚ+k)/((b₁+k)···(b_q+k)) · z/(k+1)
+	// is monotone for k larger than the parameters, so we stop when
+	// it is less than 1 and the terms are negligible.
+	kmin := 0.0
+	af := make([]float64, len(a))
+	bf := make([]float64, len(b))
+	for i, x := range a {
+		af[i], _ = x.Float64()
+		kmin = math.Max(kmin, math.Abs(af[i]))
+	}
+	for i, x := range b {
+		bf[i], _ = x.Float64()
+		kmin = math.Max(kmin, math.Abs(bf[i]))
+	}
+	zf, _ := z.Float64()
+
+	r := big.NewFloat(1).SetPrec(prec)
+	t := big.NewFloat(1).SetPrec(prec)
+	u := new(big.Float).SetPrec(prec)
+	scale := 0
+	for k := 0; ; k++ {
+		kf := big.NewFloat(float64(k))
+		for _, x := range a {
+			t.Mul(t, u.Add(x, kf))
+		}
+		if t.Sign() == 0 {
+			return r, scale
+		}
+		for _, x := range b {
+			t.Quo(t, u.Add(x, kf))
+		}
+		t.Mul(t, z)
+		t.Quo(t, u.SetInt64(int64(k+1)))
+		r.Add(r, t)
+		if e := t.MantExp(nil); e > scale {
+			scale = e
+		}
+
+		if float64(k) > kmin && t.MantExp(nil)-r.MantExp(nil) < -int(prec) {
+			ratio := math.Abs(zf) / float64(k+2)
+			for _, x := range af {
+				ratio *= math.Abs(x + float64(k+1))
+			}
+			for _, x := range bf {
+				ratio /= math.Abs(x + float64(k+1))
+			}
+			if ratio < 1 {
+				return r, scale
+			}
+		}
+	}
+}
+
+// hyp2f1 returns ₂F₁(a, b; c; z) computed to prec bits of precision,
+// for z <= 1. When z = 1, c-a-b must be positive. c must not be a
+// non-positive integer, unless the series terminates before it.
+func hyp2f1(a, b, c, z *big.Float, prec uint) *big.Float {
+
+	one := big.NewFloat(1)
+	ab := []*big.Float{a, b}
+	cs := []*big.Float{c}
+	if _, ok := hypTerminates(ab...); ok || new(big.Float).Abs(z).Cmp(big.NewFloat(0.5)) <= 0 {
+		return hypSum(ab, cs, z, prec)
+	}
+
+	ca := new(big.Float).SetPrec(exactSumPrec(c, a)).Sub(c, a)
+	cb := new(big.Float).SetPrec(exactSumPrec(c, b)).Sub(c, b)
+	y := new(big.Float).SetPrec(exactSumPrec(z, one)).Sub(one, z)
+
+	switch {
+	// For z < -1/2 we use Pfaff's transformation
+	//     ₂F₁(a, b; c; z) = (1-z)^(-a)·₂F₁(a, c-b; c; z/(z-1))
+	// where 0 < z/(z-1) < 1.
+	case z.Sign() < 0:
+		w := new(big.Float).SetPrec(prec).Quo(z, y)
+		w.Neg(w)
+		x := hyp2f1(a, cb, c, w, prec)
+		return x.Mul(x, zetaPow(y, new(big.Float).Neg(a), prec))
+
+	// If c-a or c-b is a non-positive integer, Euler's
+	// transformation
+	//     ₂F₁(a, b; c; z) = (1-z)^(c-a-b)·₂F₁(c-a, c-b; c; z)
+	// gives a series that terminates.
+	case z.Cmp(one) < 0:
+		if _, ok := hypTerminates(ca, cb); ok {
+			s := new(big.Float).SetPrec(exactSumPrec(ca, b)).Sub(ca, b)
+			x := hypSum([]*big.Float{ca, cb}, cs, z, prec)
+			return x.Mul(x, zetaPow(y, s, prec))
+		}
+	}
+
+	// Otherwise we use
+	//     ₂F₁(a, b; c; z) = A·₂F₁(a, b; a+b-c+1; 1-z)
+	//                       + B·(1-z)^(c-a-b)·₂F₁(c-a, c-b; c-a-b+1; 1-z)
+	// where A = Γ(c)Γ(c-a-b)/(Γ(c-a)Γ(c-b)) and
+	// B = Γ(c)Γ(a+b-c)/(Γ(a)Γ(b)). When z = 1 the second term
+	// vanishes.
+	//
+	// When c-a-b is an integer, A and B have poles that cancel out,
+	// so we perturb b by 2^(-prec), and then the two terms are about
+	// 2^prec times larger than the result. In general, the guard
+	// bits cover the gap between the larger term and the sum.
+	s := new(big.Float).SetPrec(exactSumPrec(ca, b)).Sub(ca, b)
+	guard := uint(0)
+	if s.IsInt() {
+		eps := new(big.Float).SetMantExp(one, -int(prec))
+		b = new(big.Float).SetPrec(exactSumPrec(b, eps)).Add(b, eps)
+		cb = new(big.Float).SetPrec(exactSumPrec(c, b)).Sub(c, b)
+		s = new(big.Float).SetPrec(exactSumPrec(ca, b)).Sub(ca, b)
+		guard = prec + uint(bitsLen(s))
+	}
+	ns := new(big.Float).Neg(s)
+	s1 := new(big.Float).SetPrec(exactSumPrec(s, one)).Add(s, one)
+	ns1 := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(one, s)
+
+	for {
+		wprec := prec + guard
+		gamma := func(x *big.Float) *big.Float {
+			return Gamma(new(big.Float).SetPrec(wprec).Set(x))
+		}
+
+		t := gamma(c)
+		t.Mul(t, gamma(s))
+		t.Quo(t, gamma(ca))
+		t.Quo(t, gamma(cb))
+		if z.Cmp(one) == 0 {
+			return t.SetPrec(prec)
+		}
+		t.Mul(t, hypSum([]*big.Float{a, b}, []*big.Float{ns1}, y, wprec))
+		scale := t.MantExp(nil)
+
+		u := gamma(c)
+		u.Mul(u, gamma(ns))
+		u.Quo(u, gamma(a))
+		u.Quo(u, gamma(b))
+		u.Mul(u, zetaPow(y, s, wprec))
+		u.Mul(u, hypSum([]*big.Float{ca, cb}, []*big.Float{s1}, y, wprec))
+		if e := u.MantExp(nil); e > scale {
+			scale = e
+		}
+
+		t.Add(t, u)
+		if lost := lostBits(t, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return t.SetPrec(prec)
+	}
+}
+
+// bitsLen returns the number of bits of the integer part of |x|.
+func bitsLen(x *big.Float) int {
+	if e := x.MantExp(nil); e > 0 {
+		return e
+	}
+	return 0
+}
@@ -0,0 +1,313 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestHyp0F1(t *testing.T) {
+	for _, test := range []struct {
+		b, z string
+		want string
+	}{
+		{"1.5", "2", "2.98040610353516773447940507287202204470990339814788408608467703609821756552719330210902433550499265810144325041110000527021946546313598142533380923340818820882056354923120782584412603417381278457583723570173273269352856796674184007794107837504240135286126431895577515968262351778303295737280877576106621988253020931707768235062592664033074920670972078765791421e+0"},
+		{"0.5", "-10", "9.99144383046929550116290796245147410610312096673716778789947067007268727351957711731321395370315480970362242758558410536961685508871661919813348934598249493263707077765563197309903117008545263843883945401338195237731079791810241686028672800197457247332695327378634695407448839489936258946571395915920198651517367322781126700402242523035075836211122719166089665e-1"},
+		{"3", "100.25", "8.03733961285849564823212488936244280781009673764028167545024178290269450966794291254244404476971577104588201944217748919215205718585281551553192269975234018410901965029641738886930963942330295100290464573141095475864956446417189142706153877385530018003484329434753747486355973070407353544558932984348225583785221576812116406139836942145672959273940673341593223e+5"},
+		{"-2.5", "1.75", "-2.94357237917601980780495446369680948917837258321101535477027161014442356273483565565909066349952442977426569554296345405811359487801215002255708812494434956523850225208336441337335266422044327008319393097311690153245656956223691498039434534604168831569473907083502763740714434506391786647848289107156763200080946996792010972579642089999386163524880934182047392e-1"},
+		{"1", "-1000", "9.34037731373783846779299897034718085327213813845605280940628981934553555942275094521655552977607294185053926957329315086569189954915325596272468943384753946031429744646587450478982049835118394583237987028046917657669421713610148236924311337031268677462547440800064979837098106331687112493342264975628331412380865047926922839271724491564670647071549343650225065e-2"},
+		{"10.25", "1p-20", "1.00000009304140066616954091971212081488833033497048545142337159901059385289112554690281374475281065095407603814289360014531255383179682773852291908303905293272218131573363960567792673700643646356753907107219821836916064283734110303318885037003945500635058562231478781435612355426764275553617134117459563882670703372404831194074425647961414803087750743426239532e+0"},
+		{"0.375", "-0.875", "-6.76404603066283339481436048971792207953522961719867414804094251593290960826142636195737476381854836020176527528111586263752886733165935822650432488342377966764747304087917108453353374271140134448713201289509006561950244343928794619143496975008265439959116398419629585894024967396388896597165562252110459914304698857034457780225300739814494685286691666561432197e-1"},
+		{"-7.75", "-30", "-1.27814681184886765044648354193554091975039763830300451061777952256029359885335629549948761860308358497209876242971627962303345011457526944043397078686588518221446865013121134738732123277449942075816816811814821602571578660130550831102650824206412122388097282681223167986384077817582586526549566325489172537960834779709654864288578115824999655994908110091068326e+2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Hyp0F1(b, z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Hyp0F1(%v, %v) =\ngot  %g;\nwant %g", prec, test.b, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestHyp1F1(t *testing.T) {
+	for _, test := range []struct {
+		a, b, z string
+		want    string
+	}{
+		{"1", "2", "1", "1.71828182845904523536028747135266249775724709369995957496696762772407663035354759457138217852516642742746639193200305992181741359662904357290033429526059563073813232862794349076323382988075319525101901157383418793070215408914993488416750924476146066808226480016847741185374234544243710753907774499206955170276183860626133138458300075204493382656029760673711320e+0"},
+		{"0.5", "1.5", "-2", "5.98144006661304101465711885237171359544993930771623732306077788664449689440417441899925378440454512676432362825959399662512656355763270274999433212934192099716272535847353978239871336995883964372324106227032947119666698107819407277414492848785853906099808871549392283338231208323848923503039252514712049887054699817502915283708092783611417947235285090643817925e-1"},
+		{"-3", "2.5", "4", "6.03174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603174603175e-2"},
+		{"2.25", "-3.5", "1.5", "7.78987954178712695859770721219556708829540365479911961191037435036273920347424132274863803419764436317631751503872038856294075233913500607260915291188380963649558076297909452923872422088749123546882616101787542407307383740901479852299045639059483637233980534532403303665290611534539878075086254297075237428596791483390766015952769252114318732497768202150668701e+1"},
+		{"1.5", "2", "-50", "1.62066022814393639424315882592018408354195501665891719247800330015397667843061799043224584581614610576991280821159865377774343440261668185125055452695400867234964450156195967966655345930855146427278422199281213794579413563407417810712672737742014407660020382990348297838097491964779515489962703960553058262073577186936943274630035152222754786635152473584685610e-3"},
+		{"0.75", "1.25", "30", "1.44936287611836301049772942525637048065980072028080696904504640082711766359420198409626455649960406325296336942437066284452148028806809438041904911162730007892342390605295509097420905560755015063024657205175266990700218544857719119380471121963541243567961394768027493360135566508697100356604528502336913701185507483412121191944759271599689452962592979534531229e+12"},
+		{"-2.5", "3", "-7.5", "1.79728753298548171281133142097202902659241623563057671227765554057367334387171258774513508585372015673921788349274558138878675367285674394269600917598103139234726152792948640986300957955969237321901503928569469713893258735392427266800315095745462612619869905621643997458344182607054821296125544749056836039293948861344482755119825256786804128681995770421077896e+1"},
+		{"10", "0.5", "0.125", "4.90379147282650038606357611961992329380116621149876849845947377464013877852327820792246743834465410154900965348764765429931769215056600757562391568410402602017643806030285676630292438112994654892413412335740093959615355578518651072524482202897347814074018045824183369852099970405378817101029003082637622170973272814764415061548389850623599510193727228276644772e+0"},
+		{"-4", "-6", "-3.5", "1.04340277777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777777778e-1"},
+		{"3", "5.5", "1p-50", "1.00000000000000048446095620006844094472966009198779145028274801616086940377587908158965135760860117374971111508758347714245972757718919842903673128628249940044236107331428230045548014914120832994871737080513059893092769588966425177182344295464599076246159031593232823826463640596557778662630764908416964652374368830169209967024220650167472664602693749604505025e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Hyp1F1(a, b, z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Hyp1F1(%v, %v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestHyp2F1(t *testing.T) {
+	for _, test := range []struct {
+		a, b, c, z string
+		want       string
+	}{
+		{"1", "1", "2", "0.5", "1.38629436111989061883446424291635313615100026872051050824136001898678724393938943121172665399283737508400296204114137146737104047151626111406534150327015192386145514165674287038061407724778334694224670023072899591047824095034536314986413031104946827905176590090601419065273328530820847831562990408748086077100160388834128334303728942567993634356909391405254326e+0"},
+		{"0.5", "0.5", "1", "0.75", "1.37288050061835016469763757500780605809453862534894005585554003887035063324089246490429234740357271097974090200233396909324107801314306427402532509313775124639649769692526380422320746886485275752967719001565188187885172044395361401572221009290305580203931222701022442080138768984322063482796348381784032549074517663772567255735544557670561554852486431238073411e+0"},
+		{"1.5", "-2", "3", "5", "3.81250000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e+0"},
+		{"0.25", "1.5", "2.75", "-0.875", "9.10502094612065580320833291547421190465128333310281643935883046195511395230873518460942212747316455452247908561810214790093346055923183156063036648371795427761262439537175181245351741576314034184828546055849039343615038036782420158802166636906313519438300754566885338581019579480725716225411438503536292193882413116236155668638720862479864553469368016765829355e-1"},
+		{"1", "2", "3", "-3", "3.58601253084468751370119057129699303077555495839886553724142218002936168013469015286282965779369472203554897324190806340584213228551941974652146332606632905808565524076279362137641316167159256235056288837615778686560390899923252633363526597544562604655163133131996846521614825487064782596526687980559808717555199135924159257102824572071125256984645796877212608e-1"},
+		{"2", "3", "5", "0.9375", "1.15904998668550141844989059326752635364223752591292675563230774423029807033663070740755829312397814472027829975083951575923352044486878337980726510711000941238316395539286937350740928888057223601104425313712295871941877327583113170077310101679669083391661493769474911992626819125113337786096647436144777021271259266273783174236222932273921643798102073340519868e+1"},
+		{"3.5", "-1.25", "0.75", "0.625", "-1.43024856137673334298629991732620205728591040809897107093426221011839493806525149450391091619697771722586997330492597204095608823069883076042361888762139045606899093971460374264693174734054266393318686234630223153314112702504402671320050128836311047839869759931522961689511914301223986871052956598637498182674999615762927254235124059297394693842927682167364747e+0"},
+		{"-0.5", "2", "3", "0.96875", "5.60595507135186405734933571637151488091035779242657618886967092651247138428076628335084604825201535660097219082266623980821602523039591892390387545090368022007219009415517919595467894823840409754300738092015230123408347793897616811088713935666783919383222347704155878027466234208052628207783723396772307287589897406378980705737862067444976551384145584627876726e-1"},
+		{"0.5", "1.5", "2", "0.875", "1.96294081724463057430501789820845865689337195376906771312995530448721336892945480401481944575591980905497812542457362696873935465658197773962347599212826884390624249338651210862852086859544274713493192917189762328026439610243984932911006703491447830898968573775094970140982794717950176714182100356268876630448462071301480582795896548390702484325012470932514346e+0"},
+		{"1", "1", "1.5", "-0.25", "8.60817881928008077778866465901210850849141365080579309514012207985122430922263922722980529394439910812661085566485307127274122470285823668877389824417011526457525247466124072967866108898131424575450066713577010904698054729418248938288908518427433022112208471769758089247505086728444477125726254519153854133647301059761415624392735772232430268243090730694946435e-1"},
+		{"2.5", "0.5", "1.25", "-10.5", "1.73480385482672255093347773978448906165493162346643069675406301773509969662239014629502686433112106993391672783891434188307617222404603561266341119471493694463992706461167377440591172536083502775208545251514133473658375494364157093356850018589914866644279047088977094026087881007225028820388261265149833173561023634663951143118207192123722710988497644877916020e-1"},
+		{"-3", "4.5", "-5", "0.75", "6.24443359375000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			b := new(big.Float).SetPrec(prec)
+			b.Parse(test.b, 10)
+
+			c := new(big.Float).SetPrec(prec)
+			c.Parse(test.c, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Hyp2F1(a, b, c, z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Hyp2F1(%v, %v, %v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, test.c, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestHypPFQ(t *testing.T) {
+	for _, test := range []struct {
+		a, b []string
+		z    string
+		want string
+	}{
+		{[]string{"1.5", "2", "0.25"}, []string{"3", "1.75"}, "-0.75", "9.17295296456692093540552367078816016460167064577025530007820917320835728480814484716944746945788612021240995841806733083363282738815442692229682738653305693272578984039932227151847648152569916041983454594973891218708322960146676173622474703335162863996000138215939144873896397757951439604469790431736017287742601192753502990380835896475660097754382336856446716e-1"},
+		{[]string{"0.5"}, []string{"1.5", "2"}, "-20", "2.24816809227050621814997819263716424747115615538902141664639152021655163398487486449032464448107775899093424847117281249728089632569373675667407937330717685802247359235198263002961388205150763385878528578213657807628494739152912804629494709509124685512759271250659979138046224767697844171704579406472177361083553604967678834995232297177002720401414478884663828e-1"},
+		{[]string{"-3", "1.5"}, nil, "0.25", "3.73046875000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e-1"},
+		{nil, nil, "-5.5", "4.08677143846406699346470268472076840839065665093819942068038107574051662987967909584397721411701310917384007330107219637191935892413462383176191856178536904093944786909849029222752592381867385874579666946491046047299032799966374237566203056162539494866520168419672967840021924695765024946801431294838925402332340714382956208405650565939885527918998771260399026e-3"},
+		{[]string{"1", "1", "1", "1"}, []string{"2", "2", "2"}, "0.5", "1.07442638721608040188124645118993165334080499868075634137952386143664816184027679466082471995087934009629394935474230641420791081322095611626567306014718531254147580244827947190546978302479833036843536386326710334312077171659731544500590220358876366161802740296827580732716386123550834150588510575207953277129286849330051707670117948958777486372653188549211667e+0"},
+		{[]string{"0.5", "1"}, []string{"1.5", "2.5", "0.75"}, "12", "8.48529199254245578589895736844327981307363981838374569380267916532425225288207566678297755977642526720215305356564299152436555987221533573748891948261995185395075605582939377887777154613102680500827996982642566895249572812452101914128680206568262499219188265210092431610976635692023108319214910884574075383252706888517525956125148757655913844723183136685280892e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			parse := func(ss []string) []*big.Float {
+				xs := make([]*big.Float, len(ss))
+				for i, s := range ss {
+					xs[i] = new(big.Float).SetPrec(prec)
+					xs[i].Parse(s, 10)
+				}
+				return xs
+			}
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.HypPFQ(parse(test.a), parse(test.b), z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, HypPFQ(%v, %v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestHypergeometricIdentities(t *testing.T) {
+	const prec = 200
+	f := func(x float64) *big.Float { return big.NewFloat(x).SetPrec(prec) }
+	one := f(1)
+	for _, test := range []struct {
+		name      string
+		got, want func(z *big.Float) *big.Float
+	}{
+		{
+			// ₀F₁(; 1; -z²/4) = J₀(z)
+			"Hyp0F1(1, -z²/4)",
+			func(z *big.Float) *big.Float {
+				w := new(big.Float).Mul(z, z)
+				return bigfloat.Hyp0F1(one, w.Quo(w, f(-4)))
+			},
+			func(z *big.Float) *big.Float { return bigfloat.BesselJ(new(big.Float), z) },
+		},
+		{
+			// ₁F₁(a; a; z) = exp(z)
+			"Hyp1F1(2.5, 2.5, z)",
+			func(z *big.Float) *big.Float { return bigfloat.Hyp1F1(f(2.5), f(2.5), z) },
+			func(z *big.Float) *big.Float { return bigfloat.Exp(z) },
+		},
+		{
+			// ₁F₁(1; 2; z) = (exp(z) - 1)/z
+			"Hyp1F1(1, 2, z)",
+			func(z *big.Float) *big.Float { return bigfloat.Hyp1F1(one, f(2), z) },
+			func(z *big.Float) *big.Float {
+				w := bigfloat.Exp(z)
+				return w.Quo(w.Sub(w, one), z)
+			},
+		},
+		{
+			// ₂F₁(1, 1; 2; z) = -log(1-z)/z
+			"Hyp2F1(1, 1, 2, z)",
+			func(z *big.Float) *big.Float { return bigfloat.Hyp2F1(one, one, f(2), z) },
+			func(z *big.Float) *big.Float {
+				w := bigfloat.Log(new(big.Float).Sub(one, z))
+				return w.Quo(w, z).Neg(w)
+			},
+		},
+		{
+			// ₂F₁(a, b; b; z) = (1-z)^(-a)
+			"Hyp2F1(1.25, 0.5, 0.5, z)",
+			func(z *big.Float) *big.Float { return bigfloat.Hyp2F1(f(1.25), f(0.5), f(0.5), z) },
+			func(z *big.Float) *big.Float {
+				return bigfloat.Pow(new(big.Float).Sub(one, z), f(-1.25))
+			},
+		},
+	} {
+		for _, z := range []float64{-12.5, -1.5, -0.75, -0.375, 0.125, 0.5, 0.625, 0.875, 0.96875} {
+			got, want := test.got(f(z)), test.want(f(z))
+			if d := new(big.Float).Sub(got, want); d.Sign() != 0 && d.MantExp(nil)-want.MantExp(nil) > -prec+8 {
+				t.Errorf("%s with z = %v is off by %g", test.name, z, d)
+			}
+		}
+	}
+}
+
+func TestHyp2F1Gauss(t *testing.T) {
+	// ₂F₁(a, b; c; 1) = Γ(c)Γ(c-a-b)/(Γ(c-a)Γ(c-b))
+	const prec = 200
+	for _, test := range []struct{ a, b, c float64 }{
+		{0.5, 1, 2.5}, {1.5, -0.25, 2}, {-2.5, 3.5, 4.75}, {1, 1, 3}, {0.125, 0.25, 0.5},
+	} {
+		a := big.NewFloat(test.a).SetPrec(prec)
+		b := big.NewFloat(test.b).SetPrec(prec)
+		c := big.NewFloat(test.c).SetPrec(prec)
+		x := bigfloat.Hyp2F1(a, b, c, big.NewFloat(1).SetPrec(prec))
+
+		g := func(x float64) *big.Float { return bigfloat.Gamma(big.NewFloat(x).SetPrec(prec)) }
+		want := g(test.c)
+		want.Mul(want, g(test.c-test.a-test.b))
+		want.Quo(want, g(test.c-test.a))
+		want.Quo(want, g(test.c-test.b))
+
+		if d := x.Sub(x, want); d.Sign() != 0 && d.MantExp(nil)-want.MantExp(nil) > -prec+8 {
+			t.Errorf("Hyp2F1(%v, %v, %v, 1) is off by %g", test.a, test.b, test.c, d)
+		}
+	}
+}
+
+func TestHypergeometricFloat64(t *testing.T) {
+	for _, test := range []struct {
+		got  *big.Float
+		want float64
+	}{
+		{bigfloat.Hyp0F1(big.NewFloat(1), big.NewFloat(-2.25)), -0.26005195490193345}, // J₀(3)
+		{bigfloat.Hyp1F1(big.NewFloat(0.5), big.NewFloat(1.5), big.NewFloat(-4)), math.Sqrt(math.Pi) / 4 * math.Erf(2)},
+		{bigfloat.Hyp2F1(big.NewFloat(1), big.NewFloat(1), big.NewFloat(2), big.NewFloat(-3)), math.Log(4) / 3},
+		{bigfloat.Hyp2F1(big.NewFloat(0.5), big.NewFloat(1), big.NewFloat(1.5), big.NewFloat(-0.5625)), math.Atan(0.75) / 0.75},
+	} {
+		x, _ := test.got.Float64()
+		if math.Abs(x-test.want) > 1e-15*math.Abs(test.want) {
+			t.Errorf("got %g; want %g", x, test.want)
+		}
+	}
+}
+
+func TestHypergeometricSpecialValues(t *testing.T) {
+	zero, one := big.NewFloat(0), big.NewFloat(1)
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"Hyp0F1(-2.5, 0)", bigfloat.Hyp0F1(big.NewFloat(-2.5), zero), 1},
+		{"Hyp1F1(3, 0.5, 0)", bigfloat.Hyp1F1(big.NewFloat(3), big.NewFloat(0.5), zero), 1},
+		{"Hyp1F1(-1, 2, 3)", bigfloat.Hyp1F1(big.NewFloat(-1), big.NewFloat(2), big.NewFloat(3)), -0.5},
+		{"Hyp1F1(-1, -2, 3)", bigfloat.Hyp1F1(big.NewFloat(-1), big.NewFloat(-2), big.NewFloat(3)), 2.5},
+		{"Hyp2F1(1, 2, 3, 0)", bigfloat.Hyp2F1(one, big.NewFloat(2), big.NewFloat(3), zero), 1},
+		{"Hyp2F1(-1, 1, 1, 0.5)", bigfloat.Hyp2F1(big.NewFloat(-1), one, one, big.NewFloat(0.5)), 0.5},
+		{"Hyp2F1(-2, 1, 1, 3)", bigfloat.Hyp2F1(big.NewFloat(-2), one, one, big.NewFloat(3)), 4},
+		{"Hyp2F1(-1, 2, 3, 1)", bigfloat.Hyp2F1(big.NewFloat(-1), big.NewFloat(2), big.NewFloat(3), one), 1.0 / 3},
+		{"HypPFQ(; ; 0)", bigfloat.HypPFQ(nil, nil, zero), 1},
+		{"HypPFQ(-1, 2, 3; ; 0.25)", bigfloat.HypPFQ([]*big.Float{big.NewFloat(-1), big.NewFloat(2), big.NewFloat(3)}, nil, big.NewFloat(0.25)), -0.5},
+	} {
+		x, _ := test.got.Float64()
+		if x != test.want {
+			t.Errorf("%s = %g; want %g", test.name, x, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkHyp1F1(b *testing.B) {
+	a, c := big.NewFloat(0.75), big.NewFloat(1.25)
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(-10.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Hyp1F1(a, c, z)
+			}
+		})
+	}
+}
+
+func BenchmarkHyp2F1(b *testing.B) {
+	a, c, d := big.NewFloat(0.5), big.NewFloat(1.5), big.NewFloat(2.25)
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(0.875).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Hyp2F1(a, c, d, z)
+			}
+		})
+	}
+}
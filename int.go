@@ -0,0 +1,135 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// LogInt returns a big.Float representation of the natural logarithm
+// of n, computed to prec bits of precision. The function panics if n
+// is negative, and returns -Inf when n = 0.
+//
+// LogInt computes log(n) as e·log(2) + log(n/2ᵉ), with e = n.BitLen()-1
+// and n/2ᵉ truncated to a little more than prec bits before it is
+// converted to a big.Float, so the cost does not depend on the size of
+// n.
+func LogInt(n *big.Int, prec uint) *big.Float {
+
+	if n.Sign() < 0 {
+		panic("LogInt: argument is negative")
+	}
+
+	// LogInt(0) = -Inf
+	if n.Sign() == 0 {
+		return big.NewFloat(math.Inf(-1)).SetPrec(prec)
+	}
+
+	// n = 2ᵉ·x with 1 <= x < 2, so log(x) and e·log(2) are both
+	// non-negative and there is no cancellation. We keep the leading
+	// wprec+1 bits of n, so the truncation error in x is less than
+	// 2**(-wprec).
+	e := n.BitLen() - 1
+	wprec := prec + 64
+	m, shift := n, 0
+	if e > int(wprec) {
+		shift = e - int(wprec)
+		m = new(big.Int).Rsh(n, uint(shift))
+	}
+	x := new(big.Float).SetPrec(wprec).SetInt(m)
+	x.SetMantExp(x, shift-e)
+	z := Log(x)
+	if e > 0 {
+		l := ln2(wprec + uint(bits.Len(uint(e))))
+		z.Add(z, l.Mul(l, new(big.Float).SetInt64(int64(e))))
+	}
+	return z.SetPrec(prec)
+}
+
+// ILog returns the largest integer k such that bᵏ <= n, that is
+// floor(log_b(n)), and reports whether n = bᵏ. It panics if n < 1 or
+// b < 2.
+func ILog(n, b *big.Int) (int, bool) {
+
+	if n.Sign() <= 0 {
+		panic("ILog: n < 1")
+	}
+	if b.Cmp(big.NewInt(2)) < 0 {
+		panic("ILog: base < 2")
+	}
+
+	// estimate k from log2(n)/log2(b), then correct it
+	k := int(log2Int(n) / log2Int(b))
+	p := new(big.Int).Exp(b, big.NewInt(int64(k)), nil)
+	for p.Cmp(n) > 0 {
+		p.Quo(p, b)
+		k--
+	}
+	for t := new(big.Int); t.Mul(p, b).Cmp(n) <= 0; k++ {
+		p, t = t, p
+	}
+	return k, p.Cmp(n) == 0
+}
+
+// IRoot returns floor(n^(1/k)), the integer k-th root of n, and reports
+// whether n is a perfect k-th power. It panics if n < 0 or k < 1.
+func IRoot(n *big.Int, k int) (*big.Int, bool) {
+
+	if n.Sign() < 0 {
+		panic("IRoot: n < 0")
+	}
+	if k < 1 {
+		panic("IRoot: k < 1")
+	}
+
+	if k == 1 || n.Sign() == 0 {
+		return new(big.Int).Set(n), true
+	}
+
+	var x *big.Int
+	if k == 2 {
+		x = new(big.Int).Sqrt(n)
+	} else if k >= n.BitLen() {
+		// 1 <= n < 2ᵏ
+		x = big.NewInt(1)
+	} else {
+		// Start from an estimate of n^(1/k) accurate to about 30
+		// bits. A Newton step
+		//     x ← ((k - 1)x + floor(n/xᵏ⁻¹))/k
+		// never goes below floor(n^(1/k)), by the inequality of
+		// arithmetic and geometric means, and decreases x until
+		// it reaches it.
+		e := log2Int(n) / float64(k)
+		g := new(big.Float).SetFloat64(math.Exp2(e - math.Floor(e)))
+		g.SetMantExp(g, int(math.Floor(e)))
+		x, _ = g.Int(nil)
+
+		k1, kk := big.NewInt(int64(k-1)), big.NewInt(int64(k))
+		t := new(big.Int)
+		step := func(x *big.Int) *big.Int {
+			t.Exp(x, k1, nil)
+			t.Quo(n, t)
+			y := new(big.Int).Mul(x, k1)
+			return y.Add(y, t).Quo(y, kk)
+		}
+		x = step(x)
+		for y := step(x); y.Cmp(x) < 0; y = step(x) {
+			x = y
+		}
+	}
+
+	p := new(big.Int).Exp(x, big.NewInt(int64(k)), nil)
+	return x, p.Cmp(n) == 0
+}
+
+// log2Int returns an approximation of log2(n), for n > 0, with a
+// relative error of about 2⁻⁵⁰.
+func log2Int(n *big.Int) float64 {
+	e := n.BitLen() - 64
+	if e < 0 {
+		e = 0
+	}
+	t := new(big.Int).Rsh(n, uint(e))
+	f, _ := new(big.Float).SetInt(t).Float64()
+	return math.Log2(f) + float64(e)
+}
@@ -0,0 +1,157 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestLogInt(t *testing.T) {
+	pow := func(b, e int64) *big.Int { return new(big.Int).Exp(big.NewInt(b), big.NewInt(e), nil) }
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, n := range []*big.Int{big.NewInt(2), big.NewInt(3), big.NewInt(10), big.NewInt(12345678901), pow(2, 64)} {
+			want := bigfloat.Log(new(big.Float).SetInt(n).SetPrec(prec))
+			if z := bigfloat.LogInt(n, prec); z.Prec() != prec || z.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LogInt(%v) =\ngot  %g;\nwant %g", prec, n, z, want)
+			}
+		}
+
+		// log(bᵉ) = e·log(b), for bᵉ with up to a million bits
+		for _, test := range []struct{ b, e int64 }{{3, 100000}, {2, 1000000}, {10, 12345}} {
+			want := bigfloat.Log(big.NewFloat(float64(test.b)).SetPrec(prec + 64))
+			want.Mul(want, big.NewFloat(float64(test.e))).SetPrec(prec)
+			if z := bigfloat.LogInt(pow(test.b, test.e), prec); z.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LogInt(%d^%d) =\ngot  %g;\nwant %g", prec, test.b, test.e, z, want)
+			}
+		}
+	}
+
+	if z := bigfloat.LogInt(big.NewInt(1), 100); z.Sign() != 0 {
+		t.Errorf("LogInt(1) = %g; want 0", z)
+	}
+	if z := bigfloat.LogInt(new(big.Int), 100); !z.IsInf() || z.Sign() > 0 {
+		t.Errorf("LogInt(0) = %g; want -Inf", z)
+	}
+}
+
+func TestILog(t *testing.T) {
+	for _, test := range []struct {
+		n, b  string
+		want  int
+		exact bool
+	}{
+		{"1", "2", 0, true},
+		{"1", "10", 0, true},
+		{"9", "10", 0, false},
+		{"999", "10", 2, false},
+		{"1000", "10", 3, true},
+		{"1001", "10", 3, false},
+		{"1267650600228229401496703205375", "2", 99, false}, // 2¹⁰⁰ - 1
+		{"1267650600228229401496703205376", "2", 100, true},
+		{"1267650600228229401496703205376", "1267650600228229401496703205377", 0, false},
+		{"1267650600228229401496703205376", "1125899906842624", 2, true}, // 2⁵⁰
+	} {
+		n, _ := new(big.Int).SetString(test.n, 10)
+		b, _ := new(big.Int).SetString(test.b, 10)
+		if k, exact := bigfloat.ILog(n, b); k != test.want || exact != test.exact {
+			t.Errorf("ILog(%v, %v) = %d, %v; want %d, %v", n, b, k, exact, test.want, test.exact)
+		}
+	}
+
+	// bᵏ - 1, bᵏ and bᵏ + 1
+	one := big.NewInt(1)
+	for _, b := range []int64{2, 3, 7, 10, 1 << 40} {
+		for _, k := range []int{1, 2, 10, 1000, 5000} {
+			p := new(big.Int).Exp(big.NewInt(b), big.NewInt(int64(k)), nil)
+			for _, d := range []int{-1, 0, 1} {
+				n := new(big.Int).Add(p, big.NewInt(int64(d)))
+				want := k
+				if d < 0 {
+					want--
+				}
+				if n.Cmp(one) <= 0 {
+					continue
+				}
+				if got, exact := bigfloat.ILog(n, big.NewInt(b)); got != want || exact != (d == 0) {
+					t.Errorf("ILog(%d^%d%+d, %d) = %d, %v; want %d, %v", b, k, d, b, got, exact, want, d == 0)
+				}
+			}
+		}
+	}
+}
+
+func TestIRoot(t *testing.T) {
+	for _, test := range []struct {
+		n     string
+		k     int
+		want  string
+		exact bool
+	}{
+		{"0", 3, "0", true},
+		{"1", 3, "1", true},
+		{"5", 1, "5", true},
+		{"5", 10, "1", false},
+		{"26", 3, "2", false},
+		{"27", 3, "3", true},
+		{"28", 3, "3", false},
+		{"1267650600228229401496703205376", 1000, "1", false},
+		{"1267650600228229401496703205376", 100, "2", true},
+		{"1267650600228229401496703205376", 99, "2", false},
+		{"1267650600228229401496703205376", 2, "1125899906842624", true},
+		{"1267650600228229401496703205375", 2, "1125899906842623", false},
+	} {
+		n, _ := new(big.Int).SetString(test.n, 10)
+		if r, exact := bigfloat.IRoot(n, test.k); r.String() != test.want || exact != test.exact {
+			t.Errorf("IRoot(%v, %d) = %v, %v; want %v, %v", n, test.k, r, exact, test.want, test.exact)
+		}
+	}
+
+	// rᵏ - 1, rᵏ and rᵏ + 1 for random r
+	rnd := rand.New(rand.NewSource(1))
+	for _, k := range []int{2, 3, 5, 7, 10, 64, 100} {
+		for _, size := range []int{1, 10, 100, 1000} {
+			r := new(big.Int).Rand(rnd, new(big.Int).Lsh(big.NewInt(1), uint(size)))
+			r.Add(r, big.NewInt(2))
+			p := new(big.Int).Exp(r, big.NewInt(int64(k)), nil)
+			for _, d := range []int{-1, 0, 1} {
+				n := new(big.Int).Add(p, big.NewInt(int64(d)))
+				want := new(big.Int).Set(r)
+				if d < 0 {
+					want.Sub(want, big.NewInt(1))
+				}
+				if got, exact := bigfloat.IRoot(n, k); got.Cmp(want) != 0 || exact != (d == 0) {
+					t.Errorf("IRoot(%v^%d%+d, %d) = %v, %v; want %v, %v", r, k, d, k, got, exact, want, d == 0)
+				}
+			}
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkLogInt(b *testing.B) {
+	n := new(big.Int).Exp(big.NewInt(3), big.NewInt(1e6), nil)
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bigfloat.LogInt(n, prec)
+			}
+		})
+	}
+}
+
+func BenchmarkIRoot(b *testing.B) {
+	for _, size := range []uint{1e3, 1e4, 1e5} {
+		n := new(big.Int).Lsh(big.NewInt(3), size)
+		b.Run(fmt.Sprintf("%v", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bigfloat.IRoot(n, 3)
+			}
+		})
+	}
+}
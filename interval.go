@@ -0,0 +1,521 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// An Interval represents a closed interval [lo, hi] of real numbers,
+// where lo and hi are big.Float values with the same precision.
+//
+// All the operations on intervals round outward: the lower endpoint
+// of a result is rounded towards -Inf and the upper one towards
+// +Inf, so that the result always contains the exact result of the
+// operation applied to any point of the operands.
+//
+// As for big.Float, the zero value for an Interval is [0, 0] with
+// precision 0, and operations on an Interval with precision 0 set it
+// to the largest precision of the operands.
+type Interval struct {
+	lo, hi big.Float
+}
+
+// NewInterval returns a new Interval [lo, hi]. Both endpoints are
+// set to the largest of the two precisions, rounding outward. The
+// function panics if lo > hi.
+func NewInterval(lo, hi *big.Float) *Interval {
+	if lo.Cmp(hi) > 0 {
+		panic("NewInterval: lo > hi")
+	}
+
+	prec := lo.Prec()
+	if hi.Prec() > prec {
+		prec = hi.Prec()
+	}
+	z := new(Interval)
+	z.lower(prec).Set(lo)
+	z.upper(prec).Set(hi)
+	return z
+}
+
+// Lo returns the lower endpoint of z. The result is a reference to
+// z's lower endpoint; it may change if a new value is assigned to z.
+func (z *Interval) Lo() *big.Float {
+	return &z.lo
+}
+
+// Hi returns the upper endpoint of z. The result is a reference to
+// z's upper endpoint; it may change if a new value is assigned to z.
+func (z *Interval) Hi() *big.Float {
+	return &z.hi
+}
+
+// Prec returns the precision of z in bits.
+func (z *Interval) Prec() uint {
+	if z.hi.Prec() > z.lo.Prec() {
+		return z.hi.Prec()
+	}
+	return z.lo.Prec()
+}
+
+// SetPrec sets the precision of both endpoints of z to prec, and
+// returns the (possibly) widened z.
+func (z *Interval) SetPrec(prec uint) *Interval {
+	z.lower(prec)
+	z.upper(prec)
+	return z
+}
+
+// Set sets z to the (possibly widened) value of x and returns z. If
+// z's precision is 0, it is changed to the precision of x.
+func (z *Interval) Set(x *Interval) *Interval {
+	if z != x {
+		prec := z.resultPrec(x, x)
+		z.lower(prec).Set(&x.lo)
+		z.upper(prec).Set(&x.hi)
+	}
+	return z
+}
+
+// Contains reports whether x is contained in z.
+func (z *Interval) Contains(x *big.Float) bool {
+	return z.lo.Cmp(x) <= 0 && x.Cmp(&z.hi) <= 0
+}
+
+// Width returns hi - lo, rounded towards +Inf. Precision is the same
+// as the one of z.
+func (z *Interval) Width() *big.Float {
+	w := new(big.Float).SetPrec(z.Prec()).SetMode(big.ToPositiveInf)
+	return w.Sub(&z.hi, &z.lo)
+}
+
+// Neg sets z to -x and returns z.
+func (z *Interval) Neg(x *Interval) *Interval {
+	prec := z.resultPrec(x, x)
+	lo := new(big.Float).Neg(&x.hi)
+	z.upper(prec).Neg(&x.lo)
+	z.lower(prec).Set(lo)
+	return z
+}
+
+// Add sets z to the sum x+y, rounded outward, and returns z. If z's
+// precision is 0, it is changed to the larger of x's or y's
+// precision before the operation.
+func (z *Interval) Add(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	z.lower(prec).Add(&x.lo, &y.lo)
+	z.upper(prec).Add(&x.hi, &y.hi)
+	return z
+}
+
+// Sub sets z to the difference x-y, rounded outward, and returns z.
+// Precision is handled as in Add.
+func (z *Interval) Sub(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	lo := new(big.Float).SetPrec(prec).SetMode(big.ToNegativeInf)
+	lo.Sub(&x.lo, &y.hi)
+	z.upper(prec).Sub(&x.hi, &y.lo)
+	z.lower(prec).Set(lo)
+	return z
+}
+
+// Mul sets z to the product x*y, rounded outward, and returns z.
+// Precision is handled as in Add. The product of 0 and an infinite
+// endpoint counts as 0, since the intervals hold real numbers.
+func (z *Interval) Mul(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	lo, hi := minMax(prec, mulZeroInf, &x.lo, &x.hi, &y.lo, &y.hi)
+	z.lower(prec).Set(lo)
+	z.upper(prec).Set(hi)
+	return z
+}
+
+// Quo sets z to the quotient x/y, rounded outward, and returns z.
+// Precision is handled as in Add. Quo panics if y contains 0.
+func (z *Interval) Quo(x, y *Interval) *Interval {
+	if y.lo.Sign() <= 0 && y.hi.Sign() >= 0 {
+		panic("Quo: division by an interval containing zero")
+	}
+
+	prec := z.resultPrec(x, y)
+	lo, hi := minMax(prec, (*big.Float).Quo, &x.lo, &x.hi, &y.lo, &y.hi)
+	z.lower(prec).Set(lo)
+	z.upper(prec).Set(hi)
+	return z
+}
+
+// Format implements fmt.Formatter. Each endpoint of z is formatted
+// as big.Float's Format method does, and the result is written as
+// [lo, hi].
+func (z *Interval) Format(s fmt.State, format rune) {
+	spec := "%"
+	for _, f := range "+-# 0" {
+		if s.Flag(int(f)) {
+			spec += string(f)
+		}
+	}
+	if p, ok := s.Precision(); ok {
+		spec += fmt.Sprintf(".%d", p)
+	}
+	spec += string(format)
+	fmt.Fprintf(s, "["+spec+", "+spec+"]", &z.lo, &z.hi)
+}
+
+// String formats z as [lo, hi], with each endpoint formatted as
+// big.Float's String method does.
+func (z *Interval) String() string {
+	return "[" + z.lo.Text('g', 10) + ", " + z.hi.Text('g', 10) + "]"
+}
+
+// lower sets the precision of z's lower endpoint to prec, and its
+// rounding mode to ToNegativeInf, and returns it.
+func (z *Interval) lower(prec uint) *big.Float {
+	return z.lo.SetMode(big.ToNegativeInf).SetPrec(prec)
+}
+
+// upper sets the precision of z's upper endpoint to prec, and its
+// rounding mode to ToPositiveInf, and returns it.
+func (z *Interval) upper(prec uint) *big.Float {
+	return z.hi.SetMode(big.ToPositiveInf).SetPrec(prec)
+}
+
+// resultPrec returns the precision of the result of an operation on
+// x and y with receiver z.
+func (z *Interval) resultPrec(x, y *Interval) uint {
+	if prec := z.Prec(); prec != 0 {
+		return prec
+	}
+	if x.Prec() > y.Prec() {
+		return x.Prec()
+	}
+	return y.Prec()
+}
+
+// minMax returns the minimum of op(a, c) for a in {a1, a2} and c in
+// {c1, c2}, rounded towards -Inf, and the maximum, rounded towards
+// +Inf.
+func minMax(prec uint, op func(z, x, y *big.Float) *big.Float, a1, a2, c1, c2 *big.Float) (*big.Float, *big.Float) {
+	var lo, hi *big.Float
+	for _, a := range []*big.Float{a1, a2} {
+		for _, c := range []*big.Float{c1, c2} {
+			l := new(big.Float).SetPrec(prec).SetMode(big.ToNegativeInf)
+			op(l, a, c)
+			if lo == nil || l.Cmp(lo) < 0 {
+				lo = l
+			}
+			h := new(big.Float).SetPrec(prec).SetMode(big.ToPositiveInf)
+			op(h, a, c)
+			if hi == nil || h.Cmp(hi) > 0 {
+				hi = h
+			}
+		}
+	}
+	return lo, hi
+}
+
+// The transcendental functions below don't call Exp, Log or pi,
+// whose results are accurate but don't come with a proven error
+// bound. Instead, they evaluate their series with interval
+// arithmetic, so that every rounding error is accounted for, and
+// they add a rigorous bound for the truncation error of each series.
+
+// ExpInterval returns an Interval containing exp(x) for every x in
+// z. Precision is the same as the one of the argument.
+func ExpInterval(z *Interval) *Interval {
+	prec := z.Prec()
+	w := new(Interval)
+	w.lower(prec).Set(&expEnclosure(&z.lo, prec).lo)
+	w.upper(prec).Set(&expEnclosure(&z.hi, prec).hi)
+	return w
+}
+
+// LogInterval returns an Interval containing the natural logarithm
+// of x for every x in z. Precision is the same as the one of the
+// argument. The function panics if z contains negative numbers.
+func LogInterval(z *Interval) *Interval {
+	if z.lo.Sign() < 0 {
+		panic("LogInterval: interval contains negative numbers")
+	}
+
+	prec := z.Prec()
+	w := new(Interval)
+	w.lower(prec).Set(&logEnclosure(&z.lo, prec).lo)
+	w.upper(prec).Set(&logEnclosure(&z.hi, prec).hi)
+	return w
+}
+
+// PowInterval returns an Interval containing x**y for every x in z
+// and every y in w. Precision is the same as the one of the first
+// argument. The function panics if z contains negative numbers, or
+// if z contains 0 and w contains non-positive numbers.
+func PowInterval(z *Interval, w *Interval) *Interval {
+	if z.lo.Sign() < 0 {
+		panic("PowInterval: interval contains negative numbers")
+	}
+	if z.lo.Sign() == 0 && w.lo.Sign() <= 0 {
+		panic("PowInterval: zero base with non-positive exponent")
+	}
+
+	prec := z.Prec()
+
+	// x**y = exp(y·log(x))
+	//
+	// As in Pow, the absolute error in y·log(x) becomes a relative
+	// error in the result, so we need as many extra bits as the
+	// magnitude of y·log(x).
+	guard := uint(64)
+	for {
+		wprec := prec + guard
+		t := LogInterval(new(Interval).SetPrec(wprec).Set(z))
+		t.Mul(t, w)
+		exp := 0
+		for _, e := range []*big.Float{&t.lo, &t.hi} {
+			if e.IsInf() {
+				continue
+			}
+			if ee := e.MantExp(nil); ee > exp {
+				exp = ee
+			}
+		}
+		if uint(exp) <= guard-64 {
+			return new(Interval).SetPrec(prec).Set(ExpInterval(t))
+		}
+		guard = 64 + uint(exp)
+	}
+}
+
+// PiInterval returns an Interval containing π, with precision prec.
+func PiInterval(prec uint) *Interval {
+	wprec := prec + 64
+
+	// Machin's formula
+	//     π = 16·atan(1/5) - 4·atan(1/239)
+	one := pointInterval(big.NewFloat(1), wprec)
+	x := atanSeries(new(Interval).SetPrec(wprec).Quo(one, pointInterval(big.NewFloat(5), wprec)), -1)
+	y := atanSeries(new(Interval).SetPrec(wprec).Quo(one, pointInterval(big.NewFloat(239), wprec)), -1)
+	x.Mul(x, pointInterval(big.NewFloat(16), wprec))
+	y.Mul(y, pointInterval(big.NewFloat(4), wprec))
+	return x.Sub(x, y).SetPrec(prec)
+}
+
+// EInterval returns an Interval containing e = exp(1), with
+// precision prec.
+func EInterval(prec uint) *Interval {
+	return expEnclosure(big.NewFloat(1), prec)
+}
+
+// Ln2Interval returns an Interval containing log(2), with precision
+// prec.
+func Ln2Interval(prec uint) *Interval {
+	return ln2Enclosure(prec + 64).SetPrec(prec)
+}
+
+// EulerGammaInterval returns an Interval containing the
+// Euler–Mascheroni constant γ, with precision prec.
+func EulerGammaInterval(prec uint) *Interval {
+
+	// As in EulerGamma, we use Brent and McMillan's algorithm B1,
+	// which we write as
+	//     γ = U/V - log(n) - K₀(2n)/I₀(2n)
+	// where U = Σ A_k, V = Σ B_k, B_k = (n^k/k!)², A_k = B_k·H_k,
+	// H_k being the k-th harmonic number, and 0 < K₀(2n)/I₀(2n) <
+	// π·exp(-4n), as shown in their paper. With n > (wprec+2)/5,
+	// π·exp(-4n) is less than 2**(-wprec). Since all the terms are positive,
+	// there is no cancellation in the sums.
+	wprec := prec + 64
+	n := int64(wprec+2)/5 + 1
+
+	a := new(Interval).SetPrec(wprec)          // A_0 = 0
+	b := pointInterval(big.NewFloat(1), wprec) // B_0 = 1
+	u := new(Interval).SetPrec(wprec)          // U = A_0
+	v := new(Interval).SetPrec(wprec).Set(b)   // V = B_0
+	n2 := pointInterval(new(big.Float).SetInt64(n*n), wprec)
+	for k := int64(1); ; k++ {
+		t := pointInterval(new(big.Float).SetInt64(k), wprec)
+		b.Mul(b, n2).Quo(b, t).Quo(b, t) // B_k = B_(k-1) n²/k²
+		a.Mul(a, n2).Quo(a, t)
+		a.Add(a, b).Quo(a, t) // A_k = (A_(k-1) n²/k + B_k)/k
+
+		u.Add(u, a)
+		v.Add(v, b)
+
+		// For j > k >= 2n, B_(j+1)/B_j = n²/(j+1)² < 1/4 and
+		// A_(j+1)/A_j < 2·B_(j+1)/B_j, so the tails of the sums are
+		// less than B_k and A_k.
+		if k >= 2*n && b.hi.MantExp(nil)-v.lo.MantExp(nil) < -int(wprec) &&
+			a.hi.MantExp(nil)-u.lo.MantExp(nil) < -int(wprec) {
+			u.upper(wprec).Add(&u.hi, &a.hi)
+			v.upper(wprec).Add(&v.hi, &b.hi)
+			break
+		}
+	}
+
+	g := u.Quo(u, v)
+	g.Sub(g, logEnclosure(new(big.Float).SetInt64(n), wprec))
+	d := new(big.Float).SetMantExp(big.NewFloat(1), -int(wprec))
+	g.lower(wprec).Sub(&g.lo, d) // - K₀(2n)/I₀(2n)
+	return g.SetPrec(prec)
+}
+
+// pointInterval returns the Interval [x, x] with precision prec,
+// rounded outward.
+func pointInterval(x *big.Float, prec uint) *Interval {
+	z := new(Interval)
+	z.lower(prec).Set(x)
+	z.upper(prec).Set(x)
+	return z
+}
+
+// magnitude returns max(|z.lo|, |z.hi|).
+func magnitude(z *Interval) *big.Float {
+	m := new(big.Float).Abs(&z.lo)
+	if h := new(big.Float).Abs(&z.hi); h.Cmp(m) > 0 {
+		return h
+	}
+	return m
+}
+
+// expEnclosure returns an Interval with precision prec containing
+// exp(x).
+func expEnclosure(x *big.Float, prec uint) *Interval {
+
+	switch {
+	case x.IsInf() && x.Sign() > 0:
+		return pointInterval(x, prec)
+	case x.IsInf():
+		return new(Interval).SetPrec(prec)
+	case x.Sign() == 0:
+		return pointInterval(big.NewFloat(1), prec)
+	}
+
+	// For |x| >= 1.4e9, exp(x) >= 2**(2e9), and exp(-x) <= 2**(-2e9),
+	// which are at the limits of big.Float's exponent range.
+	a := new(big.Float).Abs(x)
+	if a.Cmp(big.NewFloat(1.4e9)) >= 0 {
+		w := new(Interval)
+		if x.Sign() > 0 {
+			w.lower(prec).SetMantExp(big.NewFloat(1), 2e9)
+			w.upper(prec).SetInf(false)
+		} else {
+			w.lower(prec).SetInt64(0)
+			w.upper(prec).SetMantExp(big.NewFloat(1), -2e9)
+		}
+		return w
+	}
+
+	// exp(a) = exp(t)^(2^m), with t = a/2^m <= 2^(-r). We lose one
+	// bit in each of the m squarings.
+	r := int(math.Sqrt(float64(prec))) + 1
+	m := a.MantExp(nil) + r
+	if m < 0 {
+		m = 0
+	}
+	wprec := prec + uint(m) + 64
+	t := pointInterval(new(big.Float).SetMantExp(a, -m), wprec)
+
+	// exp(t) = Σ t^k/k!, for k >= 0. Since t <= 1/2, the tail of the
+	// series after the term t^k/k! is less than t^k/k!.
+	s := pointInterval(big.NewFloat(1), wprec)
+	p := pointInterval(big.NewFloat(1), wprec) // t^k/k!
+	for k := int64(1); ; k++ {
+		p.Mul(p, t).Quo(p, pointInterval(new(big.Float).SetInt64(k), wprec))
+		s.Add(s, p)
+		if p.hi.Sign() == 0 || p.hi.MantExp(nil) < -int(wprec) {
+			s.upper(wprec).Add(&s.hi, &p.hi)
+			break
+		}
+	}
+
+	for i := 0; i < m; i++ {
+		s.Mul(s, s)
+	}
+	if x.Sign() < 0 {
+		s.Quo(pointInterval(big.NewFloat(1), wprec), s)
+	}
+	return s.SetPrec(prec)
+}
+
+// logEnclosure returns an Interval with precision prec containing
+// log(x), for x >= 0.
+func logEnclosure(x *big.Float, prec uint) *Interval {
+
+	switch {
+	case x.IsInf():
+		return pointInterval(x, prec)
+	case x.Sign() == 0:
+		return pointInterval(new(big.Float).SetInf(true), prec)
+	}
+
+	// x = y·2^e, with 3/4 <= y < 3/2, and
+	//     log(y) = 2·atanh(u), u = (y-1)/(y+1)
+	// where -1/7 <= u < 1/5. y - 1 and y + 1 are exact.
+	wprec := prec + 64
+	y := new(big.Float)
+	e := x.MantExp(y)
+	if y.Cmp(big.NewFloat(0.75)) < 0 {
+		y.SetMantExp(y, 1)
+		e--
+	}
+	one := big.NewFloat(1)
+	num := new(big.Float).SetPrec(y.Prec()+2).Sub(y, one)
+	den := new(big.Float).SetPrec(y.Prec()+2).Add(y, one)
+	u := new(Interval)
+	u.lower(wprec).Quo(num, den)
+	u.upper(wprec).Quo(num, den)
+
+	z := atanSeries(u, 1)
+	z.Add(z, z)
+	if e != 0 {
+		l := ln2Enclosure(wprec)
+		z.Add(z, l.Mul(l, pointInterval(new(big.Float).SetInt64(int64(e)), wprec)))
+	}
+	return z.SetPrec(prec)
+}
+
+// ln2Enclosure returns an Interval with precision prec containing
+// log(2) = 2·atanh(1/3).
+func ln2Enclosure(prec uint) *Interval {
+	u := new(Interval).SetPrec(prec).Quo(
+		pointInterval(big.NewFloat(1), prec),
+		pointInterval(big.NewFloat(3), prec))
+	z := atanSeries(u, 1)
+	return z.Add(z, z)
+}
+
+// atanSeries returns an Interval containing Σ σ^k·u^(2k+1)/(2k+1),
+// for k >= 0 and σ = ±1, that is atan(u) when σ = -1 and atanh(u)
+// when σ = 1, for every u in the interval x, with the precision of
+// x. x must be contained in [-1/2, 1/2].
+func atanSeries(x *Interval, sigma int) *Interval {
+	prec := x.Prec()
+
+	z := new(Interval).SetPrec(prec)
+	if x.lo.Sign() == 0 && x.hi.Sign() == 0 {
+		return z
+	}
+
+	// σ·u²
+	u2 := new(Interval).SetPrec(prec).Mul(x, x)
+	if sigma < 0 {
+		u2.Neg(u2)
+	}
+
+	p := new(Interval).SetPrec(prec).Set(x) // σ^k·u^(2k+1)
+	t := new(Interval).SetPrec(prec)
+	for k := int64(0); ; k++ {
+		t.Quo(p, pointInterval(new(big.Float).SetInt64(2*k+1), prec))
+		z.Add(z, t)
+		p.Mul(p, u2)
+
+		// The tail of the series is less than |u|^(2k+3)/(1-u²),
+		// and u² <= 1/4.
+		if mp := magnitude(p); mp.Sign() == 0 ||
+			mp.MantExp(nil)-magnitude(z).MantExp(nil) < -int(prec) {
+			r := new(big.Float).SetMode(big.ToPositiveInf).SetPrec(prec).Mul(mp, big.NewFloat(2))
+			z.lower(prec).Sub(&z.lo, r)
+			z.upper(prec).Add(&z.hi, r)
+			return z
+		}
+	}
+}
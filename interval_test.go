@@ -0,0 +1,223 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// checkEnclosure checks that x contains want, and that it is at most
+// a few ulps wide.
+func checkEnclosure(x *bigfloat.Interval, want *big.Float, prec uint) error {
+	if !x.Contains(want) {
+		return fmt.Errorf("does not contain %g", want)
+	}
+	lim := new(big.Float).Abs(want)
+	lim.SetMantExp(lim, 3-int(prec))
+	if x.Width().Cmp(lim) > 0 {
+		return fmt.Errorf("width %g is too large", x.Width())
+	}
+	return nil
+}
+
+func pointInterval(s string, prec uint) *bigfloat.Interval {
+	x, _, _ := new(big.Float).SetPrec(prec).Parse(s, 10)
+	return bigfloat.NewInterval(x, x)
+}
+
+func TestExpInterval(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "2.71828182845904523536028747135266249775724709369995957496696762772407663035354759457138217852516642742746639193200305992181741359662904357290033429526059563073813232862794349076323382988075319525101901157383418793070215408914993488416750924476146066808226480016847741185374234544243710753907774499206955170276183860626133138458300075204493382656029760673711320e+0"},
+		{"-10", "4.53999297624848515355915155605506102379180888665649692590713056509994216143022816525250045459477823217080550896860284929451991172445203888371833477094145675609909092170073639701810595017839007629685177870309088243651715484487222936523324160205011682643603056049415701077299753544080794039942329321382707805200427104989603544861660668370092017075732088363446794e-5"},
+		{"100", "2.68811714181613544841262555158001358736111187737419224151916086152802870349095649141588710972198457108116708791905760686975977097618682335484596389298719660896291336261200293809572765340329622698656680169177435144518460651628044422377567622969602847319114021298622810400579115938787903849741733400849124328281268154544260518088286259665094004669090619135244386e+43"},
+	} {
+		want, _, _ := new(big.Float).SetPrec(1200).Parse(test.want, 10)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			x := bigfloat.ExpInterval(pointInterval(test.z, prec))
+			if err := checkEnclosure(x, want, prec); err != nil {
+				t.Errorf("prec = %d, ExpInterval(%v) = %g: %v", prec, test.z, x, err)
+			}
+		}
+	}
+}
+
+func TestLogInterval(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.5", "-6.93147180559945309417232121458176568075500134360255254120680009493393621969694715605863326996418687542001481020570685733685520235758130557032670751635075961930727570828371435190307038623891673471123350115364497955239120475172681574932065155524734139525882950453007095326366642654104239157814952043740430385500801944170641671518644712839968171784546957026271631e-1"},
+		{"10", "2.30258509299404568401799145468436420760110148862877297603332790096757260967735248023599720508959829834196778404228624863340952546508280675666628736909878168948290720832555468084379989482623319852839350530896537773262884616336622228769821988674654366747440424327436515504893431493939147961940440022210510171417480036880840126470806855677432162283552201148046637e+0"},
+		{"1.0009765625", "9.76085973055458895960824908017186672611834333784536237758598274400372124025879163951627594159157217908285233098760309343439265303952868194537311016410940881300064967934321113547488002258417112527761353771948689993134683795128755387848837639823313772397242348485136886200727283241818862432098776805117174431840299494747741445245732795887092250746218099117527151e-4"},
+	} {
+		want, _, _ := new(big.Float).SetPrec(1200).Parse(test.want, 10)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			x := bigfloat.LogInterval(pointInterval(test.z, prec))
+			if err := checkEnclosure(x, want, prec); err != nil {
+				t.Errorf("prec = %d, LogInterval(%v) = %g: %v", prec, test.z, x, err)
+			}
+		}
+	}
+}
+
+func TestPowInterval(t *testing.T) {
+	for _, test := range []struct {
+		z, w string
+		want string
+	}{
+		{"1.5", "1.5", "1.83711730708738357364796305602941854397446061049250259632451942543822028309298626990489457482848017611394595091996064184364414909487831800621933796342795891462168456064575742843572257895318382766761098300924001814022433251440920302535660670453093917588493104327097810820270266213065137872506119235587850981727554652049522312786857080060033280401566187338599045e+0"},
+		{"2", "-0.5", "7.07106781186547524400844362104849039284835937688474036588339868995366239231053519425193767163820786367506923115456148512462418027925368606322060748549967915706611332963752796377899975250576391030285735054779985802985137267298431007364258709320444599304776164615242154357160725419881301813997625703994843626698273165904414820310307629176197527372875143879980865e-1"},
+		{"0.25", "100", "6.22301527786114170714406405378012424059025216872116713310111661478969883403538344118394482312571361695696658955512248212471604347229003906250000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e-61"},
+	} {
+		want, _, _ := new(big.Float).SetPrec(1200).Parse(test.want, 10)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			x := bigfloat.PowInterval(pointInterval(test.z, prec), pointInterval(test.w, prec))
+			if err := checkEnclosure(x, want, prec); err != nil {
+				t.Errorf("prec = %d, PowInterval(%v, %v) = %g: %v", prec, test.z, test.w, x, err)
+			}
+		}
+	}
+}
+
+func TestPiInterval(t *testing.T) {
+	want, _, _ := new(big.Float).SetPrec(1200).Parse("3.14159265358979323846264338327950288419716939937510582097494459230781640628620899862803482534211706798214808651328230664709384460955058223172535940812848111745028410270193852110555964462294895493038196442881097566593344612847564823378678316527120190914564856692346034861045432664821339360726024914127372458700660631558817488152092096282925409171536436789259036e+0", 10)
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		x := bigfloat.PiInterval(prec)
+		if err := checkEnclosure(x, want, prec); err != nil {
+			t.Errorf("PiInterval(%d) = %g: %v", prec, x, err)
+		}
+	}
+}
+
+func TestConstantIntervals(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(prec uint) *bigfloat.Interval
+		want string
+	}{
+		{"EInterval", bigfloat.EInterval, "2.71828182845904523536028747135266249775724709369995957496696762772407663035354759457138217852516642742746639193200305992181741359662904357290033429526059563073813232862794349076323382988075319525101901157383418793070215408914993488416750924476146066808226480016847741185374234544243710753907774499206955170276183860626133138458300075204493382656029760673711320e+0"},
+		{"Ln2Interval", bigfloat.Ln2Interval, "6.93147180559945309417232121458176568075500134360255254120680009493393621969694715605863326996418687542001481020570685733685520235758130557032670751635075961930727570828371435190307038623891673471123350115364497955239120475172681574932065155524734139525882950453007095326366642654104239157814952043740430385500801944170641671518644712839968171784546957026271631e-1"},
+		{"EulerGammaInterval", bigfloat.EulerGammaInterval, "5.77215664901532860606512090082402431042159335939923598805767234884867726777664670936947063291746749514631447249807082480960504014486542836224173997644923536253500333742937337737673942792595258247094916008735203948165670853233151776611528621199501507984793745085705740029921354786146694029604325421519058775535267331399254012967420513754139549111685102807984235e-1"},
+	} {
+		want, _, _ := new(big.Float).SetPrec(1200).Parse(test.want, 10)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			x := test.f(prec)
+			if err := checkEnclosure(x, want, prec); err != nil {
+				t.Errorf("%s(%d) = %g: %v", test.name, prec, x, err)
+			}
+		}
+	}
+}
+
+func TestExpLogIntervalRandom(t *testing.T) {
+	// The enclosures of exp(x) and log(x) for random x, computed to
+	// 53 bits, contain the values computed to 1000 bits.
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1e3; i++ {
+		x := big.NewFloat(math.Ldexp(rnd.Float64(), rnd.Intn(40)-20))
+		if rnd.Intn(2) == 0 {
+			x.Neg(x)
+		}
+		if got, want := bigfloat.ExpInterval(bigfloat.NewInterval(x, x)), bigfloat.Exp(new(big.Float).SetPrec(1000).Set(x)); !got.Contains(want) {
+			t.Errorf("ExpInterval(%g) = %v does not contain %g", x, got, want)
+		}
+
+		x.Abs(x)
+		if got, want := bigfloat.LogInterval(bigfloat.NewInterval(x, x)), bigfloat.Log(new(big.Float).SetPrec(1000).Set(x)); !got.Contains(want) {
+			t.Errorf("LogInterval(%g) = %v does not contain %g", x, got, want)
+		}
+	}
+}
+
+func TestIntervalArithmetic(t *testing.T) {
+	for i := 0; i < 1e3; i++ {
+		// x and y are intervals with random float64 endpoints
+		// rounded to 24 bits, and a, b are points inside them
+		var x, y [2]*big.Float
+		for j := range x {
+			x[j] = big.NewFloat(rand.Float64() - 0.5).SetPrec(24)
+			y[j] = big.NewFloat(rand.Float64() - 0.5).SetPrec(24)
+		}
+		if x[0].Cmp(x[1]) > 0 {
+			x[0], x[1] = x[1], x[0]
+		}
+		if y[0].Cmp(y[1]) > 0 {
+			y[0], y[1] = y[1], y[0]
+		}
+		a := new(big.Float).Add(x[0], x[1])
+		a.Quo(a, big.NewFloat(2))
+		b := new(big.Float).Add(y[0], y[1])
+		b.Quo(b, big.NewFloat(2))
+
+		xi := bigfloat.NewInterval(x[0], x[1])
+		yi := bigfloat.NewInterval(y[0], y[1])
+
+		// the exact results are computed using 1000 bits
+		for _, test := range []struct {
+			op   string
+			got  *bigfloat.Interval
+			want *big.Float
+		}{
+			{"+", new(bigfloat.Interval).Add(xi, yi), new(big.Float).SetPrec(1000).Add(a, b)},
+			{"-", new(bigfloat.Interval).Sub(xi, yi), new(big.Float).SetPrec(1000).Sub(a, b)},
+			{"*", new(bigfloat.Interval).Mul(xi, yi), new(big.Float).SetPrec(1000).Mul(a, b)},
+		} {
+			if !test.got.Contains(test.want) {
+				t.Errorf("%v %s %v = %v does not contain %g", xi, test.op, yi, test.got, test.want)
+			}
+		}
+
+		if y[0].Sign() > 0 || y[1].Sign() < 0 {
+			got := new(bigfloat.Interval).Quo(xi, yi)
+			want := new(big.Float).SetPrec(1000).Quo(a, b)
+			if !got.Contains(want) {
+				t.Errorf("%v / %v = %v does not contain %g", xi, yi, got, want)
+			}
+		}
+	}
+}
+
+func TestIntervalSpecialValues(t *testing.T) {
+	zero := pointInterval("0", 53)
+	if x := bigfloat.ExpInterval(zero); x.Lo().Cmp(big.NewFloat(1)) > 0 || x.Hi().Cmp(big.NewFloat(1)) < 0 {
+		t.Errorf("ExpInterval(0) = %v", x)
+	}
+	if x := bigfloat.LogInterval(zero); !x.Lo().IsInf() {
+		t.Errorf("LogInterval(0) = %v", x)
+	}
+	if x := bigfloat.LogInterval(pointInterval("1", 53)); x.Lo().Sign() > 0 || x.Hi().Sign() < 0 {
+		t.Errorf("LogInterval(1) = %v", x)
+	}
+	if x := bigfloat.PowInterval(bigfloat.NewInterval(big.NewFloat(0), big.NewFloat(1)), pointInterval("2", 53)); x.Lo().Sign() != 0 || x.Hi().Cmp(big.NewFloat(1)) < 0 {
+		t.Errorf("PowInterval([0, 1], 2) = %v", x)
+	}
+	inf := new(big.Float).SetInf(false)
+	unit := bigfloat.NewInterval(big.NewFloat(0), big.NewFloat(1))
+	if x := bigfloat.PowInterval(bigfloat.NewInterval(big.NewFloat(1), inf), unit); x.Lo().Cmp(big.NewFloat(1)) > 0 || !x.Hi().IsInf() {
+		t.Errorf("PowInterval([1, +Inf], [0, 1]) = %v", x)
+	}
+	if x := new(bigfloat.Interval).Mul(bigfloat.NewInterval(big.NewFloat(0), inf), unit); x.Lo().Sign() != 0 || !x.Hi().IsInf() {
+		t.Errorf("[0, +Inf] * [0, 1] = %v", x)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkExpInterval(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		z := bigfloat.NewInterval(big.NewFloat(1.5).SetPrec(prec), big.NewFloat(2).SetPrec(prec))
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.ExpInterval(z)
+			}
+		})
+	}
+}
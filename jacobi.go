@@ -0,0 +1,162 @@
+package bigfloat
+
+import "math/big"
+
+// JacobiSn returns a big.Float representation of the Jacobi elliptic
+// function sn(u, k), where k is the modulus. Precision is the same as
+// the one of the first argument. The function panics if u is
+// infinite or if |k| > 1.
+func JacobiSn(u, k *big.Float) *big.Float {
+	sn, _, _ := jacobiSpecial(u, k, "JacobiSn")
+	if sn == nil {
+		sn, _, _ = jacobi(u, k, u.Prec()+64)
+	}
+	return sn.SetPrec(u.Prec())
+}
+
+// JacobiCn returns a big.Float representation of the Jacobi elliptic
+// function cn(u, k), where k is the modulus. Precision is the same as
+// the one of the first argument. The function panics if u is
+// infinite or if |k| > 1.
+func JacobiCn(u, k *big.Float) *big.Float {
+	_, cn, _ := jacobiSpecial(u, k, "JacobiCn")
+	if cn == nil {
+		_, cn, _ = jacobi(u, k, u.Prec()+64)
+	}
+	return cn.SetPrec(u.Prec())
+}
+
+// JacobiDn returns a big.Float representation of the Jacobi elliptic
+// function dn(u, k), where k is the modulus. Precision is the same as
+// the one of the first argument. The function panics if u is
+// infinite or if |k| > 1.
+func JacobiDn(u, k *big.Float) *big.Float {
+	_, _, dn := jacobiSpecial(u, k, "JacobiDn")
+	if dn == nil {
+		_, _, dn = jacobi(u, k, u.Prec()+64)
+	}
+	return dn.SetPrec(u.Prec())
+}
+
+// jacobiSpecial panics if u is infinite or if |k| > 1, using fname in
+// the panic message. When u = ±0 it returns sn, cn and dn, and it
+// returns nil values otherwise.
+func jacobiSpecial(u, k *big.Float, fname string) (sn, cn, dn *big.Float) {
+	ellipticCheck(k, fname)
+	switch {
+	case u.IsInf():
+		panic(fname + ": argument is infinite")
+
+	// sn(±0, k) = ±0, cn(±0, k) = dn(±0, k) = 1
+	case u.Sign() == 0:
+		sn = new(big.Float).Set(u)
+		return sn, big.NewFloat(1), big.NewFloat(1)
+	}
+	return nil, nil, nil
+}
+
+// jacobi returns sn(u, k), cn(u, k) and dn(u, k), for u finite and
+// non-zero, and |k| <= 1, computed to prec bits of precision.
+func jacobi(u, k *big.Float, prec uint) (sn, cn, dn *big.Float) {
+
+	one := big.NewFloat(1)
+	k = new(big.Float).Abs(k)
+
+	switch {
+	// sn(u, 0) = sin(u), cn(u, 0) = cos(u), dn(u, 0) = 1
+	case k.Sign() == 0:
+		sn, cn = sincos(new(big.Float).SetPrec(prec).Set(u))
+		return sn, cn, big.NewFloat(1).SetPrec(prec)
+
+	// sn(u, 1) = tanh(u), cn(u, 1) = dn(u, 1) = sech(u)
+	case k.Cmp(one) == 0:
+		// e**u - e**(-u) loses about -log2|u| bits when |u| is
+		// small.
+		wprec := prec
+		if e := u.MantExp(nil); e < 0 {
+			wprec += uint(-e)
+		}
+		x := Exp(new(big.Float).SetPrec(wprec).Set(u))
+		y := new(big.Float).SetPrec(wprec).Quo(one, x)
+		sn = new(big.Float).SetPrec(wprec).Sub(x, y)
+		x.Add(x, y)
+		sn.Quo(sn, x)
+		cn = x.Quo(big.NewFloat(2), x)
+		return sn.SetPrec(prec), cn.SetPrec(prec), new(big.Float).Set(cn).SetPrec(prec)
+	}
+
+	// Following Abramowitz and Stegun, 16.4, we use the descending
+	// Landen transformation: we run the AGM of 1 and k' keeping
+	// track of a_n and c_n = (a_(n-1) - b_(n-1))/2, as in ellipticE,
+	// until c_N is negligible. Then φ_N = 2**N·a_N·u, and
+	//     sin(2φ_(n-1) - φ_n) = (c_n/a_n)·sin(φ_n)
+	// gives φ_(n-1), down to φ_0 = am(u, k). Finally sn = sin(φ_0)
+	// and cn = cos(φ_0).
+	//
+	// The error on φ_N is halved at each step, so the absolute
+	// error on φ_0 is about |φ_0|·2**(-prec), which is a large
+	// relative error in sn or cn close to their zeros.
+	guard := uint(8)
+	if e := u.MantExp(nil); e > 0 {
+		guard += uint(e)
+	}
+	for {
+		wprec := prec + guard
+
+		a := []*big.Float{big.NewFloat(1).SetPrec(wprec)}
+		c := []*big.Float{new(big.Float).SetPrec(wprec).Set(k)}
+		b := ellipticComplement(k, wprec)
+		for n := 0; ; n++ {
+			an := new(big.Float).SetPrec(wprec).Add(a[n], b)
+			an.SetMantExp(an, -1)
+			b.Sqrt(b.Mul(b, a[n]))
+
+			// c_(n+1) = c_n² / (4a_(n+1))
+			cc := new(big.Float).SetPrec(wprec).Mul(c[n], c[n])
+			cc.Quo(cc, an)
+			cc.SetMantExp(cc, -2)
+
+			a, c = append(a, an), append(c, cc)
+			if cc.Sign() == 0 || cc.MantExp(nil)-an.MantExp(nil) < -int(wprec) {
+				break
+			}
+		}
+
+		N := len(a) - 1
+		phi := new(big.Float).SetPrec(wprec).Mul(u, a[N])
+		phi.SetMantExp(phi, N)
+		scale := phi.MantExp(nil) - N
+
+		t := new(big.Float).SetPrec(wprec)
+		for n := N; n > 0; n-- {
+			s, _ := sincos(phi)
+			s.Mul(s, c[n]).Quo(s, a[n])
+
+			// asin(s) = arg(√(1-s²) + si)
+			t.Mul(s, s)
+			t.Sub(one, t).Sqrt(t)
+			phi.Add(phi, arg(t, s, wprec))
+			phi.SetMantExp(phi, -1)
+		}
+
+		sn, cn = sincos(phi)
+		lost := lostBits(sn, scale, wprec)
+		if l := lostBits(cn, scale, wprec); l > lost {
+			lost = l
+		}
+		if lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+
+		// dn = √(1 - k²sn²) = √(k'² + k²cn²), which does not suffer
+		// from cancellation.
+		dn = ellipticComplement(k, wprec)
+		dn.Mul(dn, dn)
+		t.Mul(k, cn)
+		dn.Add(dn, t.Mul(t, t))
+		dn.Sqrt(dn)
+
+		return sn.SetPrec(prec), cn.SetPrec(prec), dn.SetPrec(prec)
+	}
+}
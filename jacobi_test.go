@@ -0,0 +1,177 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestJacobiSn(t *testing.T) {
+	for _, test := range []struct {
+		u, k string
+		want string
+	}{
+		{"0.5", "0.5", "4.75082936028536510082218324703870258745078171807428948028251930360125571772737975908928655599679755038068143714078542765203736324970430682219832635250193003893859089783020284480572105658758204791902771805574318670388894110212825311380302596858861391676108710082984222583898296506822912397778271980564594689768571696806659562117245928281179095442882174559608617e-1"},
+		{"1.25", "0.75", "8.98428990130774459056649472445012325239261221777344037261427029400541153287996737316628169848589847201745405238845805881848435245869408814321820476062101932751526809074611639434742054543746272476199999672838712062746851772513936733099849088281541045532393875984839708961014802509344227101154641807715472903901807791397317777926275878019884220677042560840079692e-1"},
+		{"-3", "0.875", "-9.10046356557666606370692181968235410202550499734723374914002922187899452191576678398165685835621819774653881504756336496809365020856145513054927924824963175263393032184148709810442131920653365516819630440683162739627893654050726613409022027214810011792331912997260128772023631542127215292617834798139352109640157911292220120328446604815983971048746415637008938e-1"},
+		{"10.5", "0.25", "-7.92964195704631652912225711039661248290292257326884915252545897853343455475722538154668994521589805979488874525912039571276108560144839357172686977265016578247027570480310849665421267959303234044815888369289236514629174213258493002749030559738153865241841850950961815222262509952403587345958658596175710085669804954297827155319064558382473819991061661232789398e-1"},
+		{"100.25", "0.999999940395355224609375", "-9.99996844897215871269329632596708702121934226691515366918113162000530452405320745581985125872278405209325860532932278531278650771390246860641086855183787826413024592525409520941056087498278422777628058900498935637616932774476686133382638100250691798002716868895482728898665892880921414902215891456929834026779770862384067888460058466468774356295625614910894923e-1"},
+		{"1p-40", "0.5", "9.09494701772928237915038905767419890361665604168485364123247325393791467710789097291937519429640392447674597425881630560642560282123886685930013211052694200687418314909487586051858059157724902764988782060135761995312386884008087563293477818914515035030692849788578551251266694309563732545118843736094747965634615370685909698912174512569499946041128181906438888e-13"},
+		{"2", "1", "9.64027580075816883946413724100923150255029976240934776048263217413107946317610202559474850045207689149461612218924596668273067021913020334547485956472914624336751594506316812007245950658562057196950550739835054131848435723071198131843339062586263829722006258367481879218305929297275083923803297700207172355848002085069538548200137017285152482271698397724274243e-1"},
+		{"0.75", "0", "6.81638760023334166733241952779893935338382394659229909213625262151100388887003782753145274849781911981438190343146876189498776121741565579938097014188070205470491784029354860225792286789985040761893131362044129726004353092618264863900332399650422301880093570960014088412021059275362819542510836129419123685933452442477025322155871260598677930383280068012469425e-1"},
+		{"1.68575036525726318359375", "0.5", "9.99999999999999959090848119816515528693856906584727175292756534221177610227373708893769821006752569725557365462151210139153782659615275233015933649770725384209740703175611910374883602610950956040974911381099272914483975671883949310894421595642007681503787211792933513169998096582404625392470799748638192261614690671840379980711680470773031979213622413156764245e-1"},
+		{"3.3715007305145263671875", "0.5", "-2.08893342814450907853686264753078182857225613186365483278682821839719343006120283013144020977384915780099799655151589064370063488805075334182272804409311187652124765889276425017776009083534873824167354394527551707647541900994192158013481098528192356979863984549481993831911625414036383366146218381432736198895815950586766882337053161562606677070279843568384655e-8"},
+		{"-0.125", "-0.5", "-1.24594255188315238150807958483528188932858182557618721461050125891036740021233709206061482288985234198460971436511334543630868822220677000929363078977519488211763625716264891982393648514962582523704588997016874644734856486323765115839825139060997471548200706761584397576080537714777313321849312808272427830764963626568057984618227817660991183365310975345671683e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			u := new(big.Float).SetPrec(prec)
+			u.Parse(test.u, 10)
+
+			k := new(big.Float).SetPrec(prec)
+			k.Parse(test.k, 10)
+
+			x := bigfloat.JacobiSn(u, k)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, JacobiSn(%v, %v) =\ngot  %g;\nwant %g", prec, test.u, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestJacobiCn(t *testing.T) {
+	for _, test := range []struct {
+		u, k string
+		want string
+	}{
+		{"0.5", "0.5", "8.79941022963758342138211939938800035594045353539382810624647430934302541840329933487517927603213346616164292643415627549033616874431028316636451176753015050807692840395669804806027629028057616547268923981955987614792430373779030634412269501708046923930804897734770925546156519971226835473667260899066176595839638348263906598594715348847313902562465389049136386e-1"},
+		{"1.25", "0.75", "4.39118833224671393900676686526397720624255718806775568026884921873613016722812604463983569273222653327058051151753776714993166372789407250913523085782578794864642809145130679362707888124202385410614324505505415443203509446266836642175206850736929086553370514886383605861716872485177163689306616675924060288454304846586771312920625054191409327830395862193541301e-1"},
+		{"-3", "0.875", "-4.14506488388440474250251474118629595202343360105563147629346292660434092858908640923382960495399316474944794544797006267324658177381014292320126771795564543020575864223620670116531829702892266607581367713760181802467312842745882827766433736077588797588653914202277994361011781355667743507528296554166472155187973434532184063710134504678514549211976256117907137e-1"},
+		{"10.5", "0.25", "-6.09268236764814969832455997598263852623514284344279009679028135652824877587871335255692283180525807833664511237294076938396139826802413304935412623278341343011975588962991741084711368979438296617005232213227389206748147793413662208767377599899106130200495643955691966663809663240218916314489111711281498071307241069802458888139509935888652241337915397701979455e-1"},
+		{"100.25", "0.999999940395355224609375", "-2.51201027338342765064005390673574784338347587876836123605062906429974208868818227675554499151808408053271077942387649148586858666369659161365139582472235308516148318100133312548727865611427177026081753629991780576861824757139632829533012019734871544317027887011220595302999899826822988666724041589097892538553663888481467439312473109095063370222629390318270934e-3"},
+		{"1p-40", "0.5", "9.99999999999999999999999586409693723486162564295710983999055183934395547661627024319599857813162854819633772933447286363811085129900063071403680177886847509420882483288629762061771971115544560407812653671300206177646861160700939339397057532377538156497685483685985945771418790770605015007334266348672823167548386477998572342231960818379453805223568758435171644e-1"},
+		{"2", "1", "2.65802228834079692120862739819888971530782654432268069714641147466724546188586815519099705405107785993592846929712351613217180008557141289302552601444471845361963328044936057908229067020928359991486301706011100746028979517706480384390028681794704740423903474828763863164838864354066442765422071149414693371673188651764068009455949867121671351690306044257780635e-1"},
+		{"0.75", "0", "7.31688868873820886311838753000084543840541276050772482507683220220750082501569499540967562610201174960122884908227300720779858320608699869270449272435955328888609798126761447486175424719796253854857404189223580759185795572406242799983907817502588946046757933631843196046616844175428165553865691669865334557507657842900117317343866303049359569861808824892418613e-1"},
+		{"1.68575036525726318359375", "0.5", "-9.04534707793830138272609973980354498328718838637508563108975305951592063969890904929592715132941276520700551809514454721463253297288072908958698421550328395885437056603503780028744697765696084043832087999732453890623936271455491646666580991943063123774930020173238704447578083877479702614056283913905219167144596094179582499479862931948355696876159228232409139e-9"},
+		{"3.3715007305145263671875", "0.5", "-9.99999999999999781817856639021432516719044048556165371783832579471107099101589434535353324366950483634491647615113940718528238366898140107732343180565606121914303408434851502240009377474503352660951366624258750284059773510728395852028676833597466385388573476901404978737611324886412805055469993210747772766091365365201146205253243979671821302882176563057922791e-1"},
+		{"-0.125", "-0.5", "9.92207776412818174279770780386359838593053025740527246766660360865014623068252694859156232763849650088518260161046338320477247558531611931687595023073279368678272144075207552138431052368010374993716849970764033211284433817900641546752497959106470914717737483237996431721718560644107558175736011140997465831499575188568609175454407268218160048377423864058907301e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			u := new(big.Float).SetPrec(prec)
+			u.Parse(test.u, 10)
+
+			k := new(big.Float).SetPrec(prec)
+			k.Parse(test.k, 10)
+
+			x := bigfloat.JacobiCn(u, k)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, JacobiCn(%v, %v) =\ngot  %g;\nwant %g", prec, test.u, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestJacobiDn(t *testing.T) {
+	for _, test := range []struct {
+		u, k string
+		want string
+	}{
+		{"0.5", "0.5", "9.71377398838178842823315157470233933307542433588855341182381771524120480621472045171099549550212667717062443951678260082634071144152939719525346752090977965221638128311546427499447000170213993176912241920418305537166882115860277510580327933652344007601608217754422823725041557720146314592988674140290267286792863814751268543594769304457151202844195646443217465e-1"},
+		{"1.25", "0.75", "7.38893943135336360382097851991387838538148799650629511769150551929689353271554312179840256297038334327052606249365646259957623445620542478276758416515089170421131734185650247025475037589040739567629908833161927713045775653452510373027683529631998733709393566762324327536409241926783275389649730787532325394888418664092675137400667282929817743344079621134141910e-1"},
+		{"-3", "0.875", "6.04914325246891116354935942638179191728030108560911202579664789767670039279116970610639567858777905304469624145613319628879627663138646040876528369002745376488726323996357119074450450361126569446816040423805579083593246772115756745850365114111467558294898246576467483327827009678089781329580239451957643620941671995363467231941278385982571558247731109589288825e-1"},
+		{"10.5", "0.25", "9.80153297459462034165600638917677651840409253642712226021186853262728633742422991821501204692196705314484117404483846615887734070825531389535906064354578759607541303927464084071610912790502040424845399022868870093605442087580621282140985115075752718095213070030258044921453913417788505109651411217323645135909597398963172033745591712182741670552997895816700745e-1"},
+		{"100.25", "0.999999940395355224609375", "2.53562697322536717244159194945219652094188772692833481676412653201557476954829473600414625766681045953756382302721103643213355007070447586252525492737811844261545188259302363940345015563466217005992924109176984731188541303052486722559164852765440381978529400956996536847425737127630820042456029716262832855486887402707744843782633416344819000100228212106705316e-3"},
+		{"1p-40", "0.5", "9.99999999999999999999999896602423430871540641073943782588024349157475730131446091033542633354447711855337575030261969296952961909445911762828590178587166252415119688687818005034514024405794260417025288374620947228312663126902345899436492520092240618892195384267093758778938594183686467733699894563032985503313445209905698943186789343213897489908554515154419472e-1"},
+		{"2", "1", "2.65802228834079692120862739819888971530782654432268069714641147466724546188586815519099705405107785993592846929712351613217180008557141289302552601444471845361963328044936057908229067020928359991486301706011100746028979517706480384390028681794704740423903474828763863164838864354066442765422071149414693371673188651764068009455949867121671351690306044257780635e-1"},
+		{"0.75", "0", "1.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e+0"},
+		{"1.68575036525726318359375", "0.5", "8.66025403784438658573178095924545726178178684831417182677631872078653561405784433349182600248869364461436339005254967098529295680023845637995743557635686659610125088203766886693533930898387815067357657659502957745862059082042165550335289409900024766801488517081069880239370232024312539995998619651049122100502304059852519131803208655488994230097984771588408470e-1"},
+		{"3.3715007305145263671875", "0.5", "9.99999999999999945454464159755362592002981161258408968489623661295227199559146390249682463503561308730570357981341038668312444957569599971151047710654653643362622445416907623325894265536898510217018037256797804113272082417501039342772574782847470325201356225767839230922411069148431837290737175144574239634571985765688222889066176723149247572492598099006834421e-1"},
+		{"-0.125", "-0.5", "9.98057647580297460514881187254892085339014933375548208769210414969537308453318407325981974377556285001579337173483889836431075420041498223931692884100233821975630652518741864677035239145522471217145102094206318307086707017239815514091875282494451489183260078176507838116643856339410614946725668650540840993131942477278177978119622376350336901132600954848015784e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			u := new(big.Float).SetPrec(prec)
+			u.Parse(test.u, 10)
+
+			k := new(big.Float).SetPrec(prec)
+			k.Parse(test.k, 10)
+
+			x := bigfloat.JacobiDn(u, k)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, JacobiDn(%v, %v) =\ngot  %g;\nwant %g", prec, test.u, test.k, x, want)
+			}
+		}
+	}
+}
+
+func TestJacobiIdentities(t *testing.T) {
+	// sn² + cn² = 1, dn² + k²sn² = 1
+	for _, u := range []float64{0.25, 1, 2.5, 10, -40} {
+		for _, k := range []float64{0.125, 0.5, 0.875, 0.9990234375} {
+			for _, prec := range []uint{53, 100, 500, 1000} {
+				x := new(big.Float).SetPrec(prec).SetFloat64(u)
+				y := new(big.Float).SetPrec(prec).SetFloat64(k)
+				sn, cn, dn := bigfloat.JacobiSn(x, y), bigfloat.JacobiCn(x, y), bigfloat.JacobiDn(x, y)
+
+				s := new(big.Float).Mul(sn, sn)
+				s.Add(s, new(big.Float).Mul(cn, cn))
+				d := new(big.Float).Mul(sn, y)
+				d.Mul(d, d)
+				d.Add(d, new(big.Float).Mul(dn, dn))
+
+				one := big.NewFloat(1)
+				for _, z := range []*big.Float{s.Sub(s, one), d.Sub(d, one)} {
+					if z.Sign() != 0 && z.MantExp(nil) > 4-int(prec) {
+						t.Errorf("prec = %d, u = %g, k = %g: identity is off by %g", prec, u, k, z)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestJacobiSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"JacobiSn(-0, 0.5)", bigfloat.JacobiSn(big.NewFloat(math.Copysign(0, -1)), big.NewFloat(0.5)), math.Copysign(0, -1)},
+		{"JacobiCn(0, 0.5)", bigfloat.JacobiCn(big.NewFloat(0), big.NewFloat(0.5)), 1},
+		{"JacobiDn(0, 0.5)", bigfloat.JacobiDn(big.NewFloat(0), big.NewFloat(0.5)), 1},
+		{"JacobiDn(1, 0)", bigfloat.JacobiDn(big.NewFloat(1), big.NewFloat(0)), 1},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkJacobiSn(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		u := big.NewFloat(1.25).SetPrec(prec)
+		k := big.NewFloat(0.75).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.JacobiSn(u, k)
+			}
+		})
+	}
+}
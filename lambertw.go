@@ -0,0 +1,170 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// LambertW0 returns a big.Float representation of W₀(z), the
+// principal branch of the Lambert W function, i.e. the solution
+// w >= -1 of w·exp(w) = z. Precision is the same as the one of the
+// argument. The function returns +Inf when z = +Inf, and panics if
+// z < -1/e.
+func LambertW0(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// LambertW0(±0) = ±0
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(z)
+
+	// LambertW0(+Inf) = +Inf
+	case z.IsInf() && z.Sign() > 0:
+		return new(big.Float).SetPrec(prec).SetInf(false)
+
+	case z.IsInf():
+		panic("LambertW0: argument is less than -1/e")
+	}
+
+	return lambertW(z, 0, "LambertW0")
+}
+
+// LambertWm1 returns a big.Float representation of W₋₁(z), the lower
+// branch of the Lambert W function, i.e. the solution w <= -1 of
+// w·exp(w) = z. Precision is the same as the one of the argument.
+// The function returns -Inf when z = ±0, and panics if z < -1/e or
+// z > 0.
+func LambertWm1(z *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	// LambertWm1(±0) = -Inf
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInf(true)
+
+	case z.Sign() > 0 || z.IsInf():
+		panic("LambertWm1: argument is not in [-1/e, 0]")
+	}
+
+	return lambertW(z, -1, "LambertWm1")
+}
+
+// lambertW returns W_k(z), for k = 0 or k = -1, with the same
+// precision as z. z must be finite and non-zero, and negative if
+// k = -1. fname is used in the panic message if z < -1/e.
+func lambertW(z *big.Float, branch int, fname string) *big.Float {
+
+	prec := z.Prec()
+
+	// q = 1 + e·z is the distance from the branch point z = -1/e,
+	// where W(z) = -1.
+	q := Exp(big.NewFloat(1).SetPrec(prec + 128))
+	q.Mul(q, z)
+	q.Add(q, big.NewFloat(1))
+	if q.Sign() < 0 {
+		panic(fname + ": argument is less than -1/e")
+	}
+
+	// Close to the branch point W(z) + 1 behaves like ±√(2q), and
+	// the derivative of w·exp(w) vanishes, so we solve for v = w + 1
+	// instead, which is known with full relative precision. We use
+	// v on the whole of W₋₁ and on W₀ for z < -1/4, so that
+	// |v| <= |w| (roughly) and we never lose precision.
+	useV := branch == -1 || z.Cmp(big.NewFloat(-0.25)) < 0
+
+	var guess *big.Float
+	if qf, _ := q.Float64(); useV && qf < 0.5 {
+		// v = p - p²/3 + 11p³/72 + O(p⁴), where p = ±√(2q)
+		p := new(big.Float).SetPrec(64).Set(q)
+		p.Sqrt(p.SetMantExp(p, 1))
+		if branch == -1 {
+			p.Neg(p)
+		}
+		c := new(big.Float).SetPrec(64).Mul(p, big.NewFloat(11.0/72))
+		c.Sub(c, big.NewFloat(1.0/3))
+		c.Mul(c, p)
+		c.Add(c, big.NewFloat(1))
+		guess = c.Mul(c, p)
+	} else {
+		guess = lambertWGuess(z, branch)
+		if useV {
+			guess.Add(guess, big.NewFloat(1))
+		}
+	}
+
+	// The computation of f below suffers from cancellation close to
+	// the branch point, where the result is about 2q-times more
+	// sensitive to rounding errors; and the absolute error on w
+	// becomes a relative error in exp(w). We need extra bits for
+	// both.
+	guard := uint(2)
+	if e := q.MantExp(nil); e < 0 {
+		guard += uint(-e)
+	}
+	if e := guess.MantExp(nil); e > 0 {
+		guard += uint(e)
+	}
+
+	// For f(w) = w·exp(w) - z, we have
+	//     f(w)/f'(w) = (w - z·exp(-w)) / (w+1)
+	// and for w = v - 1
+	//     f(v)/f'(v) = ((v-1) - z·exp(1-v)) / v
+	one := big.NewFloat(1)
+	f := func(t *big.Float) *big.Float {
+		p := t.Prec() + guard
+		w := new(big.Float).SetPrec(p).Set(t)
+		d := new(big.Float).SetPrec(p).Set(t) // f'(w)/exp(w)
+		if useV {
+			w.Sub(w, one)
+		} else {
+			d.Add(d, one)
+		}
+		x := Exp(new(big.Float).SetPrec(p).Neg(w))
+		x.Mul(x, z)
+		x.Sub(w, x)
+		return x.Quo(x, d).SetPrec(t.Prec())
+	}
+
+	// newton expects a guess that is accurate to its precision, so
+	// we polish the initial estimate with a few low precision steps.
+	t := new(big.Float).SetPrec(64).Set(guess)
+	for i := 0; i < 100; i++ {
+		d := f(t)
+		t.Sub(t, d)
+		if d.Sign() == 0 || d.MantExp(nil)-t.MantExp(nil) < -60 {
+			break
+		}
+	}
+
+	x := newton(f, t, prec+64)
+	if useV {
+		x.Sub(x, one)
+	}
+	return x.SetPrec(prec)
+}
+
+// lambertWGuess returns a rough estimate of W_k(z), for k = 0 or
+// k = -1, away from the branch point.
+func lambertWGuess(z *big.Float, branch int) *big.Float {
+
+	zf, _ := z.Float64()
+	switch {
+	// W₀(z) = z - z² + O(z³)
+	case branch == 0 && math.Abs(zf) < 0x1p-30:
+		return new(big.Float).SetPrec(64).Set(z)
+
+	case branch == 0 && zf < 3:
+		return big.NewFloat(math.Log1p(zf))
+	}
+
+	// For large |log|z||, following R. M. Corless et al., On the
+	// Lambert W function, 1996, Eq. 4.19,
+	//     W(z) = L1 - L2 + L2/L1 + ...
+	// where L1 = log(z) and L2 = log(L1) on W₀, and L1 = log(-z) and
+	// L2 = log(-L1) on W₋₁.
+	l1, _ := Log(new(big.Float).SetPrec(64).Abs(z)).Float64()
+	l2 := math.Log(math.Abs(l1))
+	return big.NewFloat(l1 - l2 + l2/l1)
+}
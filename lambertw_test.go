@@ -0,0 +1,171 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestLambertW0(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"1", "5.67143290409783872999968662210355549753815787186512508135131079223045793086684566693219446961752294557638024972866789785452358465940072995608516439289994614311571492959803594376698474635606134226846135698957045397762485570786587733706356633301238430455635429786085090154290819208560557523748196584659508072730890501573361831596070667108039283918360149499646349e-1"},
+		{"0.5", "3.51733711249195826024909300929951065171464215517111804046643846109960610720338710896832303832191569273769306698508861908035853169977886432335908407734682233805722204470188566622090448983966177307186283983349682556370299098495080230929131069173026775178802066235364915368194976953902213186999384009629836598439739259907331894904021158264217960664512967841270133e-1"},
+		{"-0.25", "-3.57402956181388903068811104055904753316590555076012043627620448589671402596145796289616851344441185149725100031298290143630791376151539156798795190894161839224053116690761598068865826449844743354775659981892592251944412707087465811647524535942567231767772518142702186528349188782040053745224953856632115318678154247021327601364063637516320069873824063341482858e-1"},
+		{"-0.296875", "-4.79561988452774649179168837911593571596673330567901229973972838432183704808422401752284588821454053711671582949426091059808676803278128966976232311359360465394545789771097922497756537318266297471404445802690914482161576418658144239265465973242157581613823231595852856157550965626698485836956905475580094249298268593850923047298301739064003300578935066289146337e-1"},
+		{"-0.3662109375", "-9.07656463549220722643589871777316714498460809242217234668803673996260586864135627421633931768957156609620395785320638599236384359249482582822365573264592101795140216766776900431244215269090190080224465906418212257410419859951058162764060621914678639040897071308626449881374812784060538046414808161342711615388944656761350685701233388983451060192419234784667023e-1"},
+		{"-0.3678794205188751220703125", "-9.99664956838845533749686768126937719346269762646558399903435955144243913036249026523306727192919648870520634274083197517573011439273366805848917836077276671892380677654513245884782957738125077079826207189615709873642411585529611996494302155961834733196796244534928652785886505129625435928925081628190813099711648779851420268335684730986267398301969353297890340e-1"},
+		{"10", "1.74552800274069938307430126487538991153528812908094133132220604855555725994155170498952351077888307540007077180904537487437145367930696902659927529754867046094407095004706264885271837810474916185351412764743206953693140653799845997626424132775007699237111182719209865636236156656336554654497010438390530906701626739121822968974447265695374436349408566119370812e+0"},
+		{"1000.5", "5.25002274504089801269556720054064511224396625185432812286597367578070847656200856083144297874201713025725969114376782187297216591901072497431360356918479358014831248627982457596638876747082730983609068324063129892500556023138789964858818242507836289873210550648050125032908705105730564849270197353347568099045091949862324082115986713146905850482788256203185142e+0"},
+		{"0.0009765625", "9.75610220246753049981874871098909891771765406295215921754485032055865610778421924431033430777759738224325431912484694993820855621804820286991376904813455523695503204437330508037969535700698492244860620067374002970566318935260967004228868841120802106444661634269658137765017449149262974837831990080782934475226051368891889768403123443154869965684997204152517573e-4"},
+		{"-1p-100", "-7.88860905221011805411728565283408531200992549279737411175657679452141319875855081079103360635516593458881952679694663425965088135531733567913617993599890161412388449720155547312457307522307102626669603812976345040318878446134954405396659047515386620132646855185514095240695831785367198446727966361806688540152583138982365098333205983945202224790284150631293777e-31"},
+		{"1p100", "6.51382067851536461395356979326614452101921146321394308812713700777146238832288237695276492927206006849207400899669212448313314452231223772993360374711143080167019864717411060822632396787909295109508686078913673977870752261353498292896430087063085124969693234588748766146746941322775590606381404512380479515379156513627748102754689219743375208396150120128674298e+1"},
+		{"1p10000", "6.92262925467290689798486571336576054338760409637605249317190653210187507814401759775105992828417785466137287219170728268815716013840346616381059080687998170069132782471540751807552319128940066514514005149133690504024866407976577397818504047713102051487525201026496608746902538390474719022634401420795693677106925280528651892664281476159401983527325073528238890e+3"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.LambertW0(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LambertW0(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestLambertWm1(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"-0.25", "-2.15329236411034964916909915009298137553620648531947769588451150772136258465064937894572790953802743911822772009362807372934285806962539133995923250836207167483792329806505239580254539543640741924660069799856988920362507620941526599797310456050233745762589528830081018067198226653110626082635797041795893019361984728648801084369296691164936042609922371046832256e+0"},
+		{"-0.296875", "-1.80501052945306199730750580258515745528363426501062450976570878562982098984274991935277342899363776756059128280424854275719313050666116929893470897177999588228775011563522839220715297899078299529940264953088016825193075687277090771963753694641584363114155187362996940455146707207137555567997883828488924029727607370450046122782707656237571981556871528756912691e+0"},
+		{"-0.3662109375", "-1.09840396377459477101839914038306074530683016455476229762803257467123378730409921539249481998185987769837766994232361577157353026719760404384103720901624673036021386321258684527135266882311319570243999802434524073607742582290814883629799560803612758161584020926105311426871810775112391577472734353717945683331656843323889666202152875249404921715982221321863118e+0"},
+		{"-0.3678794205188751220703125", "-1.00033511801382064662879084366378217548064183686219730094896825000881005073780924691072238914672703180874776368119610391203889711400753474650415709425126949693573506727585414304109366667805396341733367862660534595484306693076322375393851358441339019853248654274320301877540703626945796985461583378478598844229002184601773964744163830073362922969361721506457318e+0"},
+		{"-0.125", "-3.26168568457648877690566236430873973172114539334780952204021807988063514676855672840946045894261124194688643957944430912721508091741932332533149238351205242211979709106938887852549564447305687361868046607896820728061937370897243460789083168163285754950852646264398029872794756858409873559380105323048258096751550947240882951135284370483150934898680743640254693e+0"},
+		{"-0.0009765625", "-9.14463968662508319248836861113583194930933690403963821243199540505418433130654621254628678076372631259006311826197784505151906460278923640780870248766744504645500964409989485170688366542964733088526535097755211612683331411786879148647416045166369175176733778835496239370073051486163174502001397644762842329168167775341610898131780211344215232063219588326249597e+0"},
+		{"-1p-100", "-7.36135471290449432427224353797071966749972288906673297529926138763993858916390669029242474029754818519122529853046195956006670485452375143079411410081229543042028281462470974387145077685075478557724531475770743861803561356464802425895676715433389407932962809252236152908112224735674540901027691298677037449870284659244824591332077357786747254191134777312712766e+1"},
+		{"-1p-10000", "-6.94031690831593384347611673205675050963480846702888435947076531297905872209988453911295258634843008626277802415570115736993132109906191921452824056866361190349836241393686613383156949377436725876887816513274660530270569706251511069434436741563081061276809814293243856561572844150271608014960172653504779943998391439596994152271229841498984224740792772784666934e+3"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.LambertWm1(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LambertWm1(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestLambertWBranchPoint(t *testing.T) {
+	// z = -K·2**-501, just above -1/e
+	k, _ := new(big.Int).SetString("2408426215137361085007688586923329707303857763582554419324009324581879871464434126052151059071771230176981500514201964510714102057170230842720129047445", 10)
+	for _, test := range []struct {
+		branch int
+		want   string
+	}{
+		{0, "-9.99999999999999999999999999999999999999999999999999999999999999999999999999506449562616563301242640994019374231977540320382432980984561684929218939123765841725331940470238491595160063009328909314619300440389452567255698891193417738596913277930826087340013862831421243411383148761303082505216234546894165305959721469533992882712175209112034405352562926894871505e-1"},
+		{-1, "-1.00000000000000000000000000000000000000000000000000000000000000000000000000049355043738343669875735900598062576802245967961756701901543831507078106087639655296416231397617090006529939605744644445754650875085083945911404690573573457852009211617794299947930815110831970203162011385031709011975002227569057843243786499317224771549285292689802670886745961904909847e+0"},
+	} {
+		for _, prec := range []uint{500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec).SetInt(k)
+			z.SetMantExp(z, -501)
+			z.Neg(z)
+
+			var x *big.Float
+			if test.branch == 0 {
+				x = bigfloat.LambertW0(z)
+			} else {
+				x = bigfloat.LambertWm1(z)
+			}
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, W%d(-1/e + ε) =\ngot  %g;\nwant %g", prec, test.branch, x, want)
+			}
+		}
+	}
+}
+
+func testLambertWFloat64(branch int, nTests int, t *testing.T) {
+	for i := 0; i < nTests; i++ {
+		// w·exp(w) = z
+		var w float64
+		if branch == 0 {
+			w = -1 + rand.ExpFloat64()
+		} else {
+			w = -1 - rand.ExpFloat64()
+		}
+		z := big.NewFloat(w * math.Exp(w))
+
+		var x64 float64
+		if branch == 0 {
+			x64, _ = bigfloat.LambertW0(z).Float64()
+		} else {
+			x64, _ = bigfloat.LambertWm1(z).Float64()
+		}
+
+		// z has been rounded, and close to the branch point W is
+		// very sensitive to it.
+		tol := 1e-14 / math.Max(math.Abs(w+1), 1e-8)
+		if math.Abs(x64-w) > tol*math.Abs(w) {
+			t.Errorf("W%d(%g) =\n got %g;\nwant %g", branch, z, x64, w)
+		}
+	}
+}
+
+func TestLambertWFloat64(t *testing.T) {
+	testLambertWFloat64(0, 1e3, t)
+	testLambertWFloat64(-1, 1e3, t)
+}
+
+func TestLambertWSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"LambertW0(+0)", bigfloat.LambertW0(big.NewFloat(0)), 0},
+		{"LambertW0(-0)", bigfloat.LambertW0(big.NewFloat(math.Copysign(0, -1))), math.Copysign(0, -1)},
+		{"LambertW0(+Inf)", bigfloat.LambertW0(big.NewFloat(math.Inf(+1))), math.Inf(+1)},
+		{"LambertWm1(-0)", bigfloat.LambertWm1(big.NewFloat(math.Copysign(0, -1))), math.Inf(-1)},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkLambertW0(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.LambertW0(z)
+			}
+		})
+	}
+}
@@ -1,34 +1,47 @@
 package bigfloat
 
-import "math/big"
+import (
+	"math"
+	"math/big"
+	"sync"
+)
 
-// agm returns the arithmetic-geometric mean of a and b.
-// a and b must have the same precision.
-func agm(a, b *big.Float) *big.Float {
+// AGM returns a big.Float representation of the arithmetic-geometric
+// mean of a and b. Precision is the same as the one of the first
+// argument. The function returns 0 when a or b is zero, +Inf when a
+// or b is +Inf, and panics if a or b is negative.
+func AGM(a, b *big.Float) *big.Float {
 
-	if a.Prec() != b.Prec() {
-		panic("agm: different precisions")
+	if a.Sign() < 0 || b.Sign() < 0 {
+		panic("AGM: argument is negative")
 	}
 
 	prec := a.Prec()
 
+	switch {
+	// AGM(a, 0) = AGM(0, b) = 0
+	case a.Sign() == 0 || b.Sign() == 0:
+		return new(big.Float).SetPrec(prec)
+
+	// AGM(a, +Inf) = AGM(+Inf, b) = +Inf
+	case a.IsInf() || b.IsInf():
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
 	// do not overwrite a and b
-	a2 := new(big.Float).Copy(a).SetPrec(prec + 64)
-	b2 := new(big.Float).Copy(b).SetPrec(prec + 64)
+	a2 := new(big.Float).SetPrec(prec + 64).Set(a)
+	b2 := new(big.Float).SetPrec(prec + 64).Set(b)
 
 	if a2.Cmp(b2) == -1 {
 		a2, b2 = b2, a2
 	}
 	// a2 >= b2
 
-	// set lim to 2**(-prec)
-	lim := new(big.Float)
-	lim.SetMantExp(big.NewFloat(1).SetPrec(prec+64), -int(prec+1))
-
 	half := big.NewFloat(0.5)
 	t := new(big.Float)
 
-	for t.Sub(a2, b2).Cmp(lim) != -1 {
+	// iterate until a2 - b2 < a2·2**(-prec)
+	for t.Sub(a2, b2).Sign() != 0 && t.MantExp(nil)-a2.MantExp(nil) >= -int(prec+1) {
 		t.Copy(a2)
 		a2.Add(a2, b2).Mul(a2, half)
 		b2.Sqrt(b2.Mul(b2, t))
@@ -107,22 +120,298 @@ func pi(prec uint) *big.Float {
 	return a
 }
 
-// returns an approximate (to precision dPrec) solution to
-//    f(t) = 0
-// using the Newton Method.
-// fOverDf needs to be a fuction returning f(t)/f'(t).
-// t must not be changed by fOverDf.
-// guess is the initial guess (and it's not preserved).
+// eulerCache holds γ to eulerCachePrec bits. Both are guarded by
+// eulerMu.
+var eulerMu sync.Mutex
+var eulerCache *big.Float
+var eulerCachePrec uint
+
+// EulerGamma returns the Euler–Mascheroni constant γ = 0.5772... to
+// prec bits of precision.
+func EulerGamma(prec uint) *big.Float {
+
+	eulerMu.Lock()
+	if prec <= eulerCachePrec {
+		z := new(big.Float).Copy(eulerCache).SetPrec(prec)
+		eulerMu.Unlock()
+		return z
+	}
+	eulerMu.Unlock()
+
+	// Following R. P. Brent and E. M. McMillan, Some new algorithms
+	// for high-precision computation of Euler's constant, Math.
+	// Comp. 34 (1980), Algorithm B1:
+	//     γ = U/V - log(n) + O(exp(-4n))
+	// where U = Σ A_k, V = Σ B_k, B_k = (n^k/k!)², and
+	// A_k = B_k (H_k - log(n)), H_k being the k-th harmonic number.
+	//
+	// The terms grow up to about exp(2n) before decreasing, so we
+	// need 2n·log2(e), or about prec/2, extra bits.
+	n := int64(float64(prec+64)*math.Ln2/4) + 1
+	wprec := prec + prec/2 + 64
+
+	a := Log(big.NewFloat(float64(n)).SetPrec(wprec))
+	a.Neg(a)                                  // A_0 = -log(n)
+	b := big.NewFloat(1).SetPrec(wprec)       // B_0 = 1
+	u := new(big.Float).SetPrec(wprec).Set(a) // U = A_0
+	v := big.NewFloat(1).SetPrec(wprec)       // V = B_0
+
+	n2 := new(big.Float).SetInt64(n * n)
+	t := new(big.Float)
+	for k := int64(1); ; k++ {
+		t.SetInt64(k * k)
+		b.Mul(b, n2).Quo(b, t) // B_k = B_(k-1) n²/k²
+
+		t.SetInt64(k)
+		a.Mul(a, n2).Quo(a, t)
+		a.Add(a, b).Quo(a, t) // A_k = (A_(k-1) n²/k + B_k)/k
+
+		u.Add(u, a)
+		v.Add(v, b)
+
+		if b.MantExp(nil)-v.MantExp(nil) < -int(wprec) && a.MantExp(nil)-u.MantExp(nil) < -int(wprec) {
+			break
+		}
+	}
+
+	u.Quo(u, v)
+	eulerMu.Lock()
+	if prec+64 > eulerCachePrec {
+		eulerCache = u
+		eulerCachePrec = prec + 64
+	}
+	eulerMu.Unlock()
+
+	return new(big.Float).Copy(u).SetPrec(prec)
+}
+
+// newton returns a solution of f(t) = 0 to dPrec bits of precision,
+// using Newton's method from guess as findRoot does, where fOverDf
+// returns f(t)/f'(t) and must not modify t.
 func newton(fOverDf func(z *big.Float) *big.Float, guess *big.Float, dPrec uint) *big.Float {
+	x, err := findRoot(fOverDf, guess, dPrec)
+	if err != nil {
+		panic("newton: " + err.Error())
+	}
+	return x
+}
+
+// sincos returns sin(z) and cos(z), both with the same precision
+// as z. The function panics when z = ±Inf.
+func sincos(z *big.Float) (*big.Float, *big.Float) {
+
+	prec := z.Prec()
+
+	if z.IsInf() {
+		panic("sincos: argument is infinite")
+	}
+
+	// sin(0) = 0, cos(0) = 1
+	if z.Sign() == 0 {
+		return new(big.Float).SetPrec(prec), big.NewFloat(1).SetPrec(prec)
+	}
+
+	r, k := reduceHalfPi(z, prec)
+
+	// Compute sin and cos of r/2**h using Taylor series, then
+	// scale back using the double-angle formulae
+	//     sin(2x) = 2sin(x)cos(x)
+	//     cos(2x) = 1 - 2sin²(x)
+	// Each doubling can lose about one bit, so we add h more
+	// guard bits.
+	h := uint(math.Sqrt(float64(prec))) / 2
+	wprec := r.Prec() + h
+	r.SetPrec(wprec)
+	r.SetMantExp(r, -int(h))
+
+	r2 := new(big.Float).SetPrec(wprec).Mul(r, r)
+	sin := new(big.Float).SetPrec(wprec).Set(r)
+	cos := big.NewFloat(1).SetPrec(wprec)
+
+	// lim is 2**(-wprec), relative to the first term
+	lim := new(big.Float).SetMantExp(big.NewFloat(1), r.MantExp(nil)-int(wprec))
+
+	ts := new(big.Float).SetPrec(wprec).Set(r) // sin's term
+	tc := big.NewFloat(1).SetPrec(wprec)       // cos's term
+	t := new(big.Float).SetPrec(wprec)
+	for n := int64(1); ; n += 2 {
+		// ts = -ts·r²/((n+1)(n+2)), tc = -tc·r²/(n(n+1))
+		ts.Mul(ts, r2).Quo(ts, t.SetInt64(-(n+1)*(n+2)))
+		tc.Mul(tc, r2).Quo(tc, t.SetInt64(-n*(n+1)))
+		sin.Add(sin, ts)
+		cos.Add(cos, tc)
+		if new(big.Float).Abs(tc).Cmp(lim) < 0 {
+			break
+		}
+	}
+
+	two := big.NewFloat(2)
+	for i := uint(0); i < h; i++ {
+		t.Mul(sin, sin)
+		sin.Mul(sin, cos).Mul(sin, two)
+		cos.Sub(big.NewFloat(1), t.Mul(t, two))
+	}
 
-	prec, guard := guess.Prec(), uint(64)
-	guess.SetPrec(prec + guard)
+	// move back to the right quadrant
+	switch new(big.Int).And(k, big.NewInt(3)).Int64() {
+	case 1:
+		sin, cos = cos, sin.Neg(sin)
+	case 2:
+		sin, cos = sin.Neg(sin), cos.Neg(cos)
+	case 3:
+		sin, cos = cos.Neg(cos), sin
+	}
+
+	return sin.SetPrec(prec), cos.SetPrec(prec)
+}
+
+// reduceHalfPi returns r and k such that z = k(π/2) + r, with
+// |r| <= π/4. r is accurate to at least prec bits, and its precision
+// is larger than prec by the number of guard bits that were needed.
+// z must be finite.
+func reduceHalfPi(z *big.Float, prec uint) (*big.Float, *big.Int) {
+
+	// The reduction needs as many extra bits as z's exponent, and
+	// it needs more if z happens to be close to a multiple of π/2,
+	// in which case we lose the leading bits of r and retry.
+	exp := z.MantExp(nil)
+	if exp < 0 {
+		exp = 0
+	}
+	guard := uint(64 + exp)
+
+	r, k := new(big.Float), new(big.Int)
+	for {
+		halfPi := pi(prec + guard)
+		halfPi.SetMantExp(halfPi, -1)
+
+		r.SetPrec(prec+guard).Quo(z, halfPi)
+		roundInt(r, k)
+		r.Sub(r, new(big.Float).SetInt(k))
+		r.Mul(r, halfPi)
+
+		lost := -r.MantExp(nil)
+		if r.Sign() == 0 || lost < 0 || uint(lost) < guard-uint(exp)-32 {
+			break
+		}
+		guard += uint(lost)
+	}
+
+	return r, k
+}
+
+// roundInt sets k to z rounded to the nearest integer (with ties
+// away from zero), and returns it.
+func roundInt(z *big.Float, k *big.Int) *big.Int {
+	t := new(big.Float).SetPrec(z.Prec() + 1).Set(z)
+	if z.Sign() >= 0 {
+		t.Add(t, big.NewFloat(0.5))
+	} else {
+		t.Sub(t, big.NewFloat(0.5))
+	}
+	t.Int(k)
+	return k
+}
+
+// sincosPi returns sin(πz) and cos(πz), both with the same precision
+// as z. Unlike sincos, the reduction of the argument is exact, so
+// the results have full relative precision even when z is large or
+// close to an integer.
+func sincosPi(z *big.Float) (*big.Float, *big.Float) {
+
+	prec := z.Prec()
+
+	if z.IsInf() {
+		panic("sincosPi: argument is infinite")
+	}
+
+	// z = k/2 + r, |r| <= 1/4, with r computed exactly
+	k := roundInt(new(big.Float).SetMantExp(z, 1), new(big.Int))
+	h := new(big.Float).SetInt(k)
+	h.SetMantExp(h, -1)
+	r := new(big.Float).SetPrec(z.MinPrec()+2).Sub(z, h)
+
+	// sin(π(k/2 + r)) and cos(π(k/2 + r)) are ±sin(πr) and ±cos(πr)
+	x := new(big.Float).SetPrec(prec + 64)
+	sin, cos := sincos(x.Mul(pi(prec+64), r))
+
+	switch new(big.Int).And(k, big.NewInt(3)).Int64() {
+	case 1:
+		sin, cos = cos, sin.Neg(sin)
+	case 2:
+		sin, cos = sin.Neg(sin), cos.Neg(cos)
+	case 3:
+		sin, cos = cos.Neg(cos), sin
+	}
+
+	return sin.SetPrec(prec), cos.SetPrec(prec)
+}
+
+// bernoulliCache[k-1] holds B_2k. It is guarded by bernoulliMu.
+var bernoulliMu sync.Mutex
+var bernoulliCache []*big.Rat
+
+// bernoulli returns the Bernoulli number B_n, with B_1 = -1/2. The
+// returned value must not be modified.
+func bernoulli(n int) *big.Rat {
+	switch {
+	case n == 0:
+		return big.NewRat(1, 1)
+	case n == 1:
+		return big.NewRat(-1, 2)
+	case n%2 == 1:
+		return new(big.Rat)
+	}
+
+	k := n / 2
+	bernoulliMu.Lock()
+	cache := bernoulliCache
+	bernoulliMu.Unlock()
+	if k <= len(cache) {
+		return cache[k-1]
+	}
+
+	// Compute the tangent numbers T_1, ..., T_m, following R. P.
+	// Brent and D. Harvey, Fast computation of Bernoulli, Tangent and
+	// Secant numbers, 2011, Algorithm TangentNumbers. Then
+	//     B_2k = (-1)^(k-1) 2k T_k / (2^2k (2^2k - 1))
+	// We compute at least twice as many numbers as the ones already
+	// in the cache, so that the cost of growing it is amortized.
+	m := 2 * len(cache)
+	if m < k {
+		m = k
+	}
+
+	t := make([]*big.Int, m+1)
+	t[1] = big.NewInt(1)
+	for j := 2; j <= m; j++ {
+		t[j] = new(big.Int).Mul(big.NewInt(int64(j-1)), t[j-1])
+	}
+	u := new(big.Int)
+	for i := 2; i <= m; i++ {
+		for j := i; j <= m; j++ {
+			u.Mul(big.NewInt(int64(j-i)), t[j-1])
+			t[j].Mul(big.NewInt(int64(j-i+2)), t[j])
+			t[j].Add(t[j], u)
+		}
+	}
+
+	cache = make([]*big.Rat, m)
+	for j := 1; j <= m; j++ {
+		num := new(big.Int).Mul(big.NewInt(int64(2*j)), t[j])
+		if j%2 == 0 {
+			num.Neg(num)
+		}
+		den := new(big.Int).Lsh(big.NewInt(1), uint(2*j))
+		den.Mul(den, u.Sub(den, big.NewInt(1)))
+		cache[j-1] = new(big.Rat).SetFrac(num, den)
+	}
 
-	for prec < 2*dPrec {
-		guess.Sub(guess, fOverDf(guess))
-		prec *= 2
-		guess.SetPrec(prec + guard)
+	bernoulliMu.Lock()
+	if m > len(bernoulliCache) {
+		bernoulliCache = cache
 	}
+	bernoulliMu.Unlock()
 
-	return guess.SetPrec(dPrec)
+	return cache[k-1]
 }
@@ -2,7 +2,9 @@ package bigfloat
 
 import (
 	"fmt"
+	"math"
 	"math/big"
+	"sync"
 	"testing"
 )
 
@@ -30,7 +32,7 @@ func TestAgm(t *testing.T) {
 			b := new(big.Float).SetPrec(prec)
 			b.Parse(test.b, 10)
 
-			z := agm(a, b)
+			z := AGM(a, b)
 
 			if z.Cmp(want) != 0 {
 				t.Errorf("prec = %d, Agm(%v, %v) =\ngot  %g;\nwant %g", prec, test.a, test.b, z, want)
@@ -39,6 +41,41 @@ func TestAgm(t *testing.T) {
 	}
 }
 
+func TestAgmScaling(t *testing.T) {
+	// AGM(2**e·a, 2**e·b) = 2**e·AGM(a, b)
+	for _, e := range []int{-1000, -10, 10, 1000} {
+		for _, prec := range []uint{24, 53, 100, 1000} {
+			a := big.NewFloat(1).SetPrec(prec)
+			b := big.NewFloat(3).SetPrec(prec)
+			want := AGM(a, b)
+			want.SetMantExp(want, e)
+
+			z := AGM(a.SetMantExp(a, e), b.SetMantExp(b, e))
+
+			if z.Cmp(want) != 0 {
+				t.Errorf("prec = %d, AGM(2**%d, 3·2**%d) =\ngot  %g;\nwant %g", prec, e, e, z, want)
+			}
+		}
+	}
+}
+
+func TestAgmSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		a, b, want float64
+	}{
+		{0, 2, 0},
+		{2, 0, 0},
+		{math.Inf(+1), 2, math.Inf(+1)},
+		{2, math.Inf(+1), math.Inf(+1)},
+		{2, 2, 2},
+	} {
+		z, acc := AGM(big.NewFloat(test.a), big.NewFloat(test.b)).Float64()
+		if z != test.want || acc != big.Exact {
+			t.Errorf("AGM(%g, %g) = %g (%v); want %g (Exact)", test.a, test.b, z, acc, test.want)
+		}
+	}
+}
+
 func TestPi(t *testing.T) {
 	enablePiCache = false
 	piStr := "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679821480865132823066470938446095505822317253594081284811174502841027019385211055596446229489549303819644288109756659334461284756482337867831652712019091456485669234603486104543266482133936072602491412737245870066063155881748815209209628292540917153644"
@@ -56,6 +93,57 @@ func TestPi(t *testing.T) {
 	enablePiCache = true
 }
 
+func TestSincos(t *testing.T) {
+	for _, test := range []struct {
+		z        string
+		sin, cos string
+	}{
+		{"1", "8.41470984807896506652502321630298999622563060798371065672751709991910404391239668948639743543052695854349037907920674293259118920991898881193410327729212409480791955826766606999907764011978408782732566347484802870298656157017962455394893572924670127086486281053382030561377218203868449667761674266239013382753397956764255565477963989764824328690275696429120630e-1", "5.40302305868139717400936607442976603732310420617922227670097255381100394774471764517951856087183089343571731160030089097860633760021663456406512265417318584717971164474479494233117924551393254335943517756702892596375736154327549641754491775115131222730100631357078232236771401517468995936678730674227620245077637440675874981617842720216455851115632968890571081e-1"},
+		{"-3", "-1.41120008059867222100744802808110279846933264252265584151882641232422009967014471911282172853449863750413672948267327416844457031668857573754033657854911217811785476834820782166764137215566658864689844031538330125152783590765223504441950944889833925545622241603836241829395442591744103664574056654115459930982300851165901554812310315837935475921351670070155949e-1", "-9.89992496600445457271572794731261302393679096615588328814085932928329197513133220428294479355692602171495993112414169189571629286320229688602168542679234871819986249622389187501026624033235996418291729908639186429576430944877190434698005571502342677770615379990457137990442605088096402385557645431447736601061061533149529777531155979375183061845267906372342822e-1"},
+		{"1e10", "-4.87506025087510691527794294348106041676447316922786885745254537845158563447074794434213180143195804456735820740449580278488866903301853974537441134722275714682797681275061137565478903151147659335831819852572872383648697542598390246824950513958995553200289205824151154252139571605545130477132669027620085789524410906546687323748375794602992907926972527639643307e-1", "8.73119622676856001176191345307695196190412600167686736069219292875926435125889060754703218143845612130529834486792966384402581060650324681600485852211232587493192505228146387230640234974864865066626245665880120195887937946653137650242595327483248446284918461629277522399530809090912238371869620677061387046216548383865714748578293827412343693686358472385383027e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			wantSin := new(big.Float).SetPrec(prec)
+			wantSin.Parse(test.sin, 10)
+			wantCos := new(big.Float).SetPrec(prec)
+			wantCos.Parse(test.cos, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			sin, cos := sincos(z)
+
+			if sin.Cmp(wantSin) != 0 || cos.Cmp(wantCos) != 0 {
+				t.Errorf("prec = %d, sincos(%v) =\ngot  %g, %g;\nwant %g, %g", prec, test.z, sin, cos, wantSin, wantCos)
+			}
+		}
+	}
+}
+
+func TestBernoulliConcurrent(t *testing.T) {
+	// the cached Bernoulli numbers are shared between goroutines
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		n    int
+		want string
+	}{
+		{20, "-174611/330"},
+		{40, "-261082718496449122051/13530"},
+		{60, "-1215233140483755572040304994079820246041491/56786730"},
+		{80, "-4603784299479457646935574969019046849794257872751288919656867/230010"},
+	} {
+		wg.Add(1)
+		go func(n int, want string) {
+			defer wg.Done()
+			w, _ := new(big.Rat).SetString(want)
+			if b := bernoulli(n); b.Cmp(w) != 0 {
+				t.Errorf("bernoulli(%d) =\ngot  %v;\nwant %v", n, b, w)
+			}
+		}(test.n, test.want)
+	}
+	wg.Wait()
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkAgm(b *testing.B) {
@@ -65,7 +153,7 @@ func BenchmarkAgm(b *testing.B) {
 		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
 			b.ReportAllocs()
 			for n := 0; n < b.N; n++ {
-				agm(x, y)
+				AGM(x, y)
 			}
 		})
 	}
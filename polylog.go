@@ -0,0 +1,651 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Polylog returns a big.Float representation of the polylogarithm
+// Li_s(z) = Σ z^k/k^s, for k >= 1, of real order s. Precision is the
+// same as the one of z. The function returns ζ(s) when z = 1 and
+// s > 1, and +Inf when z = 1 and s <= 1. For z > 1, where Li_s has
+// a branch cut, Polylog returns the real part of Li_s(z), which is
+// the same on both sides of the cut. It panics if s or z is
+// infinite.
+func Polylog(s, z *big.Float) *big.Float {
+	if s.IsInf() {
+		panic("Polylog: order is infinite")
+	}
+	return polylogSpecial(s, z, "Polylog")
+}
+
+// Dilog returns a big.Float representation of the dilogarithm
+// Li₂(z) = Σ z^k/k², for k >= 1. Precision is the same as the one of
+// the argument. The function returns π²/6 when z = 1, and the real
+// part of Li₂(z) when z > 1, as Polylog does. It panics if z is
+// infinite.
+func Dilog(z *big.Float) *big.Float {
+	return polylogSpecial(big.NewFloat(2), z, "Dilog")
+}
+
+// polylogSpecial returns Li_s(z) with the precision of z, handling
+// the special values of z, or panics if it is not defined, using
+// fname in the panic messages.
+func polylogSpecial(s, z *big.Float, fname string) *big.Float {
+
+	prec := z.Prec()
+
+	one := big.NewFloat(1)
+	switch {
+	case z.IsInf():
+		panic(fname + ": argument is infinite")
+
+	// Li_s(±0) = ±0
+	case z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(z)
+
+	// Li_s(1) = ζ(s) for s > 1, and +Inf otherwise
+	case z.Cmp(one) == 0:
+		if s.Cmp(one) > 0 {
+			return zeta(s, prec+64).SetPrec(prec)
+		}
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	// For s = -n, with n a non-negative integer,
+	//     Li_s(z) = Σ k!·S(n+1, k+1)·(z/(1-z))^(k+1)
+	// for k = 0, ..., n, where S are the Stirling numbers of the
+	// second kind, and we compute it exactly.
+	if n, ok := exactZetaArg(s); ok && n <= 0 {
+		x, _ := z.Rat(nil)
+		w := new(big.Rat).Sub(big.NewRat(1, 1), x)
+		w.Quo(x, w)
+
+		// row m = -n+1 of the Stirling numbers, S(m, j) for
+		// j = 0, ..., m
+		m := int(1 - n)
+		st := make([]*big.Int, m+1)
+		st[0] = big.NewInt(1)
+		for i := 1; i <= m; i++ {
+			st[i] = new(big.Int)
+			for j := i; j >= 1; j-- {
+				st[j].Mul(st[j], big.NewInt(int64(j)))
+				st[j].Add(st[j], st[j-1])
+			}
+			st[0].SetInt64(0)
+		}
+
+		// Horner's method, in w
+		r := new(big.Rat)
+		c := new(big.Rat)
+		f := new(big.Int)
+		for j := m; j >= 1; j-- {
+			f.MulRange(1, int64(j-1))
+			r.Add(r, c.SetInt(f.Mul(f, st[j])))
+			r.Mul(r, w)
+		}
+		return new(big.Float).SetPrec(prec).SetRat(r)
+	}
+
+	return polylog(s, z, prec+64).SetPrec(prec)
+}
+
+// polylog returns Li_s(z), or its real part when z > 1, computed to
+// prec bits of precision, for finite s and z, with z != 0, 1.
+func polylog(s, z *big.Float, prec uint) *big.Float {
+
+	one := big.NewFloat(1)
+	a := new(big.Float).Abs(z)
+	sf, _ := s.Float64()
+	zf, _ := z.Float64()
+	large := sf > float64(prec)/math.Log2(float64(prec)) && a.Cmp(one) < 0
+
+	var f func(wprec uint) (*big.Float, int)
+	switch {
+	// Li_1(z) = -log(1-z), and Re Li_1(z) = -log(z-1) for z > 1
+	case s.Cmp(one) == 0 && a.Cmp(big.NewFloat(0.5)) > 0:
+		y := new(big.Float).SetPrec(exactSumPrec(z, one)).Sub(one, z)
+		x := polylogLog(y.Abs(y), prec)
+		return x.Neg(x)
+
+	// For |z| > 1 we use the inversion formula when s is an integer,
+	// or when z is large enough that the expansion in log(z) below
+	// converges slowly.
+	case a.Cmp(one) > 0 && s.IsInt() || zf > math.Exp(math.Pi):
+		f = func(wprec uint) (*big.Float, int) {
+			return polylogInversion(s, z, wprec)
+		}
+
+	// Li_s(-1) = -η(s)
+	case z.Cmp(big.NewFloat(-1)) == 0:
+		x := Eta(new(big.Float).SetPrec(prec).Set(s))
+		return x.Neg(x)
+
+	// For |z| <= 1/2 the series converges quickly, and so it does
+	// for large s, since its terms are smaller than 1/k^s.
+	case a.Cmp(big.NewFloat(0.5)) <= 0 || large:
+		f = func(wprec uint) (*big.Float, int) {
+			return polylogSeries(s, z, wprec)
+		}
+
+	// For z < -1/2 we use the duplication formula
+	//     Li_s(z) = 2^(1-s)·Li_s(z²) - Li_s(-z)
+	// which, when z < -1, holds for the real parts of the two terms
+	// on the right.
+	case z.Sign() < 0:
+		f = func(wprec uint) (*big.Float, int) {
+			z2 := new(big.Float).SetPrec(2*z.MinPrec()).Mul(z, z)
+			x := polylog(s, z2, wprec)
+			w := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(one, s)
+			x.Mul(x, zetaPow(big.NewFloat(2), w, wprec))
+			y := polylog(s, new(big.Float).Neg(z), wprec)
+			scale := x.MantExp(nil)
+			if e := y.MantExp(nil); e > scale {
+				scale = e
+			}
+			return x.Sub(x, y), scale
+		}
+
+	// For 1/2 < z < 1 and s = 2 we use the reflection formula
+	//     Li₂(z) = π²/6 - log(z)·log(1-z) - Li₂(1-z)
+	case s.Cmp(big.NewFloat(2)) == 0:
+		f = func(wprec uint) (*big.Float, int) {
+			y := new(big.Float).SetPrec(exactSumPrec(z, one)).Sub(one, z)
+			x := zeta(s, wprec)
+			t := polylogLog(z, wprec)
+			t.Mul(t, polylogLog(y, wprec))
+			x.Sub(x, t)
+			scale := x.MantExp(nil)
+			t, _ = polylogSeries(s, y, wprec)
+			return x.Sub(x, t), scale
+		}
+
+	default:
+		f = func(wprec uint) (*big.Float, int) {
+			return polylogLogSeries(s, z, wprec)
+		}
+	}
+
+	guard := uint(0)
+	for {
+		x, scale := f(prec + guard)
+		if lost := lostBits(x, scale, prec+guard); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return x.SetPrec(prec)
+	}
+}
+
+// polylogLog returns log(x) computed to prec bits of precision. x is
+// not rounded to prec bits first, so that the result has full
+// relative precision even when x is close to 1.
+func polylogLog(x *big.Float, prec uint) *big.Float {
+	p := prec
+	if x.MinPrec() > p {
+		p = x.MinPrec()
+	}
+	return Log(new(big.Float).SetPrec(p).Set(x)).SetPrec(prec)
+}
+
+// polylogPow returns k^(-s) computed to prec bits of precision.
+func polylogPow(k int, s *big.Float, prec uint) *big.Float {
+
+	// For small integers s we compute k^|s| exactly.
+	if s.IsInt() && s.MantExp(nil) <= 16 {
+		n, _ := s.Int64()
+		p := new(big.Int).Exp(big.NewInt(int64(k)), big.NewInt(int64(absInt(int(n)))), nil)
+		x := new(big.Float).SetPrec(prec).SetInt(p)
+		if n > 0 {
+			x.Quo(big.NewFloat(1), x)
+		}
+		return x
+	}
+
+	return zetaPow(big.NewFloat(float64(k)), new(big.Float).Neg(s), prec)
+}
+
+// polylogSeries returns Σ z^k/k^s, for k >= 1, computed to prec bits
+// of precision, and the binary exponent of its largest term. |z| must
+// be less than 1, or s larger than 1.
+func polylogSeries(s, z *big.Float, prec uint) (*big.Float, int) {
+
+	// For s < 0 the terms increase as long as |z|·(1+1/k)^(-s) > 1,
+	// which stops happening when k > -s/log(1/|z|).
+	kmin := 0
+	if s.Sign() < 0 {
+		sf, _ := s.Float64()
+		l := math.Log(2) * float64(-z.MantExp(nil)+1)
+		if zf, _ := z.Float64(); zf != 0 {
+			l = -math.Log(math.Abs(zf))
+		}
+		kmin = int(-sf/l) + 1
+	}
+
+	// When s is not a small integer k^(-s) is expensive, but it is
+	// completely multiplicative, so we only need Pow for prime k;
+	// pw[k] holds k^(-s).
+	pw := []*big.Float{nil, big.NewFloat(1)}
+
+	zk := new(big.Float).SetPrec(prec).Set(z) // z^k
+	r := new(big.Float).SetPrec(prec).Set(z)
+	scale := r.MantExp(nil)
+	t := new(big.Float).SetPrec(prec)
+	for k := 2; ; k++ {
+		if p := smallestFactor(k); p < k {
+			pw = append(pw, new(big.Float).SetPrec(prec).Mul(pw[p], pw[k/p]))
+		} else {
+			pw = append(pw, polylogPow(k, s, prec))
+		}
+
+		zk.Mul(zk, z)
+		t.Mul(zk, pw[k])
+		r.Add(r, t)
+		if t.Sign() == 0 {
+			return r, scale
+		}
+		if e := t.MantExp(nil); e > scale {
+			scale = e
+		}
+		if k > kmin && t.MantExp(nil)-r.MantExp(nil) < -int(prec) {
+			return r, scale
+		}
+	}
+}
+
+// polylogLogSeries returns Li_s(z), for 0 < z < 1, or Re Li_s(z),
+// for 1 < z < e^(2π), computed to prec bits of precision using its
+// expansion in powers of log(z), and the binary exponent of the
+// largest quantity that was involved in the computation. s must not
+// be an integer <= 1.
+func polylogLogSeries(s, z *big.Float, prec uint) (*big.Float, int) {
+
+	// For |μ| < 2π, where μ = log(z),
+	//     Li_s(z) = Γ(1-s)·(-μ)^(s-1) + Σ ζ(s-k)·μ^k/k!
+	// for k >= 0, and for s = n a positive integer the first term
+	// and the one for k = n-1 are replaced by
+	//     μ^(n-1)/(n-1)! · (H_(n-1) - log(-μ))
+	// where H_(n-1) is the (n-1)th harmonic number. When z > 1, μ > 0
+	// and only the real parts of (-μ)^(s-1) = μ^(s-1)·e^(±iπ(s-1))
+	// and of log(-μ) = log(μ) ± iπ contribute to Re Li_s(z).
+	one := big.NewFloat(1)
+	mu := polylogLog(z, prec)
+	sf, _ := s.Float64()
+	harmonic := s.IsInt() && s.Sign() > 0
+
+	// The terms with s-k > 1 for k < kp, with kp = ceil(s-1).
+	kp := 0
+	if s.Cmp(one) > 0 {
+		c := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(s, one)
+		n, _ := c.Int64()
+		kp = int(n)
+		if !c.IsInt() {
+			kp++
+		}
+	}
+	var zp []*big.Float // zp[k] = ζ(s-k)
+	if kp > 0 {
+		sigma := new(big.Float).SetPrec(exactSumPrec(s, big.NewFloat(float64(kp)))).Sub(s, big.NewFloat(float64(kp-1)))
+		next := zetaSeq(sigma, prec)
+		zp = make([]*big.Float, kp)
+		for k := kp - 1; k >= 0; k-- {
+			zp[k] = next()
+		}
+	}
+	mid := new(big.Float).SetPrec(exactSumPrec(s, big.NewFloat(float64(kp)))).Sub(s, big.NewFloat(float64(kp)))
+
+	r := new(big.Float).SetPrec(prec)
+	scale := math.MinInt32
+	add := func(t *big.Float) {
+		r.Add(r, t)
+		if t.Sign() != 0 && t.MantExp(nil) > scale {
+			scale = t.MantExp(nil)
+		}
+	}
+
+	if !harmonic {
+		w := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(one, s)
+		t := Gamma(new(big.Float).SetPrec(prec).Set(w))
+		v := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(s, one)
+		t.Mul(t, zetaPow(new(big.Float).Abs(mu), v, prec))
+		if mu.Sign() > 0 {
+			_, cos := sincosPi(new(big.Float).SetPrec(prec).Set(v))
+			t.Mul(t, cos)
+		}
+		add(t)
+	}
+
+	// For s-k < 0 and s not an integer we use the functional
+	// equation
+	//     ζ(s-k) = 2(2π)^(s-k-1)·sin(π(s-k)/2)·Γ(1-s+k)·ζ(1-s+k)
+	// where we update g = 2(2π)^(s-k-1)·Γ(1-s+k) at every step, and
+	// the sine takes the values sin(πs/2), -cos(πs/2), -sin(πs/2)
+	// and cos(πs/2).
+	var g, sin, cos, w *big.Float
+	var next func() *big.Float
+	twoPi := new(big.Float).SetMantExp(pi(prec), 1)
+
+	fk := big.NewFloat(1).SetPrec(prec) // μ^k/k!
+	t := new(big.Float).SetPrec(prec)
+	d := new(big.Float)
+	for k := 0; ; k++ {
+		if k > 0 {
+			fk.Mul(fk, mu)
+			fk.Quo(fk, d.SetInt64(int64(k)))
+		}
+
+		switch {
+		case k < kp:
+			t.Mul(zp[k], fk)
+
+		case k == kp && harmonic:
+			h := new(big.Rat)
+			for j := int64(1); j < int64(kp+1); j++ {
+				h.Add(h, big.NewRat(1, j))
+			}
+			t.SetRat(h)
+			t.Sub(t, polylogLog(new(big.Float).Abs(mu), prec))
+			t.Mul(t, fk)
+
+		case harmonic:
+			t.Mul(zeta(new(big.Float).SetInt64(int64(kp+1-k)), prec), fk)
+
+		case k == kp && mid.Sign() > 0:
+			t.Mul(zeta(mid, prec), fk)
+
+		default:
+			if g == nil {
+				// first term with s-k < 0
+				w = new(big.Float).SetPrec(exactSumPrec(s, big.NewFloat(float64(k+1)))).Sub(big.NewFloat(float64(k+1)), s)
+				next = zetaSeq(new(big.Float).Set(w), prec)
+				w.SetPrec(prec)
+				g = Gamma(new(big.Float).Set(w))
+				v := new(big.Float).SetPrec(exactSumPrec(s, big.NewFloat(float64(k+1)))).Sub(s, big.NewFloat(float64(k+1)))
+				g.Mul(g, zetaPow(twoPi, v, prec))
+				g.SetMantExp(g, 1)
+				h := new(big.Float).SetPrec(prec).Set(s)
+				sin, cos = sincosPi(h.SetMantExp(h, -1))
+			} else {
+				g.Mul(g, w)
+				g.Quo(g, twoPi)
+				w.Add(w, one)
+			}
+			switch k % 4 {
+			case 0:
+				t.Set(sin)
+			case 1:
+				t.Neg(cos)
+			case 2:
+				t.Neg(sin)
+			case 3:
+				t.Set(cos)
+			}
+			t.Mul(t, g)
+			t.Mul(t, next())
+			t.Mul(t, fk)
+		}
+		add(t)
+
+		// The terms for s-k < 0 decrease as |μ|/2π, after k > -s.
+		if k > kp+1 && float64(k) > -sf && t.Sign() != 0 &&
+			t.MantExp(nil)-r.MantExp(nil) < -int(prec) {
+			break
+		}
+	}
+
+	return r, scale
+}
+
+// polylogInversion returns Li_s(z), for an integer s and z < -1, or
+// Re Li_s(z), for z > 1, computed to prec bits of precision using the
+// inversion formula, and the binary exponent of the largest quantity
+// that was involved in the computation.
+func polylogInversion(s, z *big.Float, prec uint) (*big.Float, int) {
+
+	y := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), z)
+	u := polylogLog(new(big.Float).Abs(z), prec)
+
+	if !s.IsInt() {
+		return polylogInversionHurwitz(s, y, u, prec)
+	}
+
+	// For z < -1, with u = log(-z),
+	//     Li_n(z) + (-1)^n Li_n(1/z) = -Σ 2η(2j)·u^(n-2j)/(n-2j)!
+	// for j = 0, ..., n/2, where η(0) = 1/2. For z > 1, with
+	// u = log(z), the real part of the right side is
+	//     -u^n/n! + Σ 2ζ(2j)·u^(n-2j)/(n-2j)!
+	// for j = 1, ..., n/2. The sums are empty for n < 0.
+	n64, _ := s.Int64()
+	n := int(n64)
+
+	r := polylog(s, y, prec)
+	if n%2 == 0 {
+		r.Neg(r)
+	}
+	scale := r.MantExp(nil)
+	if n < 0 {
+		return r, scale
+	}
+
+	// p[j] = u^j/j!
+	p := make([]*big.Float, n+1)
+	p[0] = big.NewFloat(1).SetPrec(prec)
+	for j := 1; j <= n; j++ {
+		p[j] = new(big.Float).Mul(p[j-1], u)
+		p[j].Quo(p[j], big.NewFloat(float64(j)))
+	}
+
+	t := new(big.Float).SetPrec(prec)
+	for j := 0; 2*j <= n; j++ {
+		switch {
+		case j == 0:
+			t.Set(p[n])
+		case z.Sign() > 0:
+			t.Mul(zeta(new(big.Float).SetInt64(int64(2*j)), prec), p[n-2*j])
+			t.SetMantExp(t, 1)
+			t.Neg(t)
+		default:
+			// 2η(2j) = (2 - 2^(2-2j))·ζ(2j)
+			e := new(big.Float).SetMantExp(big.NewFloat(1), 2-2*j)
+			t.Sub(big.NewFloat(2), e)
+			t.Mul(t, zeta(new(big.Float).SetInt64(int64(2*j)), prec))
+			t.Mul(t, p[n-2*j])
+		}
+		r.Sub(r, t)
+		if e := t.MantExp(nil); t.Sign() != 0 && e > scale {
+			scale = e
+		}
+	}
+
+	return r, scale
+}
+
+// polylogInversionHurwitz returns Re Li_s(z), for s not an integer
+// and z > 1, given y = 1/z and u = log(z), computed to prec bits of
+// precision, and the binary exponent of the largest quantity that
+// was involved in the computation.
+func polylogInversionHurwitz(s, y, u *big.Float, prec uint) (*big.Float, int) {
+
+	// On the upper side of the branch cut
+	//     Li_s(z) + e^(iπs)·Li_s(1/z) = (2π)^s/Γ(s)·e^(iπs/2)·ζ(1-s, a)
+	// where a = -iu/2π and ζ(s, a) is the Hurwitz zeta function,
+	// and Li_s(1/z) is real.
+	one := big.NewFloat(1)
+	twoPi := new(big.Float).SetMantExp(pi(prec), 1)
+	w := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(one, s)
+	a := NewComplex(new(big.Float), new(big.Float).SetPrec(prec).Quo(u, twoPi))
+	a.im.Neg(&a.im)
+	h, scale := hurwitzZetaComplex(w, a, prec)
+
+	// c = (2π)^s/Γ(s)
+	c := zetaPow(twoPi, s, prec)
+	c.Quo(c, Gamma(new(big.Float).SetPrec(prec).Set(s)))
+	scale += c.MantExp(nil)
+
+	// Re(e^(iπs/2)·ζ(1-s, a)) = cos(πs/2)·Re ζ(1-s, a) - sin(πs/2)·Im ζ(1-s, a)
+	hs := new(big.Float).SetPrec(prec).Set(s)
+	sin, cos := sincosPi(hs.SetMantExp(hs, -1))
+	x := new(big.Float).SetPrec(prec).Mul(cos, &h.re)
+	t := new(big.Float).SetPrec(prec).Mul(sin, &h.im)
+	x.Sub(x, t)
+	x.Mul(x, c)
+
+	_, cos = sincosPi(new(big.Float).SetPrec(prec).Set(s))
+	t.Mul(cos, polylog(s, y, prec))
+	if e := t.MantExp(nil); t.Sign() != 0 && e > scale {
+		scale = e
+	}
+	return x.Sub(x, t), scale
+}
+
+// hurwitzZetaComplex returns ζ(σ, a) = Σ (a+k)^(-σ), for k >= 0, for
+// real σ != 1 and complex a with Im(a) != 0, computed to prec bits of
+// precision using the Euler-Maclaurin formula as in hurwitzZetaEM,
+// and the binary exponent of the largest quantity that was involved
+// in the computation.
+func hurwitzZetaComplex(sigma *big.Float, a *Complex, prec uint) (*Complex, int) {
+
+	sf, _ := sigma.Float64()
+	n := int(math.Abs(sf)/4+0.15*float64(prec)) + 1
+
+	one := big.NewFloat(1)
+	ns := NewComplex(new(big.Float).Neg(sigma), new(big.Float))
+	s1 := new(big.Float).SetPrec(exactSumPrec(sigma, one)).Sub(sigma, one)
+
+	// exp returns the binary exponent of the largest part of t.
+	exp := func(t *Complex) int {
+		e := math.MinInt32
+		if t.re.Sign() != 0 {
+			e = t.re.MantExp(nil)
+		}
+		if t.im.Sign() != 0 && t.im.MantExp(nil) > e {
+			e = t.im.MantExp(nil)
+		}
+		return e
+	}
+	r := new(Complex).SetPrec(prec)
+	scale := math.MinInt32
+	add := func(t *Complex) {
+		r.Add(r, t)
+		if e := exp(t); e > scale {
+			scale = e
+		}
+	}
+
+	x := new(Complex).SetPrec(prec).Set(a)
+	for k := 0; k < n; k++ {
+		add(PowComplex(x, ns))
+		x.re.Add(&x.re, one)
+	}
+
+	// x^(1-σ)/(σ-1) + 1/(2x^σ), with x = a+n
+	p := PowComplex(x, ns)
+	t := new(Complex).SetPrec(prec).Mul(p, x)
+	t.re.Quo(&t.re, s1)
+	t.im.Quo(&t.im, s1)
+	add(t)
+	t.re.SetMantExp(&p.re, -1)
+	t.im.SetMantExp(&p.im, -1)
+	add(t)
+
+	// Σ B_2j/(2j)!·q, for j >= 1, with
+	//     q = σ(σ+1)...(σ+2j-2)/x^(σ+2j-1)
+	q := new(Complex).SetPrec(prec).Quo(p, x)
+	q.re.Mul(&q.re, sigma)
+	q.im.Mul(&q.im, sigma)
+	x2 := new(Complex).SetPrec(prec).Mul(x, x)
+	b := new(big.Float).SetPrec(prec)
+	d := new(big.Float).SetPrec(prec)
+	f := big.NewInt(2) // (2j)!
+	for j := int64(1); ; j++ {
+		b.SetRat(bernoulli(int(2 * j)))
+		b.Quo(b, d.SetInt(f))
+		t.re.Mul(&q.re, b)
+		t.im.Mul(&q.im, b)
+		add(t)
+		if e := exp(t); e == math.MinInt32 || e-exp(r) < -int(prec) {
+			break
+		}
+
+		for _, k := range []int64{2*j - 1, 2 * j} {
+			d.Add(sigma, d.SetInt64(k))
+			q.re.Mul(&q.re, d)
+			q.im.Mul(&q.im, d)
+		}
+		q.Quo(q, x2)
+		f.Mul(f, big.NewInt((2*j+1)*(2*j+2)))
+	}
+
+	return r, scale
+}
+
+// zetaSeq returns a function that returns ζ(σ), ζ(σ+1), ζ(σ+2), ...
+// on successive calls, computed to prec bits of precision, for σ > 1.
+func zetaSeq(sigma *big.Float, prec uint) func() *big.Float {
+
+	// We sum the first n terms of ζ(σ) = Σ 1/k^σ, and use the
+	// Euler-Maclaurin formula for the rest, as in hurwitzZetaEM,
+	// dividing the terms by k at every step. Once 2^(-σ) is
+	// negligible, ζ(σ) = 1.
+	n := int(0.15*float64(prec)) + 2
+	one := big.NewFloat(1)
+	pw := make([]*big.Float, n+1) // pw[k] = k^(-σ)
+	pw[1] = big.NewFloat(1).SetPrec(prec)
+	for k := 2; k <= n; k++ {
+		if p := smallestFactor(k); p < k {
+			pw[k] = new(big.Float).SetPrec(prec).Mul(pw[p], pw[k/p])
+		} else {
+			pw[k] = polylogPow(k, sigma, prec)
+		}
+	}
+
+	x := new(big.Float).SetInt64(int64(n))
+	x2 := new(big.Float).SetInt64(int64(n) * int64(n))
+	j := 0
+	return func() *big.Float {
+		r := new(big.Float).SetPrec(prec)
+		if pw[2].MantExp(nil) < -int(prec)-1 {
+			return r.SetInt64(1)
+		}
+
+		jf := big.NewFloat(float64(j))
+		s := new(big.Float).SetPrec(exactSumPrec(sigma, jf)).Add(sigma, jf)
+		s1 := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(s, one)
+
+		for k := 1; k < n; k++ {
+			r.Add(r, pw[k])
+		}
+
+		// n^(1-σ)/(σ-1) + 1/(2n^σ)
+		t := new(big.Float).SetPrec(prec).Mul(pw[n], x)
+		t.Quo(t, s1)
+		r.Add(r, t)
+		t.SetMantExp(pw[n], -1)
+		r.Add(r, t)
+
+		// q = σ(σ+1)...(σ+2i-2) / n^(σ+2i-1)
+		q := new(big.Float).SetPrec(prec).Mul(s, pw[n])
+		q.Quo(q, x)
+		b := new(big.Float).SetPrec(prec)
+		f := big.NewInt(2) // (2i)!
+		for i := int64(1); ; i++ {
+			t.Mul(q, b.SetRat(bernoulli(int(2*i))))
+			t.Quo(t, b.SetInt(f))
+			r.Add(r, t)
+			if t.Sign() == 0 || t.MantExp(nil)-r.MantExp(nil) < -int(prec) {
+				break
+			}
+
+			q.Mul(q, b.Add(s, b.SetInt64(2*i-1)))
+			q.Mul(q, b.Add(s, b.SetInt64(2*i)))
+			q.Quo(q, x2)
+			f.Mul(f, big.NewInt((2*i+1)*(2*i+2)))
+		}
+
+		j++
+		for k := 2; k <= n; k++ {
+			pw[k].Quo(pw[k], b.SetInt64(int64(k)))
+		}
+		return r
+	}
+}
@@ -0,0 +1,182 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestPolylog(t *testing.T) {
+	for _, test := range []struct {
+		s, z string
+		want string
+	}{
+		{"2", "0.5", "5.82240526465012505902656320159680108744198474806126425434347047873171044071683200816840318587915857185644360650489146599186798136823369642378773825725010992996274322284433100379999291599248198351965163954430360855235304444007069408957988252404842589518848317761401903901033357181992883206902177502171079787480118923160603600142231361205647643339999513925265283e-1"},
+		{"3", "0.25", "2.58461395796573305288000129873672612021625353527988047475840814150857299515640121787129965824915017036336110382591335642684753700002186881754091465947982632698721880203127561205362272448909897755171835838359628998090629354179180606599191532362070193585347438845933698493146966614897920769230720624192787854004422662614554478484738152052203129085152617849401630e-1"},
+		{"2.5", "0.75", "8.94996620258864297930721687824311101639306476967425691589105799069803421131503261720779573358414310236581406871961627176245771934502444100558192655561704675930870180183736850335190283463281750062645065030138397783516175922867646690914671385534722437480283932567773642139733160587647850209173490649611329284963591286397451920623582041263156225213019538817048965e-1"},
+		{"0.5", "0.375", "5.19830934264148986212326627239612632717432916030462833934509700168633918312589674518909814700759215370281361016070004856679480190345179669301165936032303446676747136569559089330900744814411737323185616638313926284721634885306949954061719985265565675322412376744051023789000456289867507455501328946018533755289318071821895433125027971955961220728082685580401074e-1"},
+		{"-1.5", "0.75", "2.99193205718472799382858976160816654066614249441921304473598397672294335834168471333064612847641086315908575807361713488777566993485255787681674072436262466285896459032917311623942067988649563741813959081255131796752769114686625473145508737583663736719528516969144323846143900186549598792339193045928136151242501823821847400530966546993449119227892537044136479e+1"},
+		{"1.25", "-0.875", "-6.59849436130843480390275963133737918961159435206452227736646850477005201445956455895354872497360903747624517188367432225787950665629403333074506914414038225690767377187129397261085127101390500276496045184759110748561910825303533838931067609650926595555345657622214707433903105410988934860094972689918467256272174111481101299341407812310720078831107248049058622e-1"},
+		{"4", "-0.5", "-4.85714537830606444573298947754672872754896497071695012759556070034897766334152865586458473804893888471606767144395431848017379482388575333661397988439879519543383218828776264639177724066981589263566231983275738393249166262025461240759475902959525267476294026101321049246399116780101849783517187766266373647728500157582394205449768906515094946188545705323989135e-1"},
+		{"5", "0.9375", "9.69509424336712125232432350266262911722108597168321069722324930244567863036911143041421164589871035651025283353562145044947077664798002430707676546194839683269262206476147868791415649693161903859752814742032307242082954948478844266759739718520205990948128025623600136804435974385119805412523866553617314990000582716654238224898541783973037112852571283349601059e-1"},
+		{"-2.5", "-0.25", "-5.39279271329838530837081471047389888864304120759436536058446343180183364107844947282479927877173153113951902922368546962352411687389245696955026317579744643177166857207661218220135505673888050610609831759249061010835838673248122869942216561066248412362518430744539456700678418768907563660152767176821646026095708044572271035863231398652431311739322234097109245e-2"},
+		{"0.5", "1p-100", "7.88860905221011805411728565283226263303446745166214716012982225294666294471676988769545554257736979944144690590061482789464087755872369820855314421689282297406405990264649274194329640939168055851918674775100896281093473227793062077238493083048605051069816406215155938206393031607975579318718570241597824642186122218917219850635638112984799199334013250063512144e-31"},
+		{"1", "0.75", "1.38629436111989061883446424291635313615100026872051050824136001898678724393938943121172665399283737508400296204114137146737104047151626111406534150327015192386145514165674287038061407724778334694224670023072899591047824095034536314986413031104946827905176590090601419065273328530820847831562990408748086077100160388834128334303728942567993634356909391405254326e+0"},
+		{"-3", "0.625", "1.22962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962962963e+2"},
+		{"-4", "-7.5", "-1.95019639301704326562463684723179869522071588899445507540548097449250170967921417438516695695411580180257589320614681619346173593537940792629116875854399421913615244352072074863877136922943648550523045630651537443559457043913577212353074992763355746388544761902078871322957171039055341488614698522456838963360394743977738603253707943828146073865185015110676639e-2"},
+		{"3", "-10.5", "-6.12877115903518879100763358128298585885073383866378286297921991789153574418161431377461544140298119309448810743153646643245720292300305884390643558884182113426185733274705322952126017726413782630164314370257004086225579914374429256287541242320423388097577875987493459313956860466722881524926634081135389363994604962342213218092864589542168440660452062822913494e+0"},
+		{"7", "-1000", "-4.82182927173959392319444025739247158009905597601634788438004106905380108473786695343618641365654822767340549034579019145209587240730741682269279147509100735510583570634440520022591240465486679576526558301149435848267010736581758204967955864991550679286189295696174666572292138615770800512694671868783911144677940202009872219367702560201617131789487934022411299e+2"},
+		{"0.25", "0.875", "4.75196182455558238833398916187694607358769983736803951326859846134170200216323621453061285102785366364629704728484753165891277695545883511404114206974908128586790166434039362798654040762019904026731076612984383724234072662536693106760583233518704446746536105951325714851378331725031136539227882289870587704434639151756990903375254529759000220805223379159312076e+0"},
+		{"10.5", "0.96875", "9.69407405185872533033242975453948267794583980165491768812012048427947049424829747943185342122690922032034376025959186722642652589597290031124835961113211358945586506055333667499744793930581830608502175573609590314252661909131298681463992188461372807381097291106750263237692864290151441386510609898556224738128160461041934192499572650580106899652468323539795757e-1"},
+		{"-10.75", "0.5", "1.61226328598273472011205467784065709561128659655532561425577684867257961191773442011429219096491050977543203310326443683966385279655972543385824387782035167239678731030876648407214793820860951452136775701430533680157771659360293360570056677019859342716772011157853284874721577010695618366313297647440729795901098275276125009756103589668149047976571056271644485e+9"},
+		{"0.5", "-2", "-8.91288711552123301981519555508280876851383519759054256782459299447288673497711279030816962662131857392264856415825781714401323286944475481825183621492150931652371322105880675031280393953426618436264311123782744886711593492850232794230912499207229129258116365323644631883920264842141069698045003476286103906954689560652458743515007775923339851106498231551795320e-1"},
+		{"2.5", "-5", "-3.17005576844848008350618595936010988063712516678842190263682332135348672957497392049351216914230346888411538022179237892597673325973920360735187749702279339910055928341448949365616611320001137065761740400991060198060568608285725137984481313327450575243496181223572419550574358071680432175754250383339757398520927896538144153931327620031490998613924576370055124e+0"},
+		{"2.5", "10", "3.03781363281076146143536314813081115417765256717994235598434297769919721580682367206846693866024518691836063599199688127809331275071187040237003991820122239034883511913149281139357298103662536548360081741654335461640787420628631614173522784079700405383607865542915385028580221243222442917586950020790972409551779634788759922959207158626808441530181523430427004e+0"},
+		{"0.5", "100", "-2.52744051913150819227927260351462809001608040450034781397977139261546208613409177213622619959170672137179742939470134928774914514379382078375806534912300978937730272277463287866618819165118226334090455867377344540193119717306663185212963034730892838576949316242591741506161048110327891115522053316152418109594281980287925628009149070836412256605151075625271820e+0"},
+		{"-1.5", "-40", "4.95764870842260923935625600959448252413461508498749644470176746958537924004188317551812570545257873433628258039397359375025389415285807807423418252080785394241593622394632898125806861806485642537057972460618416627987189922146587281739349328407507500999905834447692471185679585240586350367720465977838906061644614665976922639609894642451762547701747773937681410e-2"},
+		{"3", "7", "5.31925799214567543816125183061160197617392809805756339623282647100436558191071893529137839279702844567534010062516504231206408389046810939354052871166645187169244716155229434472911162706701440936405656243223259757896630137963767607324005557829703607473375753932024285039616587179196251888061532748806921936644164455263917284814313292333588194048546087186975199e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			s := new(big.Float).SetPrec(prec)
+			s.Parse(test.s, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Polylog(s, z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Polylog(%v, %v) =\ngot  %g;\nwant %g", prec, test.s, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestDilog(t *testing.T) {
+	for _, test := range []struct {
+		z    string
+		want string
+	}{
+		{"0.5", "5.82240526465012505902656320159680108744198474806126425434347047873171044071683200816840318587915857185644360650489146599186798136823369642378773825725010992996274322284433100379999291599248198351965163954430360855235304444007069408957988252404842589518848317761401903901033357181992883206902177502171079787480118923160603600142231361205647643339999513925265283e-1"},
+		{"-1", "-8.22467033424113218236207583323012594609474950603399218867779114685003735201600436916814450309879352652002159481168595339814362343502503893967551473165433138415866654683881302547625503436070027398405779397445180411638880959920378227938481617818354855048474451042966004025818239439169423022222592029912572625341693815711382939696479403160223609895423867045529510e-1"},
+		{"0.9990234375", "1.63718494305424718437681147734449293208953157478621121415727304801367818070168999764921891066930253948771655868453114728230306027362379605217161515242577118781860856232863755634087734151918223504012897624411592754567978274020652138431739113561755144773809837270559009481963182845254399687834093357364034539206416483468089134066278021351156153924885148534802138e+0"},
+		{"-2", "-1.43674636688368094636290202389358335424995643565487210266724392486501578927739779754373786715506889010133317282830842801199906899086319644631729897014085666489898014968158449261030235223925755231061811036729332956481166662176530373843434881136483439347405544329141892234469377112595462663232717782475671187853181814591509627442476705757860756016768949536275547e+0"},
+		{"1p-20", "9.53674543780021816810201454548476512572002689400430536492445664553768790255880745314736811794270100694557744172469582510977392478320802085668145230007341762751225662404927190974702903393845449695082032652523327973935669627323474023659801664563579495008807604367776883858608364378619435862069313900710105481827693578939541589102201767678546624636559145716855642e-7"},
+		{"-100.5", "-1.22617855973123652419280338392302670907361088815466465236836551037734479037935818672849060082050822983667425607973837201392501275826613357268908133072066671856479724620850917504109581369395804896894640538220599994625790808002714677925186681120560778427601066875868878381535612845272023642575918488045918868605530783889396463316138034219973518406955783009931218e+1"},
+		{"0.25", "2.67652639082732606919183828487811575819857066913854593865201353112693343631925776853950306802525093033230831371561870381859156468004848098344384404189158471287857660397613571454848850259087733638662200167637020080731265260036615745069760925438910014732657451370649436138611534344864247958927513478722307154933949090204309311239583141018464034322833844153395013e-1"},
+		{"0.75", "9.78469392930306103743066666524561497761484274619487252104829199500641763792614807189146470101704316950603065960245165331427676929556748921766672127966406992423269217454169054303771932237918719724168487274108129318131944220160815134730311834304139307664268477883864558224799843335168699837543724503791711707177216841596602596046572553499238767409750797216274781e-1"},
+		{"-0.75", "-6.42761268839978879105290401047091623324687320033291031122920746621521248626554686254030402069207480099979029957935716942509083393837899185069646849631523441776606671304675572713534183175056813549092295489671183840448834901172579406790550939265072632615112580105256434742429643508078241143502160258581428785463234423779589614929148446275083946699002392853236201e-1"},
+		{"2", "2.46740110027233965470862274996903778382842485181019765660333734405501120560480131075044335092963805795600647844350578601944308703050751168190265441949629941524759996405164390764287651030821008219521733819233554123491664287976113468381544485345506456514542335312889801207745471831750826906666777608973771787602508144713414881908943820948067082968627160113658853e+0"}, // π²/4
+		{"12.5", "1.85559536582006457668500388426334715760600889116524821838651181858711304254345756585671412333412072628866218111393122337445328514758931348847361149549118947677673999992071523437089759890200429409631233160770827081206566882206972079134706694929909535202519453179325929161921485527594719233853249613563066865760962668244486655667197420239961083468946828788954039e-2"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			x := bigfloat.Dilog(z)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Dilog(%v) =\ngot  %g;\nwant %g", prec, test.z, x, want)
+			}
+		}
+	}
+}
+
+func TestPolylogDuplication(t *testing.T) {
+	// Li_s(z) + Li_s(-z) = 2^(1-s)·Li_s(z²)
+	const prec = 200
+	for _, test := range []struct{ s, z float64 }{
+		{2, 0.75}, {3, 0.875}, {0.5, 0.625}, {-1.5, 0.96875}, {2.75, 0.5}, {7.25, 0.9375},
+	} {
+		s := big.NewFloat(test.s)
+		z := big.NewFloat(test.z).SetPrec(prec)
+		z2 := new(big.Float).Mul(z, z)
+
+		x := bigfloat.Polylog(s, z)
+		x.Add(x, bigfloat.Polylog(s, new(big.Float).Neg(z)))
+
+		w := new(big.Float).SetPrec(prec).Sub(big.NewFloat(1), s)
+		want := bigfloat.Pow(big.NewFloat(2).SetPrec(prec), w)
+		want.Mul(want, bigfloat.Polylog(s, z2))
+
+		if d := x.Sub(x, want); d.Sign() != 0 && d.MantExp(nil)-want.MantExp(nil) > -prec+8 {
+			t.Errorf("Li(%v, %v) + Li(%v, -%v) is off by %g", test.s, test.z, test.s, test.z, d)
+		}
+	}
+}
+
+func TestPolylogFloat64(t *testing.T) {
+	for _, s := range []float64{-2.5, -1, 0.5, 1, 2, 3.25, 10} {
+		for _, z := range []float64{-0.875, -0.5, -0.125, 0.25, 0.5, 0.75} {
+			want := 0.0
+			for k := 1; k < 1000; k++ {
+				want += math.Pow(z, float64(k)) / math.Pow(float64(k), s)
+			}
+
+			x, _ := bigfloat.Polylog(big.NewFloat(s), big.NewFloat(z)).Float64()
+			if math.Abs(x-want) > 1e-13*math.Abs(want) {
+				t.Errorf("Polylog(%g, %g) = %g; want %g", s, z, x, want)
+			}
+		}
+	}
+}
+
+func TestPolylogSpecialValues(t *testing.T) {
+	zero, one := big.NewFloat(0), big.NewFloat(1)
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"Polylog(2, +0)", bigfloat.Polylog(big.NewFloat(2), zero), 0},
+		{"Polylog(2.5, -0)", bigfloat.Polylog(big.NewFloat(2.5), big.NewFloat(math.Copysign(0, -1))), math.Copysign(0, -1)},
+		{"Polylog(1, 1)", bigfloat.Polylog(big.NewFloat(1), one), math.Inf(+1)},
+		{"Polylog(0.5, 1)", bigfloat.Polylog(big.NewFloat(0.5), one), math.Inf(+1)},
+		{"Polylog(-2, 1)", bigfloat.Polylog(big.NewFloat(-2), one), math.Inf(+1)},
+		{"Polylog(0, -1)", bigfloat.Polylog(big.NewFloat(0), big.NewFloat(-1)), -0.5},
+		{"Polylog(-1, 0.5)", bigfloat.Polylog(big.NewFloat(-1), big.NewFloat(0.5)), 2},
+		{"Polylog(-2, -3)", bigfloat.Polylog(big.NewFloat(-2), big.NewFloat(-3)), 0.09375},
+		{"Dilog(0)", bigfloat.Dilog(zero), 0},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPolylog(b *testing.B) {
+	s := big.NewFloat(2.5)
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(0.75).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Polylog(s, z)
+			}
+		})
+	}
+}
+
+func BenchmarkDilog(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(0.75).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Dilog(z)
+			}
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// A Polynomial represents a polynomial
+//
+//	c₀ + c₁·x + c₂·x² + ... + cₙ·xⁿ
+//
+// with big.Float coefficients. The leading coefficient cₙ of a
+// polynomial of degree n is never zero, and the zero polynomial has
+// no coefficients and degree -1. Polynomials are immutable, so they
+// can be shared freely.
+type Polynomial struct {
+	c []*big.Float
+}
+
+// NewPolynomial returns the polynomial with coefficients c, where
+// c[i] is the coefficient of xⁱ. The coefficients are copied with
+// their precision, and the leading zero ones are dropped. The
+// function panics if one of them is ±Inf.
+func NewPolynomial(c ...*big.Float) *Polynomial {
+	n := len(c)
+	for n > 0 && c[n-1].Sign() == 0 {
+		n--
+	}
+	p := &Polynomial{make([]*big.Float, n)}
+	for i := range p.c {
+		if c[i].IsInf() {
+			panic("NewPolynomial: infinite coefficient")
+		}
+		p.c[i] = new(big.Float).Copy(c[i])
+	}
+	return p
+}
+
+// Degree returns the degree of p, or -1 if p is the zero polynomial.
+func (p *Polynomial) Degree() int {
+	return len(p.c) - 1
+}
+
+// Coeff returns a copy of the coefficient of xⁱ in p, which is zero
+// when i is larger than the degree of p. Coeff panics if i < 0.
+func (p *Polynomial) Coeff(i int) *big.Float {
+	if i < 0 {
+		panic("Coeff: negative index")
+	}
+	if i >= len(p.c) {
+		return new(big.Float)
+	}
+	return new(big.Float).Copy(p.c[i])
+}
+
+// Derivative returns the derivative of p. The coefficients of the
+// result are computed exactly.
+func (p *Polynomial) Derivative() *Polynomial {
+	if len(p.c) < 2 {
+		return &Polynomial{}
+	}
+	d := &Polynomial{make([]*big.Float, len(p.c)-1)}
+	for i := range d.c {
+		c := p.c[i+1]
+		d.c[i] = new(big.Float).SetPrec(c.Prec() + uint(bits.Len(uint(i+1))))
+		d.c[i].Mul(c, new(big.Float).SetInt64(int64(i+1)))
+	}
+	return d
+}
+
+// Eval returns p(x). Precision is the same as the one of x.
+//
+// p(x) is computed using Horner's rule, with a working precision that
+// is increased until the error bound returned by EvalBound shows that
+// the result is accurate, so that Eval is accurate even when p(x)
+// suffers from cancellation, as it happens close to its roots.
+func (p *Polynomial) Eval(x *big.Float) *big.Float {
+	if x.IsInf() {
+		panic("Eval: argument is ±Inf")
+	}
+
+	prec := x.Prec()
+
+	// The exact value of p(x) is a dyadic rational, and the loop
+	// stops at the latest when the precision is large enough for it
+	// to be computed exactly, since then the error bound is zero.
+	for wprec := prec + 64; ; wprec *= 2 {
+		y, bound := p.horner(x, wprec)
+		if bound.Sign() == 0 || y.Sign() != 0 && bound.MantExp(nil) < y.MantExp(nil)-int(prec)-2 {
+			return y.SetPrec(prec)
+		}
+	}
+}
+
+// EvalBound returns p(x) computed using Horner's rule at the
+// precision of x, together with a bound on the absolute error of the
+// result caused by the roundings in the evaluation. The bound is zero
+// when the result is exact, and otherwise it has 32 bits of
+// precision.
+func (p *Polynomial) EvalBound(x *big.Float) (y, bound *big.Float) {
+	if x.IsInf() {
+		panic("EvalBound: argument is ±Inf")
+	}
+	return p.horner(x, x.Prec())
+}
+
+// horner returns p(x) evaluated at prec bits of precision, together
+// with a bound on its rounding error.
+func (p *Polynomial) horner(x *big.Float, prec uint) (*big.Float, *big.Float) {
+
+	// Each step y ← y·x + cᵢ of Horner's rule commits two relative
+	// errors of size at most u = 2**(-prec), so the error eᵢ after
+	// the step satisfies, to first order,
+	//     |eᵢ| ≤ |x|·|eᵢ₊₁| + u·(|x·y| + |y ← y·x + cᵢ|)
+	// which we accumulate, rounding upwards, along with y. We use
+	// 2u in place of u to account for the higher order terms.
+	y := new(big.Float).SetPrec(prec)
+	bound := new(big.Float).SetPrec(32).SetMode(big.AwayFromZero)
+	ax := new(big.Float).SetPrec(32).SetMode(big.AwayFromZero).Abs(x)
+	t := new(big.Float).SetPrec(32).SetMode(big.AwayFromZero)
+	exact := true
+	for i := len(p.c) - 1; i >= 0; i-- {
+		bound.Mul(bound, ax)
+		y.Mul(y, x)
+		if y.Acc() != big.Exact {
+			exact = false
+			bound.Add(bound, t.Abs(y))
+		}
+		y.Add(y, p.c[i])
+		if y.Acc() != big.Exact {
+			exact = false
+			bound.Add(bound, t.Abs(y))
+		}
+	}
+
+	if exact {
+		return y, bound.SetInt64(0)
+	}
+	return y, bound.SetMantExp(bound, 1-int(prec))
+}
+
+// EvalComplex returns p(z). Precision is the same as the one of z.
+func (p *Polynomial) EvalComplex(z *Complex) *Complex {
+	if z.re.IsInf() || z.im.IsInf() {
+		panic("EvalComplex: argument is infinite")
+	}
+
+	prec := z.Prec()
+	y, _ := p.hornerComplex(z, prec+64, false)
+	return y.SetPrec(prec)
+}
+
+// hornerComplex returns p(z) and, if deriv is true, p'(z), evaluated
+// at prec bits of precision.
+func (p *Polynomial) hornerComplex(z *Complex, prec uint, deriv bool) (*Complex, *Complex) {
+	y := new(Complex).SetPrec(prec)
+	d := new(Complex).SetPrec(prec)
+	c := new(Complex)
+	for i := len(p.c) - 1; i >= 0; i-- {
+		if deriv {
+			d.Mul(d, z).Add(d, y)
+		}
+		y.Mul(y, z)
+		c.re.Copy(p.c[i])
+		y.Add(y, c)
+	}
+	return y, d
+}
+
+// Roots returns the complex roots of p, each one repeated as many
+// times as its multiplicity, computed to prec bits of precision
+// (relative to their absolute value) using the Aberth–Ehrlich
+// method. The roots at zero come first, and they are exact. Roots
+// panics if p is the zero polynomial.
+//
+// As for Newton, the precision of the iteration is increased, from 64
+// bits, each time the corrections become small enough. The roots
+// are approximated simultaneously, and the iteration converges
+// cubically towards the simple ones; it converges slowly towards
+// multiple roots, or clusters of very close roots, and their accuracy
+// is limited to a fraction of the working precision, so their
+// computation can take much longer. Roots returns ErrNoConvergence
+// if the iteration does not converge, and ErrDiverged if it breaks
+// down because two of the approximations coincide.
+func (p *Polynomial) Roots(prec uint) ([]*Complex, error) {
+	if len(p.c) == 0 {
+		panic("Roots: zero polynomial")
+	}
+
+	// factor out xᵏ
+	k := 0
+	for p.c[k].Sign() == 0 {
+		k++
+	}
+	roots := make([]*Complex, k, len(p.c)-1)
+	for i := range roots {
+		roots[i] = new(Complex).SetPrec(prec)
+	}
+
+	q := &Polynomial{p.c[k:]}
+	switch q.Degree() {
+	case 0:
+		return roots, nil
+	case 1:
+		// the root of c₀ + c₁·x is -c₀/c₁
+		z := new(Complex).SetPrec(prec)
+		z.re.Quo(q.c[0], q.c[1]).Neg(&z.re)
+		return append(roots, z), nil
+	}
+
+	z, err := q.aberth(prec)
+	if err != nil {
+		return nil, err
+	}
+	return append(roots, z...), nil
+}
+
+// aberth returns the roots of p, which must have degree at least 2
+// and no roots at zero, computed to prec bits of precision using the
+// Aberth–Ehrlich method.
+func (p *Polynomial) aberth(prec uint) ([]*Complex, error) {
+	n := p.Degree()
+
+	// At the starting precision the iteration needs a number of steps
+	// that grows with the degree, while after each doubling one or
+	// two steps should be enough. The approximations of a root of
+	// multiplicity m converge linearly, and only to about wprec/m
+	// bits, so when they stall we keep doubling the precision, up to
+	// 2n times the target one, allowing more steps each time.
+	const maxStepsDoubling = 10
+	maxSteps := 100 + 10*n
+	target := prec + 64
+	maxPrec := 2 * uint(n) * target
+
+	wprec := uint(64)
+	z := p.aberthStart(wprec)
+
+	one := NewComplex(big.NewFloat(1), big.NewFloat(0))
+	s, t, w := new(Complex), new(Complex), new(Complex)
+	var prev []*Complex
+	best, stalled := math.MaxInt32, 0
+	for step, limit := 0, maxSteps; ; step++ {
+		// Each root is updated in turn, as in the Gauss-Seidel method,
+		// using the correction
+		//     w = p(z)/(p'(z) - p(z)·Σⱼ 1/(z - zⱼ))
+		// and we keep track of the size of the largest one, relative
+		// to the root.
+		maxRel := math.MinInt32
+		for k := range z {
+			y, d := p.hornerComplex(z[k], wprec, true)
+			if y.re.Sign() == 0 && y.im.Sign() == 0 {
+				continue
+			}
+
+			s.SetPrec(wprec)
+			s.re.SetInt64(0)
+			s.im.SetInt64(0)
+			for j := range z {
+				if j == k {
+					continue
+				}
+				t.SetPrec(wprec).Sub(z[k], z[j])
+				if t.re.Sign() == 0 && t.im.Sign() == 0 {
+					return nil, ErrDiverged
+				}
+				s.Add(s, t.Quo(one, t))
+			}
+			d.Sub(d, s.Mul(s, y))
+			if d.re.Sign() == 0 && d.im.Sign() == 0 {
+				return nil, ErrDiverged
+			}
+			w.SetPrec(wprec).Quo(y, d)
+			z[k].Sub(z[k], w)
+			if rel := complexExp(w) - complexExp(z[k]); rel > maxRel {
+				maxRel = rel
+			}
+		}
+
+		// Until the target precision is reached we double it, as in
+		// findRoot, once the corrections are less than 2**(-wprec/2),
+		// since then the error is about 2**(-wprec) for simple roots.
+		// Otherwise we go on while they decrease, and after the
+		// starting precision we also double it when they stall for
+		// three steps, since they are then limited by the precision.
+		//
+		// We are done when the corrections are well below the
+		// requested precision, but the approximations of a multiple
+		// root stop moving long before they are accurate, when the
+		// values of p are lost in the rounding errors. So if two of
+		// them are close, we double the precision once more, and we
+		// are done only if they don't move.
+		tol := -int(prec) - 32
+		switch {
+		case wprec >= target && maxRel < tol:
+			if !aberthClustered(z) || prev != nil && aberthMoved(prev, z) < tol {
+				for k := range z {
+					z[k].SetPrec(prec)
+				}
+				return z, nil
+			}
+			prev = make([]*Complex, n)
+			for k := range z {
+				prev[k] = new(Complex).Set(z[k])
+			}
+			if wprec == maxPrec {
+				return nil, ErrNoConvergence
+			}
+			if wprec *= 2; wprec > maxPrec {
+				wprec = maxPrec
+			}
+			limit = maxStepsDoubling
+		case wprec < target && maxRel < -int(wprec/2):
+			if wprec *= 2; wprec > target {
+				wprec = target
+			}
+			limit = maxStepsDoubling
+		case maxRel < best && step < limit-1:
+			best, stalled = maxRel, 0
+			continue
+		case step < limit-1 && (stalled < 2 || wprec == 64):
+			stalled++
+			continue
+		case wprec == maxPrec:
+			return nil, ErrNoConvergence
+		default:
+			if wprec *= 2; wprec > maxPrec {
+				wprec = maxPrec
+			}
+			limit = int(wprec / 2)
+		}
+		for k := range z {
+			z[k].SetPrec(wprec)
+		}
+		step, best, stalled = -1, math.MaxInt32, 0
+	}
+}
+
+// aberthClustered reports whether two of the non-zero z are within
+// 2**(-16) of each other, relative to their absolute values.
+func aberthClustered(z []*Complex) bool {
+	d := new(Complex)
+	for k := range z {
+		for j := k + 1; j < len(z); j++ {
+			d.SetPrec(z[k].Prec()).Sub(z[k], z[j])
+			if d.re.Sign() == 0 && d.im.Sign() == 0 || complexExp(d)-complexExp(z[k]) < -16 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// aberthMoved returns the exponent of the largest difference between
+// the non-zero z and prev, relative to the values.
+func aberthMoved(prev, z []*Complex) int {
+	moved := math.MinInt32
+	d := new(Complex)
+	for k := range z {
+		d.SetPrec(z[k].Prec()).Sub(z[k], prev[k])
+		if d.re.Sign() == 0 && d.im.Sign() == 0 {
+			continue
+		}
+		if m := complexExp(d) - complexExp(z[k]); m > moved {
+			moved = m
+		}
+	}
+	return moved
+}
+
+// aberthStart returns the starting points for the Aberth–Ehrlich
+// iteration on p, with prec bits of precision.
+func (p *Polynomial) aberthStart(prec uint) []*Complex {
+	n := p.Degree()
+
+	// The magnitudes of the roots are estimated using the Newton
+	// polygon of p, the upper convex hull of the points (i, log|cᵢ|):
+	// for each of its edges, from i to j, p has about j - i roots of
+	// absolute value (|cᵢ|/|cⱼ|)**(1/(j - i)), which we place evenly
+	// on a circle, as in Bini's method. Using a single circle would
+	// be a poor start when the roots have very different magnitudes.
+	l := make([]float64, n+1)
+	hull := make([]int, 0, n+1)
+	for i, c := range p.c {
+		if c.Sign() == 0 {
+			continue
+		}
+		m := new(big.Float)
+		e := c.MantExp(m)
+		mf, _ := m.Float64()
+		l[i] = math.Log2(math.Abs(mf)) + float64(e)
+
+		for len(hull) >= 2 {
+			a, b := hull[len(hull)-2], hull[len(hull)-1]
+			if (l[b]-l[a])*float64(i-a) > (l[i]-l[a])*float64(b-a) {
+				break
+			}
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, i)
+	}
+
+	z := make([]*Complex, 0, n)
+	for h := 1; h < len(hull); h++ {
+		i, j := hull[h-1], hull[h]
+		lr := (l[i] - l[j]) / float64(j-i)
+		e := math.Floor(lr)
+		r := math.Exp2(lr - e)
+
+		// The circles are rotated by different angles, so that the
+		// points are not symmetric with respect to the real axis or
+		// to each other.
+		for k := 0; k < j-i; k++ {
+			theta := 2*math.Pi*(float64(k)/float64(j-i)+float64(i)/float64(n)) + 0.4
+			w := new(Complex).SetPrec(prec)
+			w.re.SetFloat64(r * math.Cos(theta))
+			w.re.SetMantExp(&w.re, int(e))
+			w.im.SetFloat64(r * math.Sin(theta))
+			w.im.SetMantExp(&w.im, int(e))
+			z = append(z, w)
+		}
+	}
+	return z
+}
+
+// complexExp returns the exponent of the largest part of the finite,
+// non-zero z.
+func complexExp(z *Complex) int {
+	if z.re.Sign() == 0 {
+		return z.im.MantExp(nil)
+	}
+	if z.im.Sign() == 0 {
+		return z.re.MantExp(nil)
+	}
+	if e, f := z.re.MantExp(nil), z.im.MantExp(nil); e > f {
+		return e
+	}
+	return z.im.MantExp(nil)
+}
@@ -0,0 +1,225 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func newPolynomial(c ...float64) *bigfloat.Polynomial {
+	b := make([]*big.Float, len(c))
+	for i := range c {
+		b[i] = big.NewFloat(c[i])
+	}
+	return bigfloat.NewPolynomial(b...)
+}
+
+// exactEval returns p(x) computed exactly, for a polynomial p of
+// degree n with 53-bit coefficients.
+func exactEval(p *bigfloat.Polynomial, x *big.Float) *big.Float {
+	prec := uint(p.Degree()+1)*(x.MinPrec()+53+uint(abs(x.MantExp(nil)))) + 1000
+	y := new(big.Float).SetPrec(prec)
+	for i := p.Degree(); i >= 0; i-- {
+		y.Mul(y, x)
+		y.Add(y, p.Coeff(i))
+	}
+	return y
+}
+
+// polyString returns the coefficients of p, for error messages.
+func polyString(p *bigfloat.Polynomial) string {
+	s := "["
+	for i := 0; i <= p.Degree(); i++ {
+		if i > 0 {
+			s += " "
+		}
+		s += p.Coeff(i).Text('g', 10)
+	}
+	return s + "]"
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestPolynomial(t *testing.T) {
+	p := newPolynomial(1, -3, 0, 2, 0, 0)
+	if p.Degree() != 3 {
+		t.Errorf("Degree = %d; want 3", p.Degree())
+	}
+	for i, want := range []float64{1, -3, 0, 2, 0} {
+		if c := p.Coeff(i); c.Cmp(big.NewFloat(want)) != 0 {
+			t.Errorf("Coeff(%d) = %g; want %g", i, c, want)
+		}
+	}
+
+	d := p.Derivative()
+	if d.Degree() != 2 {
+		t.Errorf("Derivative().Degree = %d; want 2", d.Degree())
+	}
+	for i, want := range []float64{-3, 0, 6} {
+		if c := d.Coeff(i); c.Cmp(big.NewFloat(want)) != 0 {
+			t.Errorf("Derivative().Coeff(%d) = %g; want %g", i, c, want)
+		}
+	}
+
+	if d := newPolynomial(5).Derivative(); d.Degree() != -1 {
+		t.Errorf("Derivative of a constant has degree %d; want -1", d.Degree())
+	}
+	if z := bigfloat.NewPolynomial(); z.Degree() != -1 || z.Coeff(0).Sign() != 0 {
+		t.Errorf("zero polynomial has degree %d", z.Degree())
+	}
+}
+
+func TestPolynomialEval(t *testing.T) {
+	polys := []*bigfloat.Polynomial{
+		newPolynomial(1, -2, 1),               // (x - 1)²
+		newPolynomial(-1, 3, -3, 1),           // (x - 1)³
+		newPolynomial(1, 0, -8, 0, 8),         // T₄(x)
+		newPolynomial(-0.5, 0.25, 3, -7, 0.1), // generic
+	}
+	xs := []float64{0, 1, -1, 0.5, 1 + 0x1p-20, 1 - 0x1p-22, 3.75, -1e10, 0x1p-30}
+
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		for _, p := range polys {
+			for _, xf := range xs {
+				x := new(big.Float).SetPrec(prec).SetFloat64(xf)
+				exact := exactEval(p, x)
+				want := new(big.Float).SetPrec(prec).Set(exact)
+
+				if z := p.Eval(x); z.Cmp(want) != 0 {
+					t.Errorf("prec = %d, p = %v, Eval(%g) =\ngot  %g;\nwant %g", prec, polyString(p), x, z, want)
+				}
+
+				y, bound := p.EvalBound(x)
+				err := new(big.Float).Sub(exact, y)
+				if err.Abs(err).Cmp(bound) > 0 {
+					t.Errorf("prec = %d, p = %v, EvalBound(%g) = %g ± %g; error is %g", prec, polyString(p), x, y, bound, err)
+				}
+			}
+		}
+	}
+}
+
+func TestPolynomialEvalComplex(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		// x³ + 2x + 1 at 1 + 2i is -8 + 2i
+		p := newPolynomial(1, 2, 0, 1)
+		z := bigfloat.NewComplex(big.NewFloat(1), big.NewFloat(2)).SetPrec(prec)
+		w := p.EvalComplex(z)
+		if w.Prec() != prec || w.Real().Cmp(big.NewFloat(-8)) != 0 || w.Imag().Cmp(big.NewFloat(2)) != 0 {
+			t.Errorf("prec = %d, EvalComplex(%v) = %v; want (-8+2i)", prec, z, w)
+		}
+
+		// x² + 1 vanishes at i
+		p = newPolynomial(1, 0, 1)
+		z = bigfloat.NewComplex(big.NewFloat(0), big.NewFloat(1)).SetPrec(prec)
+		if w := p.EvalComplex(z); w.Real().Sign() != 0 || w.Imag().Sign() != 0 {
+			t.Errorf("prec = %d, EvalComplex(%v) = %v; want 0", prec, z, w)
+		}
+	}
+}
+
+func TestPolynomialRoots(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		sqrt := func(x float64) *big.Float {
+			r := new(big.Float).SetPrec(prec + 64).SetFloat64(x)
+			return r.Sqrt(r).SetPrec(prec)
+		}
+		neg := func(x *big.Float) *big.Float { return new(big.Float).Neg(x) }
+		c := func(re, im *big.Float) *bigfloat.Complex { return bigfloat.NewComplex(re, im) }
+		f := big.NewFloat
+
+		for _, test := range []struct {
+			p    *bigfloat.Polynomial
+			want []*bigfloat.Complex
+		}{
+			{newPolynomial(3, 5), []*bigfloat.Complex{c(new(big.Float).SetPrec(prec).Quo(f(-3), f(5)), f(0))}},
+			{newPolynomial(-2, 0, 1), []*bigfloat.Complex{c(sqrt(2), f(0)), c(neg(sqrt(2)), f(0))}},
+			{newPolynomial(1, 0, 1), []*bigfloat.Complex{c(f(0), f(1)), c(f(0), f(-1))}},
+			{newPolynomial(-8, 0, 0, 1), []*bigfloat.Complex{
+				c(f(2), f(0)), c(f(-1), sqrt(3)), c(f(-1), neg(sqrt(3)))},
+			},
+			{newPolynomial(0, 0, -6, 11, -6, 1), []*bigfloat.Complex{
+				c(f(0), f(0)), c(f(0), f(0)), c(f(1), f(0)), c(f(2), f(0)), c(f(3), f(0))},
+			},
+			{newPolynomial(2, -3, 0, 1), []*bigfloat.Complex{ // (x - 1)²(x + 2)
+				c(f(1), f(0)), c(f(1), f(0)), c(f(-2), f(0))},
+			},
+			{newPolynomial(1, 0, 2, 0, 1), []*bigfloat.Complex{ // (x² + 1)²
+				c(f(0), f(1)), c(f(0), f(1)), c(f(0), f(-1)), c(f(0), f(-1))},
+			},
+			{bigfloat.NewPolynomial(f(-1), new(big.Float).SetPrec(100).Sub(f(0x1p40), f(0x1p-40)), f(1)), []*bigfloat.Complex{
+				// (x + 2⁴⁰)(x - 2⁻⁴⁰)
+				c(f(-0x1p40), f(0)), c(f(0x1p-40), f(0))},
+			},
+		} {
+			z, err := test.p.Roots(prec)
+			if err != nil || len(z) != test.p.Degree() {
+				t.Errorf("prec = %d, Roots(%v) = %v, %v", prec, polyString(test.p), z, err)
+				continue
+			}
+			for _, w := range test.want {
+				found := false
+				for i := range z {
+					if z[i] != nil && closeComplex(z[i], w, prec) {
+						z[i], found = nil, true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("prec = %d, Roots(%v): root %v not found in %v", prec, polyString(test.p), w, z)
+				}
+			}
+		}
+	}
+}
+
+// closeComplex reports whether z is within 2**(-prec+1) of w, relative
+// to its absolute value.
+func closeComplex(z, w *bigfloat.Complex, prec uint) bool {
+	if z.Prec() != prec {
+		return false
+	}
+	d := new(bigfloat.Complex).SetPrec(prec+64).Sub(z, w).Abs()
+	if d.Sign() == 0 {
+		return true
+	}
+	a := w.Abs()
+	if a.Sign() == 0 {
+		return false
+	}
+	return d.MantExp(nil) < a.MantExp(nil)-int(prec)+1
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPolynomialEval(b *testing.B) {
+	p := newPolynomial(1, -5, 10, -10, 5, -1)
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(1.5)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				p.Eval(x)
+			}
+		})
+	}
+}
+
+func BenchmarkPolynomialRoots(b *testing.B) {
+	p := newPolynomial(-1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1)
+	for _, prec := range []uint{1e2, 1e3} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				p.Roots(prec)
+			}
+		})
+	}
+}
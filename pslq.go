@@ -0,0 +1,399 @@
+package bigfloat
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ErrNoRelation is returned by PSLQ and Identify when there is no
+// integer relation with coefficients of the requested size.
+var ErrNoRelation = errors.New("bigfloat: no integer relation found")
+
+// PSLQ returns a vector of integers a, not all zero and with the first
+// non-zero one positive, such that a[0]x[0] + ... + a[n-1]x[n-1] = 0,
+// to the precision of the smallest precision among the elements of x,
+// using the PSLQ algorithm of Ferguson, Bailey and Arno. PSLQ panics
+// if x has less than 2 elements or if one of them is ±Inf.
+//
+// A relation is accepted when the sum vanishes to 7/8 of the precision
+// of x, relative to the size of its terms, so x must be known almost
+// to its full precision. PSLQ returns ErrNoRelation when there is no
+// relation with coefficients at most maxCoeff in absolute value, and
+// ErrNoConvergence if it cannot decide after maxSteps iterations.
+// Finding a relation between n numbers with coefficients of size M
+// requires a precision of at least about n·log2(M) bits.
+func PSLQ(x []*big.Float, maxCoeff int64, maxSteps int) ([]*big.Int, error) {
+
+	n := len(x)
+	if n < 2 {
+		panic("PSLQ: less than 2 numbers")
+	}
+	prec := x[0].Prec()
+	for i := range x {
+		if x[i].IsInf() {
+			panic("PSLQ: Inf argument")
+		}
+		if x[i].Prec() < prec {
+			prec = x[i].Prec()
+		}
+	}
+
+	// a zero element is a relation by itself
+	for i := range x {
+		if x[i].Sign() == 0 {
+			a := make([]*big.Int, n)
+			for j := range a {
+				a[j] = new(big.Int)
+			}
+			a[i].SetInt64(1)
+			return a, nil
+		}
+	}
+
+	wprec := prec + 64
+	newFloat := func() *big.Float { return new(big.Float).SetPrec(wprec) }
+
+	// y = x/|x| and s[k] = |(y[k], ..., y[n-1])|
+	y := make([]*big.Float, n)
+	s := make([]*big.Float, n)
+	for i := range x {
+		y[i] = newFloat().Set(x[i])
+	}
+	for k := n - 1; k >= 0; k-- {
+		s[k] = newFloat().Mul(y[k], y[k])
+		if k < n-1 {
+			s[k].Add(s[k], s[k+1])
+		}
+	}
+	for k := range s {
+		s[k].Sqrt(s[k])
+	}
+	norm := newFloat().Set(s[0])
+	for k := range s {
+		y[k].Quo(y[k], norm)
+		s[k].Quo(s[k], norm)
+	}
+
+	// H is the n×(n-1) lower trapezoidal matrix with
+	//   H[i][i] = s[i+1]/s[i]
+	//   H[i][j] = -y[i]y[j]/(s[j]s[j+1]), j < i
+	// and B is the identity. At every step y = xB/|x|, and the
+	// columns of B are candidate relations.
+	H := make([][]*big.Float, n)
+	B := make([][]*big.Int, n)
+	for i := range H {
+		H[i] = make([]*big.Float, n-1)
+		B[i] = make([]*big.Int, n)
+		for j := range H[i] {
+			H[i][j] = newFloat()
+			switch {
+			case i == j:
+				H[i][j].Quo(s[i+1], s[i])
+			case i > j:
+				t := newFloat().Mul(s[j], s[j+1])
+				H[i][j].Mul(y[i], y[j])
+				H[i][j].Quo(H[i][j], t).Neg(H[i][j])
+			}
+		}
+		for j := range B[i] {
+			B[i][j] = new(big.Int)
+		}
+		B[i][i].SetInt64(1)
+	}
+
+	// reduce performs the Hermite reduction of the rows of H from
+	// first on, using the columns up to last.
+	t, tf, u := new(big.Int), newFloat(), newFloat()
+	reduce := func(first, last int) {
+		for i := first; i < n; i++ {
+			j0 := i - 1
+			if j0 > last {
+				j0 = last
+			}
+			for j := j0; j >= 0; j-- {
+				if H[j][j].Sign() == 0 {
+					continue
+				}
+				roundInt(tf.Quo(H[i][j], H[j][j]), t)
+				if t.Sign() == 0 {
+					continue
+				}
+				tf.SetInt(t)
+				y[j].Add(y[j], u.Mul(tf, y[i]))
+				for k := 0; k <= j; k++ {
+					H[i][k].Sub(H[i][k], u.Mul(tf, H[j][k]))
+				}
+				for k := 0; k < n; k++ {
+					B[k][j].Add(B[k][j], new(big.Int).Mul(t, B[k][i]))
+				}
+			}
+		}
+	}
+	reduce(1, n)
+
+	// the relations are columns of B whose y is small; we test
+	// them against the original x, and only return those that
+	// vanish to the required precision
+	small := -int(prec) / 2
+	relation := func(i int) []*big.Int {
+		if y[i].Sign() != 0 && y[i].MantExp(nil) > small {
+			return nil
+		}
+		a := make([]*big.Int, n)
+		for k := range a {
+			a[k] = new(big.Int).Set(B[k][i])
+			if a[k].IsInt64() && absInt64(a[k].Int64()) <= maxCoeff {
+				continue
+			}
+			return nil
+		}
+		if !isRelation(x, a, prec) {
+			return nil
+		}
+		for k := range a {
+			if a[k].Sign() != 0 {
+				if a[k].Sign() < 0 {
+					for k := range a {
+						a[k].Neg(a[k])
+					}
+				}
+				break
+			}
+		}
+		return a
+	}
+
+	gamma := math.Sqrt(4.0 / 3.0)
+	g := newFloat()
+	for step := 0; step < maxSteps; step++ {
+
+		// choose m maximizing γ^(i+1)|H[i][i]|
+		m := 0
+		best := newFloat()
+		for i := 0; i < n-1; i++ {
+			g.SetFloat64(math.Pow(gamma, float64(i+1)))
+			g.Mul(g, H[i][i]).Abs(g)
+			if g.Cmp(best) > 0 {
+				m, best = i, newFloat().Set(g)
+			}
+		}
+
+		// exchange the entries m and m+1
+		y[m], y[m+1] = y[m+1], y[m]
+		H[m], H[m+1] = H[m+1], H[m]
+		for k := 0; k < n; k++ {
+			B[k][m], B[k][m+1] = B[k][m+1], B[k][m]
+		}
+
+		// restore the lower trapezoidal form of H
+		if m < n-2 {
+			t0 := newFloat().Mul(H[m][m], H[m][m])
+			t0.Add(t0, newFloat().Mul(H[m][m+1], H[m][m+1]))
+			t0.Sqrt(t0)
+			if t0.Sign() != 0 {
+				t1 := newFloat().Quo(H[m][m], t0)
+				t2 := newFloat().Quo(H[m][m+1], t0)
+				for i := m; i < n; i++ {
+					t3, t4 := H[i][m], H[i][m+1]
+					H[i][m] = newFloat().Mul(t1, t3)
+					H[i][m].Add(H[i][m], u.Mul(t2, t4))
+					H[i][m+1] = newFloat().Mul(t1, t4)
+					H[i][m+1].Sub(H[i][m+1], u.Mul(t2, t3))
+				}
+			}
+		}
+
+		reduce(m+1, m+1)
+
+		for i := 0; i < n; i++ {
+			if a := relation(i); a != nil {
+				return a, nil
+			}
+		}
+
+		// every relation has norm at least 1/max|H[i][i]|
+		best.SetInt64(0)
+		for i := 0; i < n-1; i++ {
+			if g.Abs(H[i][i]).Cmp(best) > 0 {
+				best.Set(g)
+			}
+		}
+		if best.Mul(best, g.SetInt64(maxCoeff)).Cmp(big.NewFloat(1)) < 0 {
+			return nil, ErrNoRelation
+		}
+	}
+
+	return nil, ErrNoConvergence
+}
+
+// isRelation reports whether a[0]x[0] + ... + a[n-1]x[n-1] vanishes
+// to 7/8 of prec bits, relative to the largest of its terms.
+func isRelation(x []*big.Float, a []*big.Int, prec uint) bool {
+	var terms []*big.Float
+	sprec := uint(0)
+	for i := range x {
+		if a[i].Sign() == 0 {
+			continue
+		}
+		t := new(big.Float).SetInt(a[i])
+		t.SetPrec(t.MinPrec()+x[i].MinPrec()).Mul(t, x[i])
+		terms = append(terms, t)
+		if p := t.Prec(); p > sprec {
+			sprec = p
+		}
+	}
+	if len(terms) == 0 {
+		return false
+	}
+
+	sum := new(big.Float).SetPrec(sprec + 64 + uint(len(terms)))
+	maxExp := math.MinInt32
+	for _, t := range terms {
+		sum.Add(sum, t)
+		if t.Sign() != 0 && t.MantExp(nil) > maxExp {
+			maxExp = t.MantExp(nil)
+		}
+	}
+	return sum.Sign() == 0 || sum.MantExp(nil) <= maxExp-int(prec-prec/8)
+}
+
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// identifyConstants are the constants used by Identify, together with
+// the names used in its results.
+var identifyConstants = []struct {
+	name  string
+	value func(prec uint) *big.Float
+}{
+	{"pi", pi},
+	{"pi^2", func(prec uint) *big.Float {
+		p := pi(prec + 64)
+		return p.Mul(p, p).SetPrec(prec)
+	}},
+	{"e", func(prec uint) *big.Float {
+		return Exp(new(big.Float).SetPrec(prec).SetInt64(1))
+	}},
+	{"log(2)", func(prec uint) *big.Float {
+		return Log(new(big.Float).SetPrec(prec).SetInt64(2))
+	}},
+	{"euler", EulerGamma},
+	{"zeta(3)", func(prec uint) *big.Float {
+		return Zeta(new(big.Float).SetPrec(prec).SetInt64(3))
+	}},
+}
+
+// Identify looks for a closed form of x as a linear combination with
+// rational coefficients of 1 and of at most two of the constants π,
+// π², e, log(2), γ and ζ(3), and returns it as a string such as
+// "pi^2/6" or "(1 - 3*log(2))/4". Combinations with fewer constants
+// are tried first.
+//
+// The constants are computed to the precision of x, and the search is
+// done by PSLQ, with the same accuracy requirements and the same bound
+// maxCoeff on the integer coefficients of the relation between x, 1
+// and the constants. Identify returns ErrNoRelation if no closed form
+// is found, and panics if x is ±Inf.
+func Identify(x *big.Float, maxCoeff int64) (string, error) {
+	if x.IsInf() {
+		panic("Identify: Inf argument")
+	}
+	if x.Sign() == 0 {
+		return "0", nil
+	}
+
+	prec := x.Prec()
+	consts := make([]*big.Float, len(identifyConstants))
+	for i := range identifyConstants {
+		consts[i] = identifyConstants[i].value(prec)
+	}
+
+	try := func(idx ...int) (string, bool) {
+		v := []*big.Float{x, new(big.Float).SetPrec(prec).SetInt64(1)}
+		names := []string{""}
+		for _, i := range idx {
+			v = append(v, consts[i])
+			names = append(names, identifyConstants[i].name)
+		}
+		a, err := PSLQ(v, maxCoeff, 100*len(v))
+		if err != nil || a[0].Sign() == 0 {
+			return "", false
+		}
+		return identifyString(a, names), true
+	}
+
+	if s, ok := try(); ok {
+		return s, nil
+	}
+	for i := range consts {
+		if s, ok := try(i); ok {
+			return s, nil
+		}
+	}
+	for i := range consts {
+		for j := i + 1; j < len(consts); j++ {
+			if s, ok := try(i, j); ok {
+				return s, nil
+			}
+		}
+	}
+	return "", ErrNoRelation
+}
+
+// identifyString returns the closed form -(a[1]c[0] + ... +
+// a[n-1]c[n-2])/a[0] of the relation a, where c are the constants
+// with the given names, and the empty name stands for 1.
+func identifyString(a []*big.Int, names []string) string {
+	q := new(big.Int).Set(a[0])
+	num := make([]*big.Int, len(names))
+	for i := range num {
+		num[i] = new(big.Int).Neg(a[i+1])
+	}
+	if q.Sign() < 0 {
+		q.Neg(q)
+		for i := range num {
+			num[i].Neg(num[i])
+		}
+	}
+
+	s, terms := "", 0
+	for i, c := range num {
+		if c.Sign() == 0 {
+			continue
+		}
+		switch {
+		case terms > 0 && c.Sign() < 0:
+			s += " - "
+		case terms > 0:
+			s += " + "
+		case c.Sign() < 0:
+			s += "-"
+		}
+		c := new(big.Int).Abs(c)
+		switch {
+		case names[i] == "":
+			s += c.String()
+		case c.IsInt64() && c.Int64() == 1:
+			s += names[i]
+		default:
+			s += c.String() + "*" + names[i]
+		}
+		terms++
+	}
+
+	switch {
+	case terms == 0:
+		return "0"
+	case q.IsInt64() && q.Int64() == 1:
+		return s
+	case terms == 1:
+		return s + "/" + q.String()
+	default:
+		return "(" + s + ")/" + q.String()
+	}
+}
@@ -0,0 +1,96 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestPSLQ(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		pi := bigfloat.PiInterval(prec).Lo()
+		log2 := bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+		zeta3 := bigfloat.Zeta(big.NewFloat(3).SetPrec(prec))
+
+		// x = 3π - 2log(2) + ζ(3)/5
+		x := new(big.Float).SetPrec(prec+64).Mul(pi, big.NewFloat(15))
+		x.Sub(x, new(big.Float).SetPrec(prec+64).Mul(log2, big.NewFloat(10)))
+		x.Add(x, zeta3)
+		x.Quo(x, big.NewFloat(5)).SetPrec(prec)
+
+		a, err := bigfloat.PSLQ([]*big.Float{x, pi, log2, zeta3}, 1000, 1000)
+		want := []int64{5, -15, 10, -1}
+		if err != nil || len(a) != len(want) {
+			t.Errorf("prec = %d, PSLQ = %v, %v; want %v", prec, a, err, want)
+			continue
+		}
+		for i := range want {
+			if a[i].Cmp(big.NewInt(want[i])) != 0 {
+				t.Errorf("prec = %d, PSLQ = %v; want %v", prec, a, want)
+				break
+			}
+		}
+	}
+}
+
+func TestPSLQNoRelation(t *testing.T) {
+	const prec = 200
+	pi := bigfloat.PiInterval(prec).Lo()
+	e := bigfloat.Exp(big.NewFloat(1).SetPrec(prec))
+	x := []*big.Float{big.NewFloat(1).SetPrec(prec), pi, e}
+	if a, err := bigfloat.PSLQ(x, 1000, 1000); err != bigfloat.ErrNoRelation {
+		t.Errorf("PSLQ(1, π, e) = %v, %v; want %v", a, err, bigfloat.ErrNoRelation)
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	// at 24 bits there are spurious relations with coefficients
+	// below 1000 between x, 1 and π
+	for _, prec := range []uint{53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		pi := bigfloat.PiInterval(prec + 64).Lo()
+		log2 := bigfloat.Log(big.NewFloat(2).SetPrec(prec + 64))
+		euler := bigfloat.EulerGamma(prec + 64)
+		f := func(x *big.Float) *big.Float { return x.SetPrec(prec) }
+		newFloat := func() *big.Float { return new(big.Float).SetPrec(prec + 64) }
+
+		for _, test := range []struct {
+			x    *big.Float
+			want string
+		}{
+			{new(big.Float).SetPrec(prec).Quo(big.NewFloat(-7), big.NewFloat(10)), "-7/10"},
+			{bigfloat.Zeta(big.NewFloat(2).SetPrec(prec)), "pi^2/6"},
+			{f(newFloat().Quo(pi, big.NewFloat(4))), "pi/4"},
+			{f(newFloat().Sub(big.NewFloat(1), newFloat().Mul(log2, big.NewFloat(3)))), "1 - 3*log(2)"},
+			{f(newFloat().Quo(newFloat().Add(euler, log2), big.NewFloat(2))), "(log(2) + euler)/2"},
+		} {
+			if s, err := bigfloat.Identify(test.x, 1000); err != nil || s != test.want {
+				t.Errorf("prec = %d, Identify(%g) = %q, %v; want %q", prec, test.x, s, err, test.want)
+			}
+		}
+
+		// √2 is not a rational combination of the constants
+		sqrt2 := new(big.Float).SetPrec(prec).Sqrt(big.NewFloat(2))
+		if s, err := bigfloat.Identify(sqrt2, 1000); err != bigfloat.ErrNoRelation {
+			t.Errorf("prec = %d, Identify(√2) = %q, %v; want %v", prec, s, err, bigfloat.ErrNoRelation)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPSLQ(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		pi := bigfloat.PiInterval(prec).Lo()
+		log2 := bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+		zeta3 := bigfloat.Zeta(big.NewFloat(3).SetPrec(prec))
+		x := []*big.Float{bigfloat.EulerGamma(prec), pi, log2, zeta3}
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.PSLQ(x, 1000, 1000)
+			}
+		})
+	}
+}
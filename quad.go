@@ -0,0 +1,399 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"sync"
+)
+
+// TanhSinh returns the integral of f from a to b, computed to prec
+// bits of precision using tanh-sinh (double exponential) quadrature.
+// It converges quickly for functions that are analytic inside the
+// interval, even when they have integrable singularities at its
+// ends, where they are never evaluated.
+//
+// f is called with arguments whose precision is larger than prec,
+// which it must not modify, and its results must be accurate to
+// about that precision. The nodes are cached for each precision.
+// TanhSinh returns ErrNoConvergence if successive estimates do not
+// agree to prec bits, and panics if a or b are infinite.
+func TanhSinh(f func(x *big.Float) *big.Float, a, b *big.Float, prec uint) (*big.Float, error) {
+	return integrate(f, a, b, prec, tanhSinhRule, tanhSinhMaxLevel, "TanhSinh")
+}
+
+// GaussLegendre returns the integral of f from a to b, computed to
+// prec bits of precision using Gauss–Legendre quadrature, with
+// 3·2^k nodes for increasing k until successive estimates agree. It
+// converges very quickly for functions that are analytic in a
+// neighbourhood of the interval, and slowly otherwise.
+//
+// The requirements on f and the returned errors are the same as in
+// TanhSinh. The nodes are computed at the working precision and
+// cached for each degree and precision.
+func GaussLegendre(f func(x *big.Float) *big.Float, a, b *big.Float, prec uint) (*big.Float, error) {
+	return integrate(f, a, b, prec, gaussLegendreRule, gaussLegendreMaxLevel, "GaussLegendre")
+}
+
+// A quadRule returns, for an integrand f, an interval [a, b] and a
+// working precision prec, a function that computes the estimates of
+// the integral at levels 0, 1, 2, ..., which are called in order;
+// and the binary exponent of the estimate of the integral of |f|.
+type quadRule func(f func(x *big.Float) *big.Float, a, b *big.Float, prec uint) func(level int) (*big.Float, int)
+
+// integrate returns the integral of f from a to b computed to prec
+// bits of precision using rule, with at most maxLevel levels, and
+// uses fname in the panic messages.
+func integrate(f func(x *big.Float) *big.Float, a, b *big.Float, prec uint, rule quadRule, maxLevel int, fname string) (*big.Float, error) {
+
+	if a.IsInf() || b.IsInf() {
+		panic(fname + ": interval is not finite")
+	}
+	if a.Cmp(b) == 0 {
+		return new(big.Float).SetPrec(prec), nil
+	}
+
+	// When f changes sign the sum can be much smaller than the
+	// integral of |f|, and we add that gap to the working precision
+	// unless the integral is negligible compared to the one of |f|.
+	guard := uint(0)
+	for {
+		wprec := prec + 64 + guard
+		next := rule(f, a, b, wprec)
+
+		var x, prev *big.Float
+		var scale int
+		for level := 0; ; level++ {
+			if level > maxLevel {
+				return nil, ErrNoConvergence
+			}
+			x, scale = next(level)
+			if prev != nil && quadConverged(x, prev, scale, wprec) {
+				break
+			}
+			prev = x
+		}
+
+		if lost := lostBits(x, scale, wprec); lost > int(guard) && lost < int(wprec) {
+			guard = uint(lost)
+			continue
+		}
+		return x.SetPrec(prec), nil
+	}
+}
+
+// quadConverged reports whether the estimate x of an integral, which
+// follows prev, is accurate to prec bits, or to the rounding errors
+// at precision prec of a sum whose terms have magnitude 2**scale.
+func quadConverged(x, prev *big.Float, scale int, prec uint) bool {
+
+	// Both rules converge quadratically: the error of an estimate is
+	// about the square of the one of the previous estimate, which is
+	// about the difference between them.
+	d := new(big.Float).Sub(x, prev)
+	if d.Sign() == 0 {
+		return true
+	}
+	lim := scale - int(prec) + 16
+	if x.Sign() != 0 && x.MantExp(nil)-int(prec/2) > lim {
+		lim = x.MantExp(nil) - int(prec/2)
+	}
+	return d.MantExp(nil) < lim
+}
+
+// quadSum adds w·f(x) to sum and |w·f(x)| to abs.
+func quadSum(f func(x *big.Float) *big.Float, x, w, sum, abs *big.Float) {
+	y := new(big.Float).SetPrec(sum.Prec()).Mul(f(x), w)
+	sum.Add(sum, y)
+	abs.Add(abs, y.Abs(y))
+}
+
+// ---------- Tanh-sinh ----------
+
+// tanhSinhMaxLevel is the maximum level of tanh-sinh quadrature,
+// whose step is 2**(-level).
+const tanhSinhMaxLevel = 12
+
+// A tanhSinhNode holds 1-x and the weight w of a tanh-sinh node.
+type tanhSinhNode struct {
+	c, w *big.Float
+}
+
+// quadCacheSize is the maximum number of precisions for which the
+// quadrature nodes are cached. A full cache is emptied before adding
+// new nodes.
+const quadCacheSize = 16
+
+// tanhSinhCache holds, for each precision, the nodes for the levels
+// computed so far. It is guarded by tanhSinhMu.
+var (
+	tanhSinhMu    sync.Mutex
+	tanhSinhCache = make(map[uint][][]tanhSinhNode)
+)
+
+// tanhSinhRule is the quadRule for tanh-sinh quadrature.
+func tanhSinhRule(f func(x *big.Float) *big.Float, a, b *big.Float, prec uint) func(level int) (*big.Float, int) {
+
+	// With x = a + r(1-c) = b - r(1+c), the sums over the nodes
+	// at all levels up to the current one are
+	//     Σ w·(f(a + r·c) + f(b - r·c))
+	// where c is 1-x for the nodes x in [0, 1).
+	r := new(big.Float).SetPrec(prec).Sub(b, a)
+	r.SetMantExp(r, -1)
+	sum := new(big.Float).SetPrec(prec)
+	abs := new(big.Float).SetPrec(prec)
+	return func(level int) (*big.Float, int) {
+		for _, n := range tanhSinhNodes(level, prec) {
+			d := new(big.Float).SetPrec(prec).Mul(r, n.c)
+			for _, x := range []*big.Float{
+				new(big.Float).SetPrec(prec).Add(a, d),
+				new(big.Float).SetPrec(prec).Sub(b, d),
+			} {
+				// nodes that round to the ends are skipped
+				if x.Cmp(a) != 0 && x.Cmp(b) != 0 {
+					quadSum(f, x, n.w, sum, abs)
+				}
+			}
+		}
+
+		// the integral is h·r times the sum, with h = 2**(-level)
+		x := new(big.Float).Mul(sum, r)
+		x.SetMantExp(x, -level)
+		return x, abs.MantExp(nil) + r.MantExp(nil) - level
+	}
+}
+
+// tanhSinhNodes returns the tanh-sinh nodes for [-1, 1] that are
+// new at level, computed to prec bits of precision. The nodes are
+// x = tanh(π/2·sinh(t)) with weights w = π/2·cosh(t)/cosh²(π/2·sinh(t)),
+// for t = k·2**(-level), where k is odd for level > 0. The weight of
+// the node at t = 0 is halved, since it is used twice.
+func tanhSinhNodes(level int, prec uint) []tanhSinhNode {
+
+	tanhSinhMu.Lock()
+	levels := tanhSinhCache[prec]
+	tanhSinhMu.Unlock()
+	if level < len(levels) {
+		return levels[level]
+	}
+
+	// the nodes are computed without holding the lock, on a copy
+	// of the cached levels, since other goroutines may be reading
+	// them
+	levels = append([][]tanhSinhNode(nil), levels...)
+	for len(levels) <= level {
+		levels = append(levels, tanhSinhLevel(len(levels), prec))
+	}
+
+	tanhSinhMu.Lock()
+	if len(levels) > len(tanhSinhCache[prec]) {
+		if _, ok := tanhSinhCache[prec]; !ok && len(tanhSinhCache) >= quadCacheSize {
+			tanhSinhCache = make(map[uint][][]tanhSinhNode)
+		}
+		tanhSinhCache[prec] = levels
+	}
+	tanhSinhMu.Unlock()
+	return levels[level]
+}
+
+// tanhSinhLevel computes the nodes returned by tanhSinhNodes.
+func tanhSinhLevel(level int, prec uint) []tanhSinhNode {
+
+	// The absolute error on u = π/2·sinh(t), which is up to about
+	// prec, becomes a relative error in exp(u), so we need as many
+	// extra bits as its exponent, and a few more for the rounding
+	// errors that accumulate in exp(t).
+	wprec := prec + 32
+	halfPi := pi(wprec)
+	halfPi.SetMantExp(halfPi, -1)
+	one := big.NewFloat(1)
+
+	// exp(t) is updated by multiplying it by exp(h), or exp(2h) for
+	// level > 0, where only odd multiples of h are new.
+	h := new(big.Float).SetMantExp(one, -level)
+	eh := Exp(h.SetPrec(wprec))
+	et := big.NewFloat(1).SetPrec(wprec)
+	if level > 0 {
+		et.Set(eh)
+		eh.Mul(eh, eh)
+	}
+
+	var nodes []tanhSinhNode
+	for k := 0; ; k++ {
+		if k > 0 {
+			et.Mul(et, eh)
+		}
+
+		// sinh(t) and cosh(t)
+		iet := new(big.Float).Quo(one, et)
+		sinh := new(big.Float).Sub(et, iet)
+		cosh := new(big.Float).Add(et, iet)
+		sinh.SetMantExp(sinh, -1)
+		cosh.SetMantExp(cosh, -1)
+
+		// With e = exp(u), 1-x = 2/(e² + 1) and
+		// w = 2π·cosh(t)/(e + 1/e)²
+		e := Exp(sinh.Mul(sinh, halfPi))
+		c := new(big.Float).Mul(e, e)
+		c.Quo(big.NewFloat(2), c.Add(c, one))
+		w := new(big.Float).Add(e, new(big.Float).Quo(one, e))
+		w.Quo(cosh, w.Mul(w, w))
+		w.Mul(w, halfPi)
+		w.SetMantExp(w, 2)
+		if level == 0 && k == 0 {
+			w.SetMantExp(w, -1)
+		}
+
+		if w.MantExp(nil) < -2*int(prec) {
+			return nodes
+		}
+		nodes = append(nodes, tanhSinhNode{c.SetPrec(prec), w.SetPrec(prec)})
+	}
+}
+
+// ---------- Gauss–Legendre ----------
+
+// gaussLegendreMaxLevel is the maximum level of Gauss–Legendre
+// quadrature, which uses 3·2**level nodes.
+const gaussLegendreMaxLevel = 8
+
+// A gaussLegendreNode holds a Gauss–Legendre node x >= 0 and its
+// weight w.
+type gaussLegendreNode struct {
+	x, w *big.Float
+}
+
+type gaussLegendreKey struct {
+	n    int
+	prec uint
+}
+
+// gaussLegendreCache holds the nodes for each degree and precision.
+// It is guarded by gaussLegendreMu.
+var (
+	gaussLegendreMu    sync.Mutex
+	gaussLegendreCache = make(map[gaussLegendreKey][]gaussLegendreNode)
+)
+
+// gaussLegendreRule is the quadRule for Gauss–Legendre quadrature.
+func gaussLegendreRule(f func(x *big.Float) *big.Float, a, b *big.Float, prec uint) func(level int) (*big.Float, int) {
+
+	// With m = (a+b)/2 and r = (b-a)/2, the integral is
+	//     r·Σ w·(f(m + r·x) + f(m - r·x))
+	// where the sum is over the nodes x >= 0, and the weight of the
+	// node at x = 0 is halved.
+	m := new(big.Float).SetPrec(prec).Add(a, b)
+	m.SetMantExp(m, -1)
+	r := new(big.Float).SetPrec(prec).Sub(b, a)
+	r.SetMantExp(r, -1)
+	return func(level int) (*big.Float, int) {
+		sum := new(big.Float).SetPrec(prec)
+		abs := new(big.Float).SetPrec(prec)
+		for _, n := range gaussLegendreNodes(3<<uint(level), prec) {
+			d := new(big.Float).SetPrec(prec).Mul(r, n.x)
+			quadSum(f, new(big.Float).SetPrec(prec).Add(m, d), n.w, sum, abs)
+			quadSum(f, new(big.Float).SetPrec(prec).Sub(m, d), n.w, sum, abs)
+		}
+		return sum.Mul(sum, r), abs.MantExp(nil) + r.MantExp(nil)
+	}
+}
+
+// gaussLegendreNodes returns the non-negative nodes of the n-point
+// Gauss–Legendre rule for [-1, 1], the roots of the Legendre
+// polynomial P_n, and their weights w = 2/((1-x²)P'_n(x)²), computed
+// to prec bits of precision. The weight of the node at 0, when n is
+// odd, is halved, since it is used twice.
+func gaussLegendreNodes(n int, prec uint) []gaussLegendreNode {
+
+	key := gaussLegendreKey{n, prec}
+	gaussLegendreMu.Lock()
+	nodes, ok := gaussLegendreCache[key]
+	gaussLegendreMu.Unlock()
+	if ok {
+		return nodes
+	}
+
+	one := big.NewFloat(1)
+	nodes = make([]gaussLegendreNode, 0, (n+1)/2)
+	for i := 1; i <= (n+1)/2; i++ {
+
+		// Start from x = cos(π(i - 1/4)/(n + 1/2)), refine it in
+		// float64, and then with newton, which expects a guess that
+		// is accurate to its precision.
+		x := math.Cos(math.Pi * (float64(i) - 0.25) / (float64(n) + 0.5))
+		for j := 0; j < 100; j++ {
+			p, dp := legendreFloat64(n, x)
+			dx := p / dp
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+
+		var t *big.Float
+		if 2*i == n+1 {
+			t = new(big.Float).SetPrec(prec) // the middle node is 0
+		} else {
+			f := func(z *big.Float) *big.Float {
+				p, dp := legendre(n, z)
+				return p.Quo(p, dp)
+			}
+			t = newton(f, big.NewFloat(x).SetPrec(32), prec+32)
+		}
+
+		_, dp := legendre(n, new(big.Float).SetPrec(prec+32).Set(t))
+		w := new(big.Float).SetPrec(prec+32).Mul(t, t)
+		w.Sub(one, w)
+		w.Mul(w, dp.Mul(dp, dp))
+		w.Quo(big.NewFloat(2), w)
+		if t.Sign() == 0 {
+			w.SetMantExp(w, -1)
+		}
+		nodes = append(nodes, gaussLegendreNode{t.SetPrec(prec), w.SetPrec(prec)})
+	}
+
+	gaussLegendreMu.Lock()
+	if len(gaussLegendreCache) >= quadCacheSize*(gaussLegendreMaxLevel+1) {
+		gaussLegendreCache = make(map[gaussLegendreKey][]gaussLegendreNode)
+	}
+	gaussLegendreCache[key] = nodes
+	gaussLegendreMu.Unlock()
+	return nodes
+}
+
+// legendre returns P_n(x) and P'_n(x), with the precision of x,
+// using the recurrence
+//
+//	(k+1)P_(k+1)(x) = (2k+1)x·P_k(x) - k·P_(k-1)(x)
+//
+// and P'_n(x) = n(x·P_n(x) - P_(n-1)(x))/(x² - 1), for |x| < 1.
+func legendre(n int, x *big.Float) (*big.Float, *big.Float) {
+
+	prec := x.Prec()
+	p0 := big.NewFloat(1).SetPrec(prec)
+	p1 := new(big.Float).SetPrec(prec).Set(x)
+	t := new(big.Float).SetPrec(prec)
+	d := new(big.Float)
+	for k := int64(1); k < int64(n); k++ {
+		t.Mul(x, p1)
+		t.Mul(t, d.SetInt64(2*k+1))
+		p0.Mul(p0, d.SetInt64(k))
+		t.Sub(t, p0)
+		t.Quo(t, d.SetInt64(k+1))
+		p0, p1, t = p1, t, p0
+	}
+
+	dp := new(big.Float).SetPrec(prec).Mul(x, p1)
+	dp.Sub(dp, p0)
+	dp.Mul(dp, d.SetInt64(int64(n)))
+	t.Mul(x, x)
+	return p1, dp.Quo(dp, t.Sub(t, big.NewFloat(1)))
+}
+
+// legendreFloat64 returns P_n(x) and P'_n(x) in float64, as legendre.
+func legendreFloat64(n int, x float64) (float64, float64) {
+	p0, p1 := 1.0, x
+	for k := 1; k < n; k++ {
+		p0, p1 = p1, (float64(2*k+1)*x*p1-float64(k)*p0)/float64(k+1)
+	}
+	return p1, float64(n) * (x*p1 - p0) / (x*x - 1)
+}
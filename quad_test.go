@@ -0,0 +1,187 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+type quadTest struct {
+	name string
+	f    func(x *big.Float) *big.Float
+	a, b float64
+	want func(prec uint) *big.Float
+}
+
+var quadTests = []quadTest{
+	{
+		"1/(1+x²)", // π/4 = Γ(1/2)²/4
+		func(x *big.Float) *big.Float {
+			r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+			r.Add(r, big.NewFloat(1))
+			return r.Quo(big.NewFloat(1), r)
+		},
+		0, 1,
+		func(prec uint) *big.Float {
+			r := bigfloat.Gamma(big.NewFloat(0.5).SetPrec(prec + 64))
+			r.Mul(r, r)
+			return r.Quo(r, big.NewFloat(4)).SetPrec(prec)
+		},
+	},
+	{
+		"1/x", // -log(2)
+		func(x *big.Float) *big.Float {
+			return new(big.Float).SetPrec(x.Prec()).Quo(big.NewFloat(1), x)
+		},
+		2, 1,
+		func(prec uint) *big.Float {
+			r := bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+			return r.Neg(r)
+		},
+	},
+	{
+		"x³ + 2**(-40)", // 2**(-39)
+		func(x *big.Float) *big.Float {
+			r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+			r.Mul(r, x)
+			return r.Add(r, new(big.Float).SetMantExp(big.NewFloat(1), -40))
+		},
+		-1, 1,
+		func(prec uint) *big.Float {
+			return new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(1), -39)
+		},
+	},
+	{
+		"exp(x)", // e² - 1
+		bigfloat.Exp,
+		0, 2,
+		func(prec uint) *big.Float {
+			r := bigfloat.Exp(big.NewFloat(2).SetPrec(prec + 64))
+			return r.Sub(r, big.NewFloat(1)).SetPrec(prec)
+		},
+	},
+}
+
+// quadSingularTests have singularities at the ends of the interval,
+// where only TanhSinh converges.
+var quadSingularTests = []quadTest{
+	{
+		"1/√x", // 2
+		func(x *big.Float) *big.Float {
+			r := new(big.Float).SetPrec(x.Prec()).Sqrt(x)
+			return r.Quo(big.NewFloat(1), r)
+		},
+		0, 1,
+		func(prec uint) *big.Float { return big.NewFloat(2).SetPrec(prec) },
+	},
+	{
+		"log(x)", // -1
+		bigfloat.Log,
+		0, 1,
+		func(prec uint) *big.Float { return big.NewFloat(-1).SetPrec(prec) },
+	},
+}
+
+func testQuad(t *testing.T, name string, quad func(func(*big.Float) *big.Float, *big.Float, *big.Float, uint) (*big.Float, error), tests []quadTest) {
+	for _, test := range tests {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500} {
+			if test.name == "exp(x)" && prec > 200 {
+				break // too slow
+			}
+			want := test.want(prec)
+			x, err := quad(test.f, big.NewFloat(test.a), big.NewFloat(test.b), prec)
+			if err != nil || x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, %s(%s, %v, %v) =\ngot  %g (%v);\nwant %g", prec, name, test.name, test.a, test.b, x, err, want)
+			}
+		}
+	}
+}
+
+func TestTanhSinh(t *testing.T) {
+	testQuad(t, "TanhSinh", bigfloat.TanhSinh, quadTests)
+	testQuad(t, "TanhSinh", bigfloat.TanhSinh, quadSingularTests)
+}
+
+func TestGaussLegendre(t *testing.T) {
+	testQuad(t, "GaussLegendre", bigfloat.GaussLegendre, quadTests)
+}
+
+func TestQuadConcurrent(t *testing.T) {
+	// the cached nodes are shared between goroutines
+	test := quadTests[0]
+	var wg sync.WaitGroup
+	for _, prec := range []uint{24, 53, 64, 100, 24, 53, 64, 100} {
+		for _, quad := range []func(func(*big.Float) *big.Float, *big.Float, *big.Float, uint) (*big.Float, error){
+			bigfloat.TanhSinh, bigfloat.GaussLegendre,
+		} {
+			wg.Add(1)
+			go func(quad func(func(*big.Float) *big.Float, *big.Float, *big.Float, uint) (*big.Float, error), prec uint) {
+				defer wg.Done()
+				want := test.want(prec)
+				x, err := quad(test.f, big.NewFloat(test.a), big.NewFloat(test.b), prec)
+				if err != nil || x.Cmp(want) != 0 {
+					t.Errorf("prec = %d, concurrent quadrature of %s =\ngot  %g (%v);\nwant %g", prec, test.name, x, err, want)
+				}
+			}(quad, prec)
+		}
+	}
+	wg.Wait()
+}
+
+func TestQuadSpecialValues(t *testing.T) {
+	f := func(x *big.Float) *big.Float { return x }
+	one := big.NewFloat(1)
+	for _, quad := range []func(func(*big.Float) *big.Float, *big.Float, *big.Float, uint) (*big.Float, error){
+		bigfloat.TanhSinh, bigfloat.GaussLegendre,
+	} {
+		if x, err := quad(f, one, one, 53); err != nil || x.Sign() != 0 {
+			t.Errorf("integral over [1, 1] = %g (%v); want 0", x, err)
+		}
+	}
+
+	// 1/x² is not integrable at 0
+	g := func(x *big.Float) *big.Float {
+		r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+		return r.Quo(one, r)
+	}
+	if _, err := bigfloat.GaussLegendre(g, big.NewFloat(0), one, 100); err != bigfloat.ErrNoConvergence {
+		t.Errorf("GaussLegendre(1/x², 0, 1): got error %v; want %v", err, bigfloat.ErrNoConvergence)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkTanhSinh(b *testing.B) {
+	f := func(x *big.Float) *big.Float {
+		r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+		r.Add(r, big.NewFloat(1))
+		return r.Quo(big.NewFloat(1), r)
+	}
+	for _, prec := range []uint{1e2, 1e3} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.TanhSinh(f, big.NewFloat(0), big.NewFloat(1), prec)
+			}
+		})
+	}
+}
+
+func BenchmarkGaussLegendre(b *testing.B) {
+	f := func(x *big.Float) *big.Float {
+		r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+		r.Add(r, big.NewFloat(1))
+		return r.Quo(big.NewFloat(1), r)
+	}
+	for _, prec := range []uint{1e2, 1e3} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.GaussLegendre(f, big.NewFloat(0), big.NewFloat(1), prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// ExpRat returns a big.Float representation of exp(x), computed to
+// prec bits of precision.
+//
+// Since x is rational, exp(x) is computed by binary splitting of its
+// Taylor series on big.Int values, which is much faster than Exp at
+// high precisions when the numerator and the denominator of x are
+// small.
+func ExpRat(x *big.Rat, prec uint) *big.Float {
+
+	// exp(0) = 1
+	if x.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(prec)
+	}
+
+	// exp(x) = exp(x/2ᵏ)^(2ᵏ), with |x/2ᵏ| < 2⁻⁷. Each squaring
+	// doubles the relative error, so we need k extra bits.
+	p, q := x.Num(), x.Denom()
+	k := 0
+	if e := p.BitLen() - q.BitLen() + 8; e > 0 {
+		k = e
+	}
+	wprec := prec + 64 + uint(k)
+	q = new(big.Int).Lsh(q, uint(k))
+
+	// we need N terms, with |x/2ᵏ|ᴺ/N! < 2**(-wprec)
+	l := float64(p.BitLen() - q.BitLen() + 1)
+	n, s := 1, 0.0
+	for s > -float64(wprec) {
+		s += l - math.Log2(float64(n))
+		n++
+	}
+
+	// exp(x) = 1 + Σ_{n>=1} Π_{i=1}^{n} p/(i·q)
+	one := big.NewInt(1)
+	_, Q, B, T := binarySplit(1, n,
+		func(i int) *big.Int { return p },
+		func(i int) *big.Int { return new(big.Int).Mul(big.NewInt(int64(i)), q) },
+		func(i int) *big.Int { return one },
+	)
+	z := new(big.Float).SetPrec(wprec).SetInt(T)
+	z.Quo(z, new(big.Float).SetPrec(wprec).SetInt(Q.Mul(Q, B)))
+	z.Add(z, big.NewFloat(1))
+
+	for i := 0; i < k; i++ {
+		z.Mul(z, z)
+	}
+	return z.SetPrec(prec)
+}
+
+// LogRat returns a big.Float representation of the natural logarithm
+// of x, computed to prec bits of precision. The function panics if x
+// is negative, and returns -Inf when x = 0.
+//
+// Since x is rational, log(x) is computed by binary splitting of the
+// series of atanh on big.Int values, which is faster than Log when
+// the numerator and the denominator of x are small.
+func LogRat(x *big.Rat, prec uint) *big.Float {
+
+	if x.Sign() < 0 {
+		panic("LogRat: argument is negative")
+	}
+
+	// LogRat(0) = -Inf
+	if x.Sign() == 0 {
+		return big.NewFloat(math.Inf(-1)).SetPrec(prec)
+	}
+
+	// x = 2ᵐ·a/b with 2/3 <= a/b < 4/3
+	a, b := new(big.Int).Set(x.Num()), new(big.Int).Set(x.Denom())
+	m := a.BitLen() - b.BitLen()
+	if m > 0 {
+		b.Lsh(b, uint(m))
+	} else {
+		a.Lsh(a, uint(-m))
+	}
+	a3 := new(big.Int).Mul(a, big.NewInt(3))
+	if b4 := new(big.Int).Lsh(b, 2); a3.Cmp(b4) >= 0 {
+		b.Lsh(b, 1)
+		m++
+	} else if b2 := new(big.Int).Lsh(b, 1); a3.Cmp(b2) < 0 {
+		a.Lsh(a, 1)
+		m--
+	}
+
+	// log(x) = m·log(2) + 2·atanh((a - b)/(a + b)), and the two
+	// terms have opposite signs only if |m| = 1, when they cancel
+	// at most 2 bits
+	wprec := prec + 64
+	z := atanhRat(new(big.Int).Sub(a, b), new(big.Int).Add(a, b), wprec)
+	z.SetMantExp(z, 1)
+	if m != 0 {
+		log2 := ln2(wprec + uint(bits.Len(uint(absInt(m)))))
+		z.Add(z, log2.Mul(log2, new(big.Float).SetInt64(int64(m))))
+	}
+	return z.SetPrec(prec)
+}
+
+// ln2Cache holds log(2) to ln2CachePrec bits. Both are guarded by
+// ln2Mu.
+var ln2Mu sync.Mutex
+var ln2Cache *big.Float
+var ln2CachePrec uint
+
+// ln2 returns log(2) to prec bits of precision.
+func ln2(prec uint) *big.Float {
+
+	ln2Mu.Lock()
+	if prec <= ln2CachePrec {
+		z := new(big.Float).Copy(ln2Cache).SetPrec(prec)
+		ln2Mu.Unlock()
+		return z
+	}
+	ln2Mu.Unlock()
+
+	// log(2) = 18·atanh(1/26) - 2·atanh(1/4801) + 8·atanh(1/8749)
+	wprec := prec + 64
+	z := new(big.Float).SetPrec(wprec)
+	for _, t := range []struct{ c, k int64 }{{18, 26}, {-2, 4801}, {8, 8749}} {
+		a := atanhRat(big.NewInt(1), big.NewInt(t.k), wprec)
+		z.Add(z, a.Mul(a, big.NewFloat(float64(t.c))))
+	}
+
+	ln2Mu.Lock()
+	if wprec > ln2CachePrec {
+		ln2Cache = z
+		ln2CachePrec = wprec
+	}
+	ln2Mu.Unlock()
+	return new(big.Float).Copy(z).SetPrec(prec)
+}
+
+// PowRat returns a big.Float representation of x**y, computed to prec
+// bits of precision. The function panics when x is negative, and
+// returns +Inf when x = 0 and y < 0.
+//
+// When y is an integer and the result is not too large, it is
+// computed exactly and rounded. Otherwise PowRat computes
+// exp(y·log(x)) using LogRat.
+func PowRat(x, y *big.Rat, prec uint) *big.Float {
+
+	if x.Sign() < 0 {
+		panic("PowRat: negative base")
+	}
+
+	// PowRat(x, 0) = 1
+	if y.Sign() == 0 {
+		return big.NewFloat(1).SetPrec(prec)
+	}
+
+	// PowRat(0, y) is 0 for y > 0, and +Inf for y < 0
+	if x.Sign() == 0 {
+		if y.Sign() < 0 {
+			return big.NewFloat(math.Inf(+1)).SetPrec(prec)
+		}
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// exact x**n, if it has at most about 4·prec bits
+	p, q := x.Num(), x.Denom()
+	if y.IsInt() && y.Num().IsInt64() {
+		n := y.Num().Int64()
+		size := p.BitLen()
+		if q.BitLen() > size {
+			size = q.BitLen()
+		}
+		if n > -int64(4*prec) && n < int64(4*prec) && absInt64(n)*int64(size) <= int64(4*(prec+64)) {
+			e := big.NewInt(absInt64(n))
+			r := new(big.Rat).SetFrac(new(big.Int).Exp(p, e, nil), new(big.Int).Exp(q, e, nil))
+			if n < 0 {
+				r.Inv(r)
+			}
+			return new(big.Float).SetPrec(prec).SetRat(r)
+		}
+	}
+
+	// exp(t) with t = y·log(x) has the same relative error as the
+	// absolute error of t, so we need as many extra bits as the
+	// magnitude of t
+	wprec := prec + 64
+	for {
+		t := LogRat(x, wprec)
+		t.Mul(t, new(big.Float).SetInt(y.Num()))
+		t.Quo(t, new(big.Float).SetInt(y.Denom()))
+		if t.Sign() == 0 || t.MantExp(nil) <= int(wprec-prec-64) {
+			return Exp(t).SetPrec(prec)
+		}
+		wprec = prec + 64 + uint(t.MantExp(nil))
+	}
+}
+
+// atanhRat returns atanh(u/v), for |u/v| < 1, computed to prec bits
+// of precision by binary splitting of the series
+// Σ (u/v)²ⁿ⁺¹/(2n + 1).
+func atanhRat(u, v *big.Int, prec uint) *big.Float {
+	if u.Sign() == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// we need N terms, with |u/v|²ᴺ⁺¹ < 2**(-prec)
+	l := float64(v.BitLen() - u.BitLen() - 1)
+	if l < 1 {
+		r, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(u)).Float64()
+		l = math.Log2(math.Abs(r))
+	}
+	n := int(float64(prec)/(2*l)) + 2
+
+	u2, v2 := new(big.Int).Mul(u, u), new(big.Int).Mul(v, v)
+	_, Q, B, T := binarySplit(0, n,
+		func(i int) *big.Int {
+			if i == 0 {
+				return u
+			}
+			return u2
+		},
+		func(i int) *big.Int {
+			if i == 0 {
+				return v
+			}
+			return v2
+		},
+		func(i int) *big.Int { return big.NewInt(int64(2*i + 1)) },
+	)
+	z := new(big.Float).SetPrec(prec).SetInt(T)
+	return z.Quo(z, new(big.Float).SetPrec(prec).SetInt(Q.Mul(Q, B)))
+}
+
+// binarySplit returns P, Q, B and T such that
+//
+//	Σ_{n=a}^{b-1} 1/d(n) Π_{i=a}^{n} p(i)/q(i) = T/(BQ)
+//
+// and P/Q = Π_{i=a}^{b-1} p(i)/q(i), following B. Haible and
+// T. Papanikolaou, Fast multiprecision evaluation of series of
+// rational numbers (1998). The results do not share memory with the
+// values returned by p, q and d.
+func binarySplit(a, b int, p, q, d func(n int) *big.Int) (P, Q, B, T *big.Int) {
+	if b-a == 1 {
+		P = new(big.Int).Set(p(a))
+		return P, new(big.Int).Set(q(a)), new(big.Int).Set(d(a)), new(big.Int).Set(P)
+	}
+
+	m := (a + b) / 2
+	P1, Q1, B1, T1 := binarySplit(a, m, p, q, d)
+	P2, Q2, B2, T2 := binarySplit(m, b, p, q, d)
+
+	// T = B2·Q2·T1 + B1·P1·T2
+	T = T1.Mul(T1, B2).Mul(T1, Q2)
+	T.Add(T, T2.Mul(T2, P1).Mul(T2, B1))
+	return P1.Mul(P1, P2), Q1.Mul(Q1, Q2), B1.Mul(B1, B2), T
+}
@@ -0,0 +1,105 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+var ratArgs = []string{"1/3", "-7/5", "22/7", "100/7", "-1000", "1/1099511627776", "355/113"}
+
+func TestExpRat(t *testing.T) {
+	for _, s := range ratArgs {
+		x, _ := new(big.Rat).SetString(s)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := bigfloat.Exp(new(big.Float).SetPrec(prec + 128).SetRat(x)).SetPrec(prec)
+			if z := bigfloat.ExpRat(x, prec); z.Prec() != prec || z.Cmp(want) != 0 {
+				t.Errorf("prec = %d, ExpRat(%v) =\ngot  %g;\nwant %g", prec, x, z, want)
+			}
+		}
+	}
+
+	if z := bigfloat.ExpRat(new(big.Rat), 100); z.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("ExpRat(0) = %g; want 1", z)
+	}
+}
+
+func TestLogRat(t *testing.T) {
+	for _, s := range []string{"1/3", "7/5", "22/7", "100/7", "1/1099511627776", "1099511627777", "127/128", "1000001/1000000", "2"} {
+		x, _ := new(big.Rat).SetString(s)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := bigfloat.Log(new(big.Float).SetPrec(prec + 128).SetRat(x)).SetPrec(prec)
+			if z := bigfloat.LogRat(x, prec); z.Prec() != prec || z.Cmp(want) != 0 {
+				t.Errorf("prec = %d, LogRat(%v) =\ngot  %g;\nwant %g", prec, x, z, want)
+			}
+		}
+	}
+
+	if z := bigfloat.LogRat(big.NewRat(1, 1), 100); z.Sign() != 0 {
+		t.Errorf("LogRat(1) = %g; want 0", z)
+	}
+	if z := bigfloat.LogRat(new(big.Rat), 100); !z.IsInf() || z.Sign() > 0 {
+		t.Errorf("LogRat(0) = %g; want -Inf", z)
+	}
+}
+
+func TestPowRat(t *testing.T) {
+	for _, test := range []struct {
+		x, y string
+	}{
+		{"2", "1/2"},
+		{"3/2", "5"},
+		{"3/2", "-5"},
+		{"10", "-1/3"},
+		{"7/3", "100/7"},
+		{"1/1000", "1000"},
+		{"12345", "1/7"},
+	} {
+		x, _ := new(big.Rat).SetString(test.x)
+		y, _ := new(big.Rat).SetString(test.y)
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			xf := new(big.Float).SetPrec(prec + 128).SetRat(x)
+			yf := new(big.Float).SetPrec(prec + 128).SetRat(y)
+			want := bigfloat.Pow(xf, yf).SetPrec(prec)
+			if z := bigfloat.PowRat(x, y, prec); z.Prec() != prec || z.Cmp(want) != 0 {
+				t.Errorf("prec = %d, PowRat(%v, %v) =\ngot  %g;\nwant %g", prec, x, y, z, want)
+			}
+		}
+	}
+
+	// integer powers are exact
+	if z := bigfloat.PowRat(big.NewRat(3, 2), big.NewRat(5, 1), 53); z.Cmp(big.NewFloat(7.59375)) != 0 {
+		t.Errorf("PowRat(3/2, 5) = %g; want 7.59375", z)
+	}
+	if z := bigfloat.PowRat(new(big.Rat), big.NewRat(-1, 2), 53); !z.IsInf() || z.Sign() < 0 {
+		t.Errorf("PowRat(0, -1/2) = %g; want +Inf", z)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkExpRat(b *testing.B) {
+	x := big.NewRat(1, 3)
+	for _, prec := range []uint{1e2, 1e3, 1e4, 1e5} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.ExpRat(x, prec)
+			}
+		})
+	}
+}
+
+func BenchmarkLogRat(b *testing.B) {
+	x := big.NewRat(10, 1)
+	for _, prec := range []uint{1e2, 1e3, 1e4, 1e5} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.LogRat(x, prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package bigfloat
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrNoConvergence is returned by the root finders when the
+	// iteration does not converge within the allowed number of steps.
+	ErrNoConvergence = errors.New("bigfloat: iteration did not converge")
+
+	// ErrDiverged is returned by the root finders when a step is not
+	// defined, because a derivative vanishes, or when the iteration
+	// goes to infinity.
+	ErrDiverged = errors.New("bigfloat: iteration diverged")
+
+	// ErrNoBracket is returned by the bracketing root finders when f
+	// has the same sign at the two ends of the interval.
+	ErrNoBracket = errors.New("bigfloat: root is not bracketed")
+)
+
+// Newton returns a root of f, computed to prec bits of precision
+// using Newton's method starting from guess, where df is the
+// derivative of f.
+//
+// As in Exp, the iteration starts at a low precision, which is
+// doubled each time the steps become small enough, so that most of
+// them are cheap. f and df are called with arguments of increasing
+// precision, which they must not modify, and their results must be
+// accurate to about that precision.
+//
+// Newton returns ErrDiverged if df vanishes at one of the iterates
+// or if they go to infinity, and ErrNoConvergence if the iteration
+// does not converge. Since the convergence test is relative, a root
+// at zero is only found if f vanishes exactly at one of the iterates.
+func Newton(f, df func(x *big.Float) *big.Float, guess *big.Float, prec uint) (*big.Float, error) {
+
+	// x_(n+1) = x_n - f(x_n)/f'(x_n)
+	step := func(x *big.Float) *big.Float {
+		fx := f(x)
+		if fx.Sign() == 0 {
+			return new(big.Float)
+		}
+		dfx := df(x)
+		if dfx.Sign() == 0 {
+			return nil
+		}
+		return new(big.Float).SetPrec(x.Prec()).Quo(fx, dfx)
+	}
+	return findRoot(step, guess, prec)
+}
+
+// Halley returns a root of f, computed to prec bits of precision
+// using Halley's method starting from guess, where df and d2f are
+// the first and second derivatives of f. Halley's method converges
+// cubically, instead of quadratically, which pays off when the
+// second derivative is cheap to compute.
+//
+// The precision is increased as in Newton, and f, df and d2f are
+// subject to the same requirements. Halley returns ErrDiverged if
+// the step is not defined at one of the iterates or if they go to
+// infinity, and ErrNoConvergence if the iteration does not converge.
+func Halley(f, df, d2f func(x *big.Float) *big.Float, guess *big.Float, prec uint) (*big.Float, error) {
+
+	// x_(n+1) = x_n - f(x_n)f'(x_n)/(f'(x_n)² - f(x_n)f''(x_n)/2)
+	step := func(x *big.Float) *big.Float {
+		fx := f(x)
+		if fx.Sign() == 0 {
+			return new(big.Float)
+		}
+		wprec := x.Prec()
+		dfx := df(x)
+		d := new(big.Float).SetPrec(wprec).Mul(fx, d2f(x))
+		d.SetMantExp(d, -1)
+		d.Sub(new(big.Float).SetPrec(wprec).Mul(dfx, dfx), d)
+		if d.Sign() == 0 {
+			return nil
+		}
+		return d.Quo(new(big.Float).SetPrec(wprec).Mul(fx, dfx), d)
+	}
+	return findRoot(step, guess, prec)
+}
+
+// findRoot iterates x_(n+1) = x_n - step(x_n) starting from guess,
+// doubling the working precision each time the step is less than
+// half of it, until prec bits of precision are reached. step returns
+// nil when it is not defined.
+func findRoot(step func(x *big.Float) *big.Float, guess *big.Float, prec uint) (*big.Float, error) {
+
+	// We work with 64 guard bits, starting at 64 bits of precision.
+	// The guess may be poor, so we allow more steps at the starting
+	// precision than after each doubling, where one or two steps
+	// should be enough.
+	const maxSteps, maxStepsDoubling = 100, 10
+
+	maxPrec := prec + 64
+	wprec := uint(64)
+	x := new(big.Float).SetPrec(wprec).Set(guess)
+	for n, limit := 0, maxSteps; ; n++ {
+		if n == limit {
+			return nil, ErrNoConvergence
+		}
+
+		d := step(x)
+		if d == nil || d.IsInf() {
+			return nil, ErrDiverged
+		}
+		x.Sub(x, d)
+		if x.IsInf() {
+			return nil, ErrDiverged
+		}
+
+		// With quadratic convergence, the error after a step whose
+		// size is about 2**(-wprec/2) relative to x is about
+		// 2**(-wprec). The first step after a doubling has about
+		// that size, so we allow two bits of slack, which the guard
+		// bits absorb, to avoid a second step at each precision.
+		if d.Sign() == 0 || x.Sign() != 0 && d.MantExp(nil)-x.MantExp(nil) < 2-int(wprec/2) {
+			if wprec == maxPrec {
+				return x.SetPrec(prec), nil
+			}
+			if wprec *= 2; wprec > maxPrec {
+				wprec = maxPrec
+			}
+			x.SetPrec(wprec)
+			n, limit = -1, maxStepsDoubling
+		}
+	}
+}
+
+// Bisection returns a root of f in the interval [a, b], computed to
+// tol bits of precision using bisection. f(a) and f(b) must have
+// opposite signs. f is called with arguments that have 64 more bits
+// of precision than tol, which it must not modify.
+//
+// The iteration stops when the bracket is smaller than 2**(-tol)
+// relative to its ends, or when f vanishes. Bisection returns
+// ErrNoBracket if f(a) and f(b) have the same sign, and
+// ErrNoConvergence if this does not happen in maxIter steps, which
+// is the case for a root at zero that is not hit exactly.
+func Bisection(f func(x *big.Float) *big.Float, a, b *big.Float, tol uint, maxIter int) (*big.Float, error) {
+
+	wprec := tol + 64
+	a = new(big.Float).SetPrec(wprec).Set(a)
+	b = new(big.Float).SetPrec(wprec).Set(b)
+	fa, fb := f(a), f(b)
+	switch {
+	case fa.Sign() == 0:
+		return a.SetPrec(tol), nil
+	case fb.Sign() == 0:
+		return b.SetPrec(tol), nil
+	case fa.Sign() == fb.Sign():
+		return nil, ErrNoBracket
+	}
+
+	m := new(big.Float).SetPrec(wprec)
+	for n := 0; n < maxIter; n++ {
+		m.Add(a, b)
+		m.SetMantExp(m, -1)
+		if bracketSmall(a, b, m, tol) {
+			return m.SetPrec(tol), nil
+		}
+
+		fm := f(m)
+		switch {
+		case fm.Sign() == 0:
+			return m.SetPrec(tol), nil
+		case fm.Sign() == fa.Sign():
+			a.Set(m)
+			fa = fm
+		default:
+			b.Set(m)
+		}
+	}
+	return nil, ErrNoConvergence
+}
+
+// Illinois returns a root of f in the interval [a, b], computed to
+// tol bits of precision using the Illinois variant of the regula
+// falsi method, which converges superlinearly for smooth f. The
+// requirements on f, the stopping criterion and the returned errors
+// are the same as in Bisection.
+func Illinois(f func(x *big.Float) *big.Float, a, b *big.Float, tol uint, maxIter int) (*big.Float, error) {
+
+	wprec := tol + 64
+	a = new(big.Float).SetPrec(wprec).Set(a)
+	b = new(big.Float).SetPrec(wprec).Set(b)
+	fa, fb := f(a), f(b)
+	switch {
+	case fa.Sign() == 0:
+		return a.SetPrec(tol), nil
+	case fb.Sign() == 0:
+		return b.SetPrec(tol), nil
+	case fa.Sign() == fb.Sign():
+		return nil, ErrNoBracket
+	}
+
+	// The root stays between a and b. We take the secant step
+	//     c = (a·f(b) - b·f(a))/(f(b) - f(a))
+	// which replaces b, and when a is kept we halve f(a), so that the
+	// next steps eventually move past the root and replace it too.
+	c := new(big.Float).SetPrec(wprec)
+	t := new(big.Float).SetPrec(wprec)
+	for n := 0; n < maxIter; n++ {
+		c.Mul(a, fb)
+		c.Sub(c, t.Mul(b, fa))
+		c.Quo(c, t.Sub(fb, fa))
+
+		fc := f(c)
+		if fc.Sign() == 0 {
+			return c.SetPrec(tol), nil
+		}
+		if fc.Sign() == fb.Sign() {
+			fa = new(big.Float).SetMantExp(fa, -1)
+		} else {
+			a.Set(b)
+			fa = fb
+		}
+		b.Set(c)
+		fb = fc
+
+		if bracketSmall(a, b, b, tol) {
+			return b.SetPrec(tol), nil
+		}
+	}
+	return nil, ErrNoConvergence
+}
+
+// Brent returns a root of f in the interval [a, b], computed to tol
+// bits of precision using Brent's method, which combines inverse
+// quadratic interpolation and the secant method with bisection
+// steps, so that it converges superlinearly for smooth f and never
+// much slower than Bisection. The requirements on f, the stopping
+// criterion and the returned errors are the same as in Bisection.
+func Brent(f func(x *big.Float) *big.Float, a, b *big.Float, tol uint, maxIter int) (*big.Float, error) {
+
+	wprec := tol + 64
+	a = new(big.Float).SetPrec(wprec).Set(a)
+	b = new(big.Float).SetPrec(wprec).Set(b)
+	fa, fb := f(a), f(b)
+	switch {
+	case fa.Sign() == 0:
+		return a.SetPrec(tol), nil
+	case fb.Sign() == 0:
+		return b.SetPrec(tol), nil
+	case fa.Sign() == fb.Sign():
+		return nil, ErrNoBracket
+	}
+
+	// Following R. P. Brent, Algorithms for Minimization without
+	// Derivatives, 1973, procedure zero. b is the best estimate, the
+	// root is between b and c, and a is the previous value of b.
+	newf := func() *big.Float { return new(big.Float).SetPrec(wprec) }
+	c, fc := newf().Set(a), fa
+	d := newf().Sub(b, a)
+	e := newf().Set(d)
+	m, tl := newf(), newf()
+	p, q, r, s := newf(), newf(), newf(), newf()
+	one := big.NewFloat(1)
+	for n := 0; n < maxIter; n++ {
+		if fb.Sign() == fc.Sign() {
+			c.Set(a)
+			fc = fa
+			d.Sub(b, a)
+			e.Set(d)
+		}
+		if cmpAbs(fc, fb) < 0 {
+			a.Set(b)
+			b.Set(c)
+			c.Set(a)
+			fa, fb, fc = fb, fc, fb
+		}
+
+		// tl is 2**(-tol-1)|b|, and m is half the bracket
+		tl.SetMantExp(tl.Abs(b), -int(tol)-1)
+		m.Sub(c, b)
+		m.SetMantExp(m, -1)
+		if fb.Sign() == 0 || cmpAbs(m, tl) <= 0 {
+			return b.SetPrec(tol), nil
+		}
+
+		if cmpAbs(e, tl) >= 0 && cmpAbs(fa, fb) > 0 {
+			s.Quo(fb, fa)
+			if a.Cmp(c) == 0 {
+				// secant step
+				p.Mul(m, s)
+				p.SetMantExp(p, 1)
+				q.Sub(one, s)
+			} else {
+				// inverse quadratic interpolation
+				q.Quo(fa, fc)
+				r.Quo(fb, fc)
+				t := newf().Sub(q, r)
+				t.Mul(t, q)
+				t.Mul(t, m)
+				t.SetMantExp(t, 1)
+				u := newf().Sub(b, a)
+				u.Mul(u, newf().Sub(r, one))
+				p.Mul(s, t.Sub(t, u))
+				q.Sub(q, one)
+				q.Mul(q, r.Sub(r, one))
+				q.Mul(q, s.Sub(s, one))
+			}
+			if p.Sign() > 0 {
+				q.Neg(q)
+			} else {
+				p.Neg(p)
+			}
+
+			// Accept the interpolation only if it falls within the
+			// bracket and decreases faster than bisection, that is
+			// if 2p < min(3mq - |tl·q|, |e·q|).
+			lim := newf().Mul(m, q)
+			lim.Mul(lim, big.NewFloat(3))
+			lim.Sub(lim, newf().Abs(newf().Mul(tl, q)))
+			if eq := newf().Abs(newf().Mul(e, q)); eq.Cmp(lim) < 0 {
+				lim = eq
+			}
+			if newf().SetMantExp(p, 1).Cmp(lim) < 0 {
+				e.Set(d)
+				d.Quo(p, q)
+			} else {
+				d.Set(m)
+				e.Set(m)
+			}
+		} else {
+			d.Set(m)
+			e.Set(m)
+		}
+
+		a.Set(b)
+		fa = fb
+		if cmpAbs(d, tl) > 0 {
+			b.Add(b, d)
+		} else if m.Sign() > 0 {
+			b.Add(b, tl)
+		} else {
+			b.Sub(b, tl)
+		}
+		fb = f(b)
+	}
+	return nil, ErrNoConvergence
+}
+
+// bracketSmall reports whether |b - a| <= 2**(-tol)|x|.
+func bracketSmall(a, b, x *big.Float, tol uint) bool {
+	d := new(big.Float).Sub(b, a)
+	return d.Sign() == 0 || x.Sign() != 0 && d.MantExp(nil)-x.MantExp(nil) < -int(tol)
+}
+
+// cmpAbs compares |x| and |y|, and returns -1, 0 or +1 as Cmp.
+func cmpAbs(x, y *big.Float) int {
+	return new(big.Float).Abs(x).Cmp(new(big.Float).Abs(y))
+}
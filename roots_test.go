@@ -0,0 +1,189 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestNewton(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		// x² - 2 = 0
+		two := big.NewFloat(2).SetPrec(prec)
+		f := func(x *big.Float) *big.Float {
+			r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+			return r.Sub(r, two)
+		}
+		df := func(x *big.Float) *big.Float {
+			return new(big.Float).SetPrec(x.Prec()).Add(x, x)
+		}
+		want := new(big.Float).SetPrec(prec).Sqrt(two)
+
+		x, err := bigfloat.Newton(f, df, big.NewFloat(1), prec)
+		if err != nil || x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, Newton(x² - 2) =\ngot  %g (%v);\nwant %g", prec, x, err, want)
+		}
+
+		// exp(x) - 3 = 0
+		three := big.NewFloat(3)
+		g := func(x *big.Float) *big.Float {
+			r := bigfloat.Exp(x)
+			return r.Sub(r, three)
+		}
+		dg := func(x *big.Float) *big.Float { return bigfloat.Exp(x) }
+		want = bigfloat.Log(big.NewFloat(3).SetPrec(prec))
+
+		x, err = bigfloat.Newton(g, dg, big.NewFloat(5), prec)
+		if err != nil || x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, Newton(exp(x) - 3) =\ngot  %g (%v);\nwant %g", prec, x, err, want)
+		}
+	}
+}
+
+func TestHalley(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		// x³ - 5 = 0
+		five := big.NewFloat(5)
+		f := func(x *big.Float) *big.Float {
+			r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+			return r.Mul(r, x).Sub(r, five)
+		}
+		df := func(x *big.Float) *big.Float {
+			r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+			return r.Mul(r, big.NewFloat(3))
+		}
+		d2f := func(x *big.Float) *big.Float {
+			return new(big.Float).SetPrec(x.Prec()).Mul(x, big.NewFloat(6))
+		}
+		third := new(big.Float).SetPrec(prec+64).Quo(big.NewFloat(1), big.NewFloat(3))
+		want := bigfloat.Pow(big.NewFloat(5).SetPrec(prec+64), third).SetPrec(prec)
+
+		x, err := bigfloat.Halley(f, df, d2f, big.NewFloat(0.5), prec)
+		if err != nil || x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, Halley(x³ - 5) =\ngot  %g (%v);\nwant %g", prec, x, err, want)
+		}
+	}
+}
+
+func TestRootErrors(t *testing.T) {
+	// x² + 1 has no real roots
+	one := big.NewFloat(1)
+	f := func(x *big.Float) *big.Float {
+		r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+		return r.Add(r, one)
+	}
+	df := func(x *big.Float) *big.Float {
+		return new(big.Float).SetPrec(x.Prec()).Add(x, x)
+	}
+	d2f := func(x *big.Float) *big.Float { return big.NewFloat(2) }
+
+	if _, err := bigfloat.Newton(f, df, big.NewFloat(0), 100); err != bigfloat.ErrDiverged {
+		t.Errorf("Newton(x² + 1) from 0: got error %v; want %v", err, bigfloat.ErrDiverged)
+	}
+	if _, err := bigfloat.Newton(f, df, big.NewFloat(0.75), 100); err != bigfloat.ErrNoConvergence {
+		t.Errorf("Newton(x² + 1) from 0.75: got error %v; want %v", err, bigfloat.ErrNoConvergence)
+	}
+	if _, err := bigfloat.Halley(f, df, d2f, big.NewFloat(0.75), 100); err == nil {
+		t.Errorf("Halley(x² + 1) from 0.75: got no error")
+	}
+}
+
+func TestBracketing(t *testing.T) {
+	two, three := big.NewFloat(2), big.NewFloat(3)
+	for _, solver := range []struct {
+		name  string
+		solve func(func(*big.Float) *big.Float, *big.Float, *big.Float, uint, int) (*big.Float, error)
+	}{
+		{"Bisection", bigfloat.Bisection},
+		{"Illinois", bigfloat.Illinois},
+		{"Brent", bigfloat.Brent},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			for _, test := range []struct {
+				name string
+				f    func(*big.Float) *big.Float
+				a, b float64
+				want *big.Float
+			}{
+				{
+					"x² - 2",
+					func(x *big.Float) *big.Float {
+						r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+						return r.Sub(r, two)
+					},
+					0, 2,
+					new(big.Float).SetPrec(prec).Sqrt(two),
+				},
+				{
+					"exp(x) - 3",
+					func(x *big.Float) *big.Float {
+						r := bigfloat.Exp(x)
+						return r.Sub(r, three)
+					},
+					5, -1,
+					bigfloat.Log(big.NewFloat(3).SetPrec(prec)),
+				},
+			} {
+				x, err := solver.solve(test.f, big.NewFloat(test.a), big.NewFloat(test.b), prec, 10000)
+				if err != nil {
+					t.Errorf("prec = %d, %s(%s) returned error %v", prec, solver.name, test.name, err)
+					continue
+				}
+				if d := new(big.Float).Sub(x, test.want); d.Sign() != 0 && d.MantExp(nil)-test.want.MantExp(nil) > -int(prec)+2 {
+					t.Errorf("prec = %d, %s(%s) =\ngot  %g;\nwant %g", prec, solver.name, test.name, x, test.want)
+				}
+			}
+		}
+
+		f := func(x *big.Float) *big.Float { return new(big.Float).Mul(x, x) }
+		if _, err := solver.solve(f, big.NewFloat(-1), big.NewFloat(2), 100, 1000); err != bigfloat.ErrNoBracket {
+			t.Errorf("%s(x²) on [-1, 2]: got error %v; want %v", solver.name, err, bigfloat.ErrNoBracket)
+		}
+		g := func(x *big.Float) *big.Float {
+			r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+			return r.Mul(r, x).Sub(r, two)
+		}
+		if _, err := solver.solve(g, big.NewFloat(0.125), big.NewFloat(10), 1000, 3); err != bigfloat.ErrNoConvergence {
+			t.Errorf("%s(x³ - 2) in 3 steps: got error %v; want %v", solver.name, err, bigfloat.ErrNoConvergence)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkNewton(b *testing.B) {
+	two := big.NewFloat(2)
+	f := func(x *big.Float) *big.Float {
+		r := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+		return r.Sub(r, two)
+	}
+	df := func(x *big.Float) *big.Float {
+		return new(big.Float).SetPrec(x.Prec()).Add(x, x)
+	}
+	for _, prec := range []uint{1e2, 1e3} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Newton(f, df, big.NewFloat(1), prec)
+			}
+		})
+	}
+}
+
+func BenchmarkBrent(b *testing.B) {
+	three := big.NewFloat(3)
+	f := func(x *big.Float) *big.Float {
+		r := bigfloat.Exp(x)
+		return r.Sub(r, three)
+	}
+	for _, prec := range []uint{1e2, 1e3} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Brent(f, big.NewFloat(0), big.NewFloat(2), prec, 10000)
+			}
+		})
+	}
+}
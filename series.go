@@ -0,0 +1,354 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// SumRichardson returns the sum of the series with terms term(k),
+// k = 0, 1, 2, ..., computed to prec bits of precision using
+// Richardson extrapolation on its partial sums.
+//
+// Richardson extrapolation works when the n-th partial sum behaves
+// like S + c₁/n + c₂/n² + ..., as it happens for series of rational
+// functions such as Σ 1/(k + 1)², that converge slowly, and it is not
+// suited to alternating series.
+//
+// term(k, wprec) must return the k-th term accurate to wprec bits of
+// precision. The number of terms is doubled, starting from 8, until
+// the results for two successive numbers of terms agree to prec bits,
+// and SumRichardson returns ErrNoConvergence if this does not happen
+// when the number of terms exceeds maxTerms.
+func SumRichardson(term func(k int, prec uint) *big.Float, prec uint, maxTerms int) (*big.Float, error) {
+	return sumAccelerated(term, prec, maxTerms, richardson)
+}
+
+// SumAitken returns the sum of the series with terms term(k),
+// k = 0, 1, 2, ..., computed to prec bits of precision by applying
+// Aitken's Δ² process repeatedly to its partial sums.
+//
+// The Δ² process is effective on series that converge linearly, or
+// that diverge, like geometric ones, and on alternating series such
+// as Σ (-1)ᵏ/(k + 1), but not on series that converge logarithmically
+// such as Σ 1/(k + 1)². The requirements on term and maxTerms are the
+// same as in SumRichardson.
+func SumAitken(term func(k int, prec uint) *big.Float, prec uint, maxTerms int) (*big.Float, error) {
+	return sumAccelerated(term, prec, maxTerms, aitken)
+}
+
+// SumLevin returns the sum of the series with terms term(k),
+// k = 0, 1, 2, ..., computed to prec bits of precision using the Levin
+// u-transform of its partial sums.
+//
+// The Levin u-transform is effective both on alternating series and
+// on series that converge logarithmically, such as Σ 1/(k + 1)², and
+// it is often the best choice when little is known about the series.
+// It uses the terms as estimates of the remainders, so they must not
+// be zero: SumLevin returns ErrDiverged if one of them is. The
+// requirements on term and maxTerms are the same as in SumRichardson.
+func SumLevin(term func(k int, prec uint) *big.Float, prec uint, maxTerms int) (*big.Float, error) {
+	return sumAccelerated(term, prec, maxTerms, levin)
+}
+
+// sumAccelerated returns the sum of the series with terms term(k),
+// computed to prec bits of precision by applying the sequence
+// transformation transform to its partial sums s[0], ..., s[n], where
+// s[j] is the sum of the first j terms.
+//
+// transform returns its result at the precision of the partial sums,
+// together with the binary exponent of the largest quantity involved
+// in its computation, which we use to increase the precision when the
+// result suffers from cancellation.
+func sumAccelerated(term func(k int, prec uint) *big.Float, prec uint, maxTerms int, transform func(s []*big.Float) (*big.Float, int, error)) (*big.Float, error) {
+
+	var prev *big.Float
+	guard := uint(0)
+	for n := 8; n <= maxTerms; n *= 2 {
+
+		// the rounding errors of the n-1 additions in the partial
+		// sums are compensated by bits.Len(n) bits
+		var r *big.Float
+		for {
+			wprec := prec + 64 + uint(bits.Len(uint(n))) + guard
+			s := make([]*big.Float, n+1)
+			s[0] = new(big.Float).SetPrec(wprec)
+			for k := 0; k < n; k++ {
+				s[k+1] = new(big.Float).SetPrec(wprec).Add(s[k], term(k, wprec))
+			}
+
+			x, scale, err := transform(s)
+			if err != nil {
+				return nil, err
+			}
+			// A zero result is taken as exact, since retrying would
+			// not end if it is.
+			if lost := lostBits(x, scale, wprec); lost > int(guard) && x.Sign() != 0 {
+				guard = uint(lost)
+				continue
+			}
+			r = x
+			break
+		}
+
+		if prev != nil && seriesConverged(r, prev, prec) {
+			return r.SetPrec(prec), nil
+		}
+		prev = r
+	}
+
+	return nil, ErrNoConvergence
+}
+
+// seriesConverged reports whether x and prev, the results for two
+// successive numbers of terms, agree to prec bits. prev is the less
+// accurate one, so when they agree x is accurate too.
+func seriesConverged(x, prev *big.Float, prec uint) bool {
+	if x.Cmp(prev) == 0 {
+		return true
+	}
+	if x.Sign() == 0 {
+		return false
+	}
+	d := new(big.Float).Sub(x, prev)
+	return d.MantExp(nil) < x.MantExp(nil)-int(prec)-4
+}
+
+// richardson returns the Richardson extrapolation of the partial sums
+// s, and the exponent of the largest quantity involved.
+func richardson(s []*big.Float) (*big.Float, int, error) {
+
+	// If the n-th partial sum is S + c₁/n + ... + c_N/nᴺ + O(1/nᴺ⁺¹),
+	// then S is
+	//     1/N! Σₖ (-1)ᵏ⁺ᴺ (N choose k) (N + k)ᴺ s[N + k]
+	// up to O(1/nᴺ⁺¹), where the sum runs from k = 0 to N. The
+	// weights are integers, and they are computed exactly.
+	N := (len(s) - 1) / 2
+	prec := s[0].Prec()
+
+	r := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	w, binom := new(big.Int), big.NewInt(1)
+	scale := math.MinInt32
+	for k := 0; k <= N; k++ {
+		w.Exp(big.NewInt(int64(N+k)), big.NewInt(int64(N)), nil)
+		w.Mul(w, binom)
+		if (k+N)%2 == 1 {
+			w.Neg(w)
+		}
+		t.SetInt(w)
+		t.Mul(t, s[N+k])
+		if t.Sign() != 0 && t.MantExp(nil) > scale {
+			scale = t.MantExp(nil)
+		}
+		r.Add(r, t)
+
+		// (N choose k+1) = (N choose k)·(N - k)/(k + 1)
+		binom.Mul(binom, big.NewInt(int64(N-k)))
+		binom.Quo(binom, big.NewInt(int64(k+1)))
+	}
+
+	f := new(big.Float).SetInt(new(big.Int).MulRange(1, int64(N)))
+	scale -= f.MantExp(nil) - 1
+	return r.Quo(r, f), scale, nil
+}
+
+// aitken returns the result of applying Aitken's Δ² process
+// repeatedly to the partial sums s, and the exponent of the largest
+// quantity involved.
+func aitken(s []*big.Float) (*big.Float, int, error) {
+
+	// Each application of
+	//     a[i] ← a[i+2] - (a[i+2] - a[i+1])²/((a[i+2] - a[i+1]) - (a[i+1] - a[i]))
+	// shortens the sequence by two. The division amplifies the
+	// rounding errors of the differences by a factor of about
+	// |Δa|/|Δ²a|, and the amplifications of successive applications
+	// multiply, so we add up the bits they cost.
+	a := s[1:]
+	prec := a[0].Prec()
+	lost := 0
+	d1 := new(big.Float).SetPrec(prec)
+	d2 := new(big.Float).SetPrec(prec)
+	dd := new(big.Float).SetPrec(prec)
+	for len(a) >= 3 {
+		b := make([]*big.Float, len(a)-2)
+		l := 0
+		for i := range b {
+			d1.Sub(a[i+1], a[i])
+			d2.Sub(a[i+2], a[i+1])
+			dd.Sub(d2, d1)
+			b[i] = new(big.Float).SetPrec(prec).Set(a[i+2])
+			if dd.Sign() == 0 {
+				continue
+			}
+			if e := d2.MantExp(nil) - dd.MantExp(nil); e > l {
+				l = e
+			}
+			d2.Quo(d2.Mul(d2, d2), dd)
+			b[i].Sub(b[i], d2)
+		}
+		lost += l
+		a = b
+
+		// Once the last two values agree to the precision that is
+		// left, further applications would only amplify the noise.
+		if n := len(a); n >= 2 {
+			d1.Sub(a[n-1], a[n-2])
+			if d1.Sign() == 0 || a[n-1].Sign() != 0 && d1.MantExp(nil)-a[n-1].MantExp(nil) < lost-int(prec) {
+				break
+			}
+		}
+	}
+
+	r := a[len(a)-1]
+	if r.Sign() == 0 {
+		return r, lost - int(prec), nil
+	}
+	return r, r.MantExp(nil) + lost, nil
+}
+
+// levin returns the Levin u-transform of the partial sums s, and the
+// exponent of the largest quantity involved. It returns ErrDiverged if
+// one of the terms is zero.
+func levin(s []*big.Float) (*big.Float, int, error) {
+
+	// With the partial sums Sⱼ = s[j+1], the terms aⱼ = Sⱼ - Sⱼ₋₁ and
+	// the remainder estimates ωⱼ = (j + 1)·aⱼ, the transform is
+	//     Σⱼ cⱼ·Sⱼ/ωⱼ / Σⱼ cⱼ/ωⱼ,  cⱼ = (-1)ʲ (k choose j) (j + 1)ᵏ⁻¹
+	// where the sums run from j = 0 to k = len(s) - 2. Both sums
+	// suffer from cancellation, and we keep track of the largest of
+	// their terms relative to the results.
+	k := len(s) - 2
+	prec := s[0].Prec()
+
+	num := new(big.Float).SetPrec(prec)
+	den := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	c := new(big.Float).SetPrec(prec)
+	w, binom := new(big.Int), big.NewInt(1)
+	numScale, denScale := math.MinInt32, math.MinInt32
+	for j := 0; j <= k; j++ {
+		// c/ω = c/((j + 1)·aⱼ)
+		t.Sub(s[j+1], s[j])
+		if t.Sign() == 0 {
+			return nil, 0, ErrDiverged
+		}
+		t.Mul(t, new(big.Float).SetInt64(int64(j+1)))
+		w.Exp(big.NewInt(int64(j+1)), big.NewInt(int64(k-1)), nil)
+		w.Mul(w, binom)
+		if j%2 == 1 {
+			w.Neg(w)
+		}
+		c.SetInt(w)
+		c.Quo(c, t)
+
+		if e := c.MantExp(nil); e > denScale {
+			denScale = e
+		}
+		den.Add(den, c)
+		c.Mul(c, s[j+1])
+		if c.Sign() != 0 && c.MantExp(nil) > numScale {
+			numScale = c.MantExp(nil)
+		}
+		num.Add(num, c)
+
+		binom.Mul(binom, big.NewInt(int64(k-j)))
+		binom.Quo(binom, big.NewInt(int64(j+1)))
+	}
+
+	// The relative error of the result is the sum of the relative
+	// errors of num and den, and we return a scale for which
+	// lostBits returns the sum of the bits lost by the two.
+	if den.Sign() == 0 {
+		return nil, 0, ErrDiverged
+	}
+	r := new(big.Float).SetPrec(prec).Quo(num, den)
+	if num.Sign() == 0 {
+		return r, numScale + int(prec), nil
+	}
+	lost := (numScale - num.MantExp(nil)) + (denScale - den.MantExp(nil))
+	return r, r.MantExp(nil) + lost, nil
+}
+
+// SumEulerMaclaurin returns the sum of the series with terms f(k, 0),
+// k = 0, 1, 2, ..., computed to prec bits of precision using the
+// Euler–Maclaurin formula.
+//
+// f(x, n) must return the n-th derivative of a smooth function f at
+// x, and f(x, -1) must return the antiderivative of f that vanishes
+// at +Inf, that is -∫ f(t) dt from x to +Inf, with the precision of
+// x. The sum of the first N terms is computed directly, and the one of
+// the remaining terms as -f(N, -1) + f(N, 0)/2 - Σⱼ B₂ⱼ/(2j)! f(N, 2j - 1),
+// where the series is asymptotic, so N is doubled, starting from 16,
+// until its terms become small enough. SumEulerMaclaurin returns
+// ErrNoConvergence if this does not happen when N exceeds maxTerms.
+func SumEulerMaclaurin(f func(x *big.Float, n int) *big.Float, prec uint, maxTerms int) (*big.Float, error) {
+
+	guard := uint(0)
+	for N := 16; N <= maxTerms; {
+		wprec := prec + 64 + uint(bits.Len(uint(N))) + guard
+		x, scale, ok := eulerMaclaurin(f, N, wprec)
+		if !ok {
+			N *= 2
+			continue
+		}
+		if lost := lostBits(x, scale, wprec); lost > int(guard) && x.Sign() != 0 {
+			guard = uint(lost)
+			continue
+		}
+		return x.SetPrec(prec), nil
+	}
+
+	return nil, ErrNoConvergence
+}
+
+// eulerMaclaurin returns the sum of the series with terms f(k, 0),
+// computed at precision prec with the Euler–Maclaurin formula at N,
+// and the exponent of the largest quantity involved. It returns false
+// if the terms of the asymptotic series start growing before they are
+// small enough.
+func eulerMaclaurin(f func(x *big.Float, n int) *big.Float, N int, prec uint) (*big.Float, int, bool) {
+
+	r := new(big.Float).SetPrec(prec)
+	x := new(big.Float).SetPrec(prec)
+	scale := math.MinInt32
+	add := func(t *big.Float) {
+		if t.Sign() != 0 && t.MantExp(nil) > scale {
+			scale = t.MantExp(nil)
+		}
+		r.Add(r, t)
+	}
+
+	for k := 0; k < N; k++ {
+		add(f(x.SetInt64(int64(k)), 0))
+	}
+
+	x.SetInt64(int64(N))
+	add(new(big.Float).Neg(f(x, -1)))
+	t := new(big.Float).SetPrec(prec).Set(f(x, 0))
+	add(t.SetMantExp(t, -1))
+
+	// B₂ⱼ/(2j)! is computed from the Bernoulli number, with the
+	// factorial updated at each step.
+	b := new(big.Float).SetPrec(prec)
+	fact := new(big.Float).SetPrec(prec).SetInt64(2)
+	last := new(big.Float)
+	for j := 1; ; j++ {
+		b.SetRat(bernoulli(2 * j))
+		b.Quo(b, fact)
+		t.Mul(f(x, 2*j-1), b).Neg(t)
+
+		// the terms of an asymptotic series decrease up to a point
+		// and then start growing
+		if t.Sign() == 0 || r.Sign() != 0 && t.MantExp(nil) < r.MantExp(nil)-int(prec) {
+			return r, scale, true
+		}
+		if j > 1 && t.MantExp(nil) > last.MantExp(nil) {
+			return nil, 0, false
+		}
+		add(t)
+		last.Set(t)
+
+		fact.Mul(fact, big.NewFloat(float64((2*j+1)*(2*j+2))))
+	}
+}
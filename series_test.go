@@ -0,0 +1,163 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// zetaTerm returns the terms of Σ 1/(k + 1)ˢ.
+func zetaTerm(s int) func(k int, prec uint) *big.Float {
+	return func(k int, prec uint) *big.Float {
+		x := new(big.Float).SetPrec(prec).SetInt64(int64(k + 1))
+		p := new(big.Float).SetPrec(prec).SetInt64(1)
+		for i := 0; i < s; i++ {
+			p.Mul(p, x)
+		}
+		return p.Quo(big.NewFloat(1), p)
+	}
+}
+
+// log2Term returns the terms of log(2) = Σ (-1)ᵏ/(k + 1).
+func log2Term(k int, prec uint) *big.Float {
+	x := new(big.Float).SetPrec(prec).SetInt64(int64(k + 1))
+	if k%2 == 1 {
+		x.Neg(x)
+	}
+	return x.Quo(big.NewFloat(1), x)
+}
+
+// log2GeometricTerm returns the terms of log(2) = Σ 1/((k + 1)·2ᵏ⁺¹).
+func log2GeometricTerm(k int, prec uint) *big.Float {
+	x := new(big.Float).SetPrec(prec).SetInt64(int64(k + 1))
+	x.SetMantExp(x, k+1)
+	return x.Quo(big.NewFloat(1), x)
+}
+
+type seriesTest struct {
+	name    string
+	term    func(k int, prec uint) *big.Float
+	want    func(prec uint) *big.Float
+	maxPrec uint
+}
+
+var (
+	zeta2Test = seriesTest{"ζ(2)", zetaTerm(2), func(prec uint) *big.Float {
+		return bigfloat.Zeta(big.NewFloat(2).SetPrec(prec))
+	}, 1000}
+	zeta4Test = seriesTest{"ζ(4)", zetaTerm(4), func(prec uint) *big.Float {
+		return bigfloat.Zeta(big.NewFloat(4).SetPrec(prec))
+	}, 1000}
+	log2Test = seriesTest{"log(2)", log2Term, func(prec uint) *big.Float {
+		return bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+	}, 1000}
+	log2GeometricTest = seriesTest{"log(2), geometric", log2GeometricTerm, func(prec uint) *big.Float {
+		return bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+	}, 1000}
+)
+
+func testSum(t *testing.T, name string, sum func(func(int, uint) *big.Float, uint, int) (*big.Float, error), tests []seriesTest) {
+	for _, test := range tests {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			if prec > test.maxPrec {
+				break
+			}
+			want := test.want(prec)
+			x, err := sum(test.term, prec, 1<<12)
+			if err != nil || x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, %s(%s) =\ngot  %g (%v);\nwant %g", prec, name, test.name, x, err, want)
+			}
+		}
+	}
+}
+
+func TestSumRichardson(t *testing.T) {
+	testSum(t, "SumRichardson", bigfloat.SumRichardson, []seriesTest{zeta2Test, zeta4Test})
+}
+
+func TestSumAitken(t *testing.T) {
+	// The iterated Δ² process costs O(n²) operations on n terms, and
+	// it needs thousands of them on log(2) at 1000 bits
+	log2, log2Geometric := log2Test, log2GeometricTest
+	log2.maxPrec, log2Geometric.maxPrec = 200, 500
+	testSum(t, "SumAitken", bigfloat.SumAitken, []seriesTest{log2, log2Geometric})
+}
+
+func TestSumLevin(t *testing.T) {
+	testSum(t, "SumLevin", bigfloat.SumLevin, []seriesTest{zeta2Test, zeta4Test, log2Test, log2GeometricTest})
+}
+
+// zeta3Derivative returns the n-th derivative of 1/(x + 1)³, or its
+// antiderivative -1/(2(x + 1)²) if n = -1.
+func zeta3Derivative(x *big.Float, n int) *big.Float {
+	prec := x.Prec()
+
+	// (d/dx)ⁿ (x + 1)⁻³ = (-1)ⁿ (n + 2)!/2 (x + 1)⁻⁽ⁿ⁺³⁾
+	y := new(big.Float).SetPrec(prec).Add(x, big.NewFloat(1))
+	p := new(big.Float).SetPrec(prec).SetInt64(1)
+	for i := 0; i < n+3; i++ {
+		p.Mul(p, y)
+	}
+	c := new(big.Float).SetPrec(prec).SetInt64(1)
+	if n >= 0 {
+		c.SetInt(new(big.Int).MulRange(1, int64(n+2)))
+	}
+	c.SetMantExp(c, -1)
+	if n%2 != 0 {
+		c.Neg(c)
+	}
+	return c.Quo(c, p)
+}
+
+func TestSumEulerMaclaurin(t *testing.T) {
+	for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+		want := bigfloat.Zeta(big.NewFloat(3).SetPrec(prec))
+		x, err := bigfloat.SumEulerMaclaurin(zeta3Derivative, prec, 1<<12)
+		if err != nil || x.Cmp(want) != 0 {
+			t.Errorf("prec = %d, SumEulerMaclaurin(ζ(3)) =\ngot  %g (%v);\nwant %g", prec, x, err, want)
+		}
+	}
+}
+
+func TestSumNoConvergence(t *testing.T) {
+	// Richardson extrapolation does not work on alternating series
+	if _, err := bigfloat.SumRichardson(log2Term, 100, 1<<8); err != bigfloat.ErrNoConvergence {
+		t.Errorf("SumRichardson(log(2)): got error %v; want %v", err, bigfloat.ErrNoConvergence)
+	}
+
+	zero := func(k int, prec uint) *big.Float {
+		if k == 3 {
+			return new(big.Float)
+		}
+		return log2Term(k, prec)
+	}
+	if _, err := bigfloat.SumLevin(zero, 100, 1<<8); err != bigfloat.ErrDiverged {
+		t.Errorf("SumLevin with a zero term: got error %v; want %v", err, bigfloat.ErrDiverged)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSumLevin(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.SumLevin(zetaTerm(2), prec, 1<<12)
+			}
+		})
+	}
+}
+
+func BenchmarkSumEulerMaclaurin(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.SumEulerMaclaurin(zeta3Derivative, prec, 1<<12)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// JacobiTheta returns a big.Float representation of the Jacobi theta
+// function θn(z, q), for n = 1, 2, 3 or 4, where q is the nome:
+//
+//	θ1(z, q) = 2 Σ (-1)^k q^((k+1/2)²) sin((2k+1)z)
+//	θ2(z, q) = 2 Σ q^((k+1/2)²) cos((2k+1)z)
+//	θ3(z, q) = 1 + 2 Σ q^(k²) cos(2kz)
+//	θ4(z, q) = 1 + 2 Σ (-1)^k q^(k²) cos(2kz)
+//
+// Precision is the same as the one of z. The function panics if n is
+// not 1, 2, 3 or 4, if z is infinite, or if q is not in [0, 1).
+func JacobiTheta(n int, z, q *big.Float) *big.Float {
+
+	prec := z.Prec()
+
+	switch {
+	case n < 1 || n > 4:
+		panic("JacobiTheta: n is not 1, 2, 3 or 4")
+	case q.Sign() < 0 || q.Cmp(big.NewFloat(1)) >= 0:
+		panic("JacobiTheta: nome is out of range")
+	case z.IsInf():
+		panic("JacobiTheta: argument is infinite")
+
+	// θ1(z, 0) = θ2(z, 0) = 0, θ3(z, 0) = θ4(z, 0) = 1
+	case q.Sign() == 0:
+		return big.NewFloat(float64(n / 3)).SetPrec(prec)
+
+	// θ1(±0, q) = ±0
+	case n == 1 && z.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(z)
+	}
+
+	// Reduce z as k(π/2) + r, |r| <= π/4. Since
+	//     θ1(z + π/2) = θ2(z), θ2(z + π/2) = -θ1(z)
+	//     θ3(z + π/2) = θ4(z), θ4(z + π/2) = θ3(z)
+	// we only need θn(r) for some n.
+	r, k := reduceHalfPi(z, prec+64)
+	m := int(new(big.Int).And(k, big.NewInt(3)).Int64())
+
+	neg := false
+	switch n {
+	case 1, 2:
+		// θ1(r + mπ/2) is θ1(r), θ2(r), -θ1(r) or -θ2(r)
+		m = (m + n - 1) % 4
+		n, neg = 1+m%2, m >= 2
+	case 3, 4:
+		n = 3 + (n-3+m)%2
+	}
+
+	x := theta(n, r, q, prec+64)
+	if neg {
+		x.Neg(x)
+	}
+	return x.SetPrec(prec)
+}
+
+// theta returns θn(r, q), for |r| <= π/4 and 0 < q < 1, computed to
+// prec bits of precision.
+func theta(n int, r, q *big.Float, prec uint) *big.Float {
+
+	// q = exp(-πt). Log has an absolute error of about 2**(-prec),
+	// and log(q) is about q - 1 when q is close to 1, so it needs
+	// -log2(1-q) extra bits.
+	one := big.NewFloat(1)
+	d := new(big.Float).SetPrec(exactSumPrec(q, one)).Sub(one, q)
+	wprec := prec
+	if e := d.MantExp(nil); e < 0 {
+		wprec += uint(-e)
+	}
+	pt := Log(new(big.Float).SetPrec(wprec).Set(q))
+	pt.Neg(pt).SetPrec(prec)
+
+	// The series converge quickly when q <= exp(-π), and otherwise
+	// we use the Jacobi imaginary transformation, which maps q to
+	// exp(-π/t) < exp(-π).
+	if pt.Cmp(pi(prec)) >= 0 {
+		return thetaSeries(n, r, q, prec)
+	}
+	return thetaModular(n, r, pt, prec)
+}
+
+// thetaSeries returns θn(r, q), for |r| <= π/4 and 0 < q <= exp(-π),
+// computed to prec bits of precision using the series in the
+// definition.
+func thetaSeries(n int, r, q *big.Float, prec uint) *big.Float {
+
+	// Since q is small and |r| <= π/4, θ2, θ3 and θ4 are not close
+	// to zero, and every term of θ1 is proportional to sin(r), so
+	// none of the sums suffers from cancellation.
+	wprec := prec + 16
+
+	s, c := sincos(new(big.Float).SetPrec(wprec).Set(r))
+	q2 := new(big.Float).SetPrec(wprec).Mul(q, q)
+
+	// sin(2r) and cos(2r), the step of the angles
+	s2 := new(big.Float).SetPrec(wprec).Mul(s, c)
+	s2.SetMantExp(s2, 1)
+	c2 := new(big.Float).SetPrec(wprec).Mul(s, s)
+	c2.Sub(big.NewFloat(1), c2.SetMantExp(c2, 1))
+
+	// For θ1 and θ2 the k-th term is q^(k(k+1)) times the sine or
+	// cosine of (2k+1)r, with q^((k+1)(k+2)) = q^(k(k+1))·q^(2k+2);
+	// for θ3 and θ4 it's q^(k²) times the cosine of 2kr, for k >= 1,
+	// with q^((k+1)²) = q^(k²)·q^(2k+1).
+	w := big.NewFloat(1).SetPrec(wprec) // q^(k(k+1)) or q^(k²)
+	v := new(big.Float).SetPrec(wprec)  // q^(2k+2) or q^(2k+1)
+	sm, cm := s, c
+	if n >= 3 {
+		w.Set(q)
+		v.Mul(q2, q)
+		sm, cm = new(big.Float).Set(s2), new(big.Float).Set(c2)
+	} else {
+		v.Set(q2)
+	}
+
+	sum := new(big.Float).SetPrec(wprec)
+	t := new(big.Float).SetPrec(wprec)
+	u := new(big.Float).SetPrec(wprec)
+	for k := 0; ; k++ {
+		if n == 1 {
+			t.Mul(w, sm)
+		} else {
+			t.Mul(w, cm)
+		}
+		// θ1 alternates from k = 0, θ4 from k = 1
+		if n == 1 && k%2 == 1 || n == 4 && k%2 == 0 {
+			t.Neg(t)
+		}
+		sum.Add(sum, t)
+
+		if w.MantExp(nil) < -int(wprec) {
+			break
+		}
+		w.Mul(w, v)
+		v.Mul(v, q2)
+
+		// rotate the angle by 2r
+		t.Mul(sm, c2)
+		u.Mul(cm, s2)
+		cm.Mul(cm, c2)
+		cm.Sub(cm, sm.Mul(sm, s2))
+		sm.Add(t, u)
+	}
+
+	if n >= 3 {
+		// θ = 1 + 2Σ
+		sum.SetMantExp(sum, 1)
+		return sum.Add(sum, big.NewFloat(1))
+	}
+
+	// θ = 2q^(1/4)Σ
+	t.Sqrt(q)
+	t.Sqrt(t)
+	sum.Mul(sum, t)
+	return sum.SetMantExp(sum, 1)
+}
+
+// thetaModular returns θn(r, q), for |r| <= π/4 and
+// exp(-π) < q = exp(-πt) < 1, where pt = πt, computed to prec bits
+// of precision.
+func thetaModular(n int, r, pt *big.Float, prec uint) *big.Float {
+
+	// Following DLMF 20.7.30-33, the Jacobi imaginary transformation
+	// turns θn(r, q) into t^(-1/2)·exp(-r²/(πt)) times a theta
+	// function of ir/t and exp(-π/t). Writing its hyperbolic
+	// functions as exponentials, and bringing the exp(-r²/(πt))
+	// factor into the sums, we get
+	//     θ1(r, q) = t^(-1/2) Σ (-1)^k exp(-(π/t)(k + 1/2 - r/π)²)
+	//     θ2(r, q) = t^(-1/2) Σ (-1)^k exp(-(π/t)(k - r/π)²)
+	//     θ3(r, q) = t^(-1/2) Σ exp(-(π/t)(k - r/π)²)
+	//     θ4(r, q) = t^(-1/2) Σ exp(-(π/t)(k + 1/2 - r/π)²)
+	// over all the integers k, where the terms decrease quickly
+	// since π/t > π.
+	//
+	// The absolute error on the arguments of exp, which are less
+	// than prec·log(2) for the terms that matter, becomes a relative
+	// error in the terms, so we need log2(prec) extra bits; and θ1
+	// is small when r is, while its terms are not.
+	base := uint(bits.Len(prec)) + 8
+	guard := uint(0)
+	for {
+		wprec := prec + base + guard
+
+		p := pi(wprec)
+		a := new(big.Float).SetPrec(wprec).Mul(p, p)
+		a.Quo(a, pt) // π/t
+		rho := new(big.Float).SetPrec(wprec).Quo(r, p)
+		if n == 1 || n == 4 {
+			rho.Sub(big.NewFloat(0.5), rho)
+		} else {
+			rho.Neg(rho)
+		}
+
+		// the terms are largest for k = 0, and k = -1 for θ1 and θ4
+		sum := new(big.Float).SetPrec(wprec)
+		scale := 0
+		x := new(big.Float).SetPrec(wprec)
+		for _, dir := range []int{1, -1} {
+			for k := (1 - dir) / -2; ; k += dir {
+				x.SetInt64(int64(k))
+				x.Add(x, rho)
+				x.Mul(x, x)
+				x.Mul(x, a)
+				t := Exp(x.Neg(x))
+				if (n == 1 || n == 2) && k%2 != 0 {
+					t.Neg(t)
+				}
+				sum.Add(sum, t)
+
+				e := t.MantExp(nil)
+				if k == 0 {
+					scale = e
+				}
+				if e < scale-int(wprec) {
+					break
+				}
+			}
+		}
+
+		if lost := lostBits(sum, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+
+		// t = pt/π
+		x.Quo(pt, p)
+		sum.Quo(sum, x.Sqrt(x))
+		return sum.SetPrec(prec)
+	}
+}
@@ -0,0 +1,133 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestJacobiTheta(t *testing.T) {
+	for _, test := range []struct {
+		n    int
+		z, q string
+		want string
+	}{
+		{1, "0.5", "0.125", "5.51604161962946274354292860804570850402263426256996533936333951798158190919767518301923492222188060954697645806776688972778993244127304701774620965904868878190780093801118777953762135083694419643507152487774758038829093860379747900076217063431660577081036292998050817591932733501539596651700469487850596004468515646700851848152992900357913104923823191093287578e-1"},
+		{1, "1.25", "0.5", "1.83517751564656868710519093826498279856561174391326245230206519801124272024215126634854645891061380872014002683198187709546892398904075490898675612546375499829092834976960465445047409651641020573691135046230776055688351541147454120943347028479797488362743079890159549884366213392703600921985508540910118285728237388121876741842473774012590706204180053316813017e+0"},
+		{1, "-2.5", "0.875", "-7.54427132232503003247331307524750952049230751188117594436749769167391864715796509478288027677328079479766391864767406369175181033033502716656060476873890954050039242440809262884569282181984947031777596659598928290373952937585605431895443268213647914670423888178908788964587179700724070434612946335358670918912812017426971408419946211736509918739381054868637766e-3"},
+		{1, "10", "0.03125", "-4.56654042554714841984023063886086396069080054921315500551160768351164854013511727288949247296917375147127044793669947364737239784953608634588701547852397796041400567072397875364456920650096393456203675420104191564235400646255575864632157368805309448232893726804654239999891554515402742838250501937044339461075341194776930109364105133103132059969010343827629220e-1"},
+		{1, "0.75", "0.9921875", "9.92360785854997252169464358626078473513947716454012271362801959743562254434035202089818604654653200098143703798941038387847255320318691155499371730586740231149413399179268390136239390989149244989580275450080826887864439461114602134158514297423263050010050545242105463687312218439139051251481576942492145261386543793088470952234837299237303783905430687916635807e-37"},
+		{1, "1p-40", "0.5", "4.99293066750706713587477909912498528900727320477630488648604788774717242117117767778091947354263301055605074009874931052084985655694022627468159370738969509016434420454732874710421969148482004853806504419590846145477300100321800005049254977862871698682483630629690158626542332779733123136178448560277888229697032709018797350210357315317761683902165789280217980e-13"},
+		{1, "3.140625", "0.25", "1.11355098820099979652014587419996486658643756382839987930552206494097419967895321270740841832564001094318451828465583307245861429124756825728366352743489863288629593919099853527406263770055682590903587035123252475139263263820087174744586796697339699890959138380011304839785027144014200296181269967525804096058366150329040950383087864676189844454064113680192907e-3"},
+		{1, "1.5", "0.04296875", "9.09942572993133345763794737960316121293871398644321219004238207618479083974212712027053210779836579450224874605147071678347859350104446222161590191877508639851204664681234334571880737095150571000841502025820240942488018318094172005474657946938952751743256024470896690855952261560093723491145374778907121798601447235931035739821963717084348449403126554625220135e-1"},
+		{1, "1.5", "0.04345703125", "9.12554363441195683360565020273231390024531574706864517588742888288944404104864495845700884596413650775862370387663974966998606150574259899892165936978122917690153157930425516044397150060690743809130083108525206418030119976794484958156578568373353162927590192956717152695457977267079340157576990869065893018314427330369405422617741719262875443834507383752187461e-1"},
+		{2, "0.5", "0.125", "1.04493818571854384879907701611114471615511166706137445627363403812603514228876191864566605677905524438860377414082111384443289482118556970569737404540992214435363826855791844094931092387145787919646643548724900500138210266872032996227901800744220330224355678677852303401440914728565930119974228143088152417875680127799335532455289111454027008280246098136880085e+0"},
+		{2, "1.25", "0.5", "2.11247515867782712788397954390440038656131949008845024923377753145643904416786502647177204167906656656592024026188449307396414487127999202430656192272595587770250266595226621523554179879840087855929201747295181965135618893760335461880355858015989444359005239535427333953693703914785193563911922965467822215896867188364508580920813106827383673575811887563128486e-1"},
+		{2, "-2.5", "0.875", "-2.22316109601390001777957228662452426388618289407486884359362545439695102619896745991579677723907092880750707473058355264756937343700108342869215917339817051479209181333814115090517216310115692433837039970869440375258163025003236092569425138788653477022136361222143005203224485163211256374256062577321767536411845983818152498036318939593809177615606497076143307e-1"},
+		{2, "10", "0.03125", "-7.05445570761075876488229128815070506526358569661107662185618533600438465775393067919776221493531298471292020333035752573133675757703693313783886997981974649128035301742306946803127174395288855103107253110782428776012085998420729187884660752281335690450424019241546113054748491395418789974119312814109872943094822151956200014017234249305228868920824535347415853e-1"},
+		{2, "0.75", "0.9921875", "1.42702377684829903455250594594625836565316799017046538966557342925782249867270307603734386997886765395207273507319953212785333559525590060003635549265926777788688751641051473410493838381993380733548468284050475492885701191381400418804224790149546257628106595969180904352801473727470221280104478494987816842694946865028686589452498390066887916027612582156764621e-30"},
+		{2, "1p-40", "0.5", "2.12893125051302755859161086187924970035381462273101352367390011772630173793581103622594196070210020843855788226143128914108146742481549974193736398362057234323757191029163360376915023258708021767450402447108264702608252805429040759641443872075516813958746703570922916569868694607582173305187340932953779093251001759804631797647057928634629859671762475151459740e+0"},
+		{2, "3.140625", "0.25", "-1.50294622272243588115892442810895045200867280568433236913619730130336639803985661383111277664130841807617208356976505534249341545371352309670115852612061457691961586102055803629736530941644357163407097434671465714275280989059327862793797255052811688698188962668859943803113056817879624312782901914498762350745129364302520169243716884390917452089353494073948259e+0"},
+		{2, "1.5", "0.04296875", "6.40574998732106209464380115893878873505891650121927399428623100816487296395701151114462900896263526342138120979677145870283748875572008945138392902004711530384813214464090941637855574937625249884449225400625772984920048001460357432871020890321801281846466127562588128855990056255958481485844903431654296532047605974853976794775806765972099372278689144071110469e-2"},
+		{2, "1.5", "0.04345703125", "6.42305880855482636119597678442798062386839775715450516619705739886078528074527076130259237165606027632985235087113878409897840143236876819789339490370134491143048701754000946400535062825182259996153996928826628686751757740429786117054023941742370787378952937306981160503777105984745009041212569085701664375516133557228926250398898531856369257740071526539114971e-2"},
+		{2, "0", "0.5", "2.12893125051302755859161340257535018085380539695844894096899554942105493240478171588051817328905014795407532434338534232767057669351159955334890532051621882068299178765258025217841320046259732196463839310285591604104664987363507039442072992521165314301788511242776561384785545574388058971044980238541643300350747899642643726123063136069776202172733465810942271e+0"},
+		{3, "0.5", "0.125", "1.13487236501745972802141660515988352629583403016020454954589116872450085852006805908349342827780326468751052081793547682529221922288033571745007482048403268462061043543332554837446487038507700575796106606970201317231729641526126441230347440793937337370326850895678308283361396816320207763815074495105036665636286073139819556722134645287322193850997161234804737e+0"},
+		{3, "1.25", "0.5", "2.35642654866624523323199121593762937131665273300369676861567455265307682010616884867905670416136883115723264340231810967109682989603670459682437126981730304601491288069796992556529984393854682826506292974622088187418214801602341563012801286792445698946759874714875949903950885731488841898097350428803374839666179906525421534775069780204039653686353256691910273e-1"},
+		{3, "-2.5", "0.875", "2.22316109601390001823608678048868680676807094537460743644710027576178376151396235808916005182660605984637758785882967424346872568419117082007594502566535196510309517353988584846733935690773712332197752878996995152149184903506269092164751059270898109373139547480783474337777330798945828417511235327753386016681272770883164327604368247789024041129619792826191794e-1"},
+		{3, "10", "0.03125", "1.02550385677988279765845366515891489128357502177662321067363933378632174976424509192377597828733473636337735362421069162062981712879715361623889822778436623319051367413143620430775777422152398078630716633550071976453198711147234688954309200527710537759647474415727329405856879712681433683237631490902279978635983786598336588931178554133639220604516174318765727e+0"},
+		{3, "0.75", "0.9921875", "1.42702377684829903455250594594625836565316799017046538966557342925782249867270307603734386997886765395207273507319953212785333559525590060003635549265926777788688751641051473410493838381993380733548468284050475492885701191381400418804224790149546257628106595969180904352801473727470221357510492147033642328124795139698397268711488849314407805957315362768359114e-30"},
+		{3, "1p-40", "0.5", "2.12893682721187715866945600803171635449464424167527978693428088202977615473388087193420563091793758267542576088928233173728821368817370934400801061534642267414828165264932513338943208330195258552927524930454340138672571220714724166195204061213804393752941219634023132018927583423295942626430501819287326846319897400679959070138622269688070606927749621521601669e+0"},
+		{3, "3.140625", "0.25", "1.50781913485639575231292651181052841210960909044825830489693230027085635804357245099738428736219150839815709997389387317584175949324670859556341928089753889450037048193975651977667178018844264702373288884117221936865121555626274073114572904542345756605465075343115271815251264703361149791566032146077403505067887750987404363955654263905548143800844283871831954e+0"},
+		{3, "1.5", "0.04296875", "9.14929066020491794406844763741851451382845943146188413076971074684401010278799724217472293408213368625931313521553184208509863695857210676421189170209278985826860531103558146803594159693982962986915491847267340002277200019060188204458382786192054482222494488861825530387976513088349970657709743540623767219280071285454232212280256919564057842362381348775790720e-1"},
+		{3, "1.5", "0.04345703125", "9.13962579137871640132532729634270270533531467259650540858552986842317700130926865219331815269874652510430653069747197050672207729513180950005070993882587093430553158977110762857100882388683384331813323338407769956903554694142867000815748243956900035415845462677221000089535695930974664043969127869872702995128095161964784449363726800333953078505335566886702829e-1"},
+		{3, "0", "0.5", "2.12893682721187715866945854854495132461251653994087809288901119205696026635915681331826128032493829689480494322337041223085083852053749332319885546762965911794912547835398768865344370665672109762922278069280554281250452871042787719820290858018866322460411137354186126346708963964372384383211779145643264732947034213584589768548385494099980095394439575613570945e+0"},
+		{4, "0.5", "0.125", "8.64721241587465414406749470745816106496835730535972218583786917664818814480618878361328763126774741958164194598651009199124867809610825154101805708385957509726307800851696496090854991497589466151725414762612922590355427257763127594368365392961946312834234271288327694992947378747797569149163672218659748827448321506575863457481355299611405342208600138812920134e-1"},
+		{4, "1.25", "0.5", "1.83522167859308027875658400268591382463354557297512225614206532466889201808352233350852795432673217330271591075019062584016082812379265422122060556511850669524029815825133720864233346399915222570247393489466827455960472722244040813886556999136874220278053866117339014285226537781563137310454578875109999347590562766656677466279125349039439431013498647919781489e+0"},
+		{4, "-2.5", "0.875", "7.54427132232619822782913370449845224339323883257826427569530439864509651240493097648059346133106637787676188088435938695142457357454614838584208241093349295873676479330234807228658594041714045449471447799621037804349840884142151892523671381049230207618405307515717755792543404643864933029798118584038643745338425013381113059132367757730647113527559799303649739e-3"},
+		{4, "10", "0.03125", "9.74493599053317076220618601082335712636523585171585385328815934634513141071179887815069252297277971196892240781910802777927217555747848529278592600534827309914044178882658472057472144861589267171572035625508765293833233310733832060816561015628837084305894750842317451905088337839246855428674585407252393298568330828581228794139466734425662584436740262352036637e-1"},
+		{4, "0.75", "0.9921875", "9.92360785854997252169464358626078473513947716454012271362801959743562254434035202089818604654653200098143703798941038387847255320318691155499371730586740231149413399179268390136239390989149244989580275450080826887864439461114602134158514297423263050010050545244407221082388281667653236901358973230671083023953358325838259032231169585594632677313975423166236264e-37"},
+		{4, "1p-40", "0.5", "1.21124208002580502460850177718286769305825768889210875758627951684868386838642418419007551496022508793737460118222147125206091549311190245689000357629378963760168820985053263778672539161291559157114466037947816159478394514022363363248065447274036063015919542434645580966380929578418260978066852315498973971941205015591037925648412070828170536680292245643458687e-1"},
+		{4, "3.140625", "0.25", "5.07805749030861313197591780279447724569599645086892849621498521995716810588283663173166608761296619498786302529109707197642200726926535536219168953172835302205984843892828598625919685231763196774107864808471627497595608963061549459900546229470967792151558333939210350318277741930830207852023718947632013253606405373864674924044433454882514441036806290114706407e-1"},
+		{4, "1.5", "0.04296875", "1.08508402637551312083933290256848286096391656602892319440988357102343440149551987649141153789235320037661341321474707720726632300174051696547870352462042484434369271680121313946034798454390070226408510801172170289036636259579633014887118794415200754024348800123197289982992959488033948578270488203934194513372821370659384801505064447053596698172112016605254768e+0"},
+		{4, "1.5", "0.04345703125", "1.08605111858801051159137599554199580957667037094807989881487602898180848193177914124616561326727331255147753033435007375769039060135467131939320929665068496513850338860056807991735111011955478771627658343440755041888022242078982455831876560565115122253894874161836191970714346971176768066383973053121886484445439798878686668952108664365572383427122572861675274e+0"},
+		{4, "0", "0.5", "1.21124208002580502460849293181867505809858246820960597233901108396897531797504349258512966165378178073175279042782661604882934200082513480978578334431768132669756301417896065956497284780590007111673893730035068559758689819517500503641380793829296215158899804215170335850895538254875532347475099839196548584444268303127363886915340420324415118056471717474269753e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			z := new(big.Float).SetPrec(prec)
+			z.Parse(test.z, 10)
+
+			q := new(big.Float).SetPrec(prec)
+			q.Parse(test.q, 10)
+
+			x := bigfloat.JacobiTheta(test.n, z, q)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, JacobiTheta(%v, %v, %v) =\ngot  %g;\nwant %g", prec, test.n, test.z, test.q, x, want)
+			}
+		}
+	}
+}
+
+func TestJacobiThetaIdentities(t *testing.T) {
+	// θ3(0, q)⁴ = θ2(0, q)⁴ + θ4(0, q)⁴
+	for _, q := range []float64{0.01, 0.0432, 0.25, 0.5, 0.9} {
+		for _, prec := range []uint{53, 100, 500, 1000} {
+			z := new(big.Float).SetPrec(prec)
+			y := new(big.Float).SetPrec(prec).SetFloat64(q)
+
+			var p [5]*big.Float
+			for n := 2; n <= 4; n++ {
+				x := bigfloat.JacobiTheta(n, z, y)
+				x.Mul(x, x)
+				p[n] = x.Mul(x, x)
+			}
+			d := new(big.Float).Add(p[2], p[4])
+			d.Sub(d, p[3])
+			if d.Sign() != 0 && d.MantExp(nil)-p[3].MantExp(nil) > 4-int(prec) {
+				t.Errorf("prec = %d, q = %g: Jacobi's identity is off by %g", prec, q, d)
+			}
+		}
+	}
+}
+
+func TestJacobiThetaSpecialValues(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"JacobiTheta(1, -0, 0.5)", bigfloat.JacobiTheta(1, big.NewFloat(math.Copysign(0, -1)), big.NewFloat(0.5)), math.Copysign(0, -1)},
+		{"JacobiTheta(1, 1, 0)", bigfloat.JacobiTheta(1, big.NewFloat(1), big.NewFloat(0)), 0},
+		{"JacobiTheta(2, 1, 0)", bigfloat.JacobiTheta(2, big.NewFloat(1), big.NewFloat(0)), 0},
+		{"JacobiTheta(3, 1, 0)", bigfloat.JacobiTheta(3, big.NewFloat(1), big.NewFloat(0)), 1},
+		{"JacobiTheta(4, 1, 0)", bigfloat.JacobiTheta(4, big.NewFloat(1), big.NewFloat(0)), 1},
+	} {
+		x, acc := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkJacobiTheta(b *testing.B) {
+	for _, q := range []float64{0.01, 0.5} {
+		for _, prec := range []uint{1e2, 1e3} {
+			z := big.NewFloat(1.25).SetPrec(prec)
+			y := big.NewFloat(q)
+			b.Run(fmt.Sprintf("q=%v/%v", q, prec), func(b *testing.B) {
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					bigfloat.JacobiTheta(3, z, y)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Sinc returns a big.Float representation of the unnormalized sinc
+// function sin(x)/x. Precision is the same as the one of x. The
+// function returns 1 when x = ±0 and 0 when x = ±Inf.
+func Sinc(x *big.Float) *big.Float {
+
+	prec := x.Prec()
+
+	switch {
+	// sinc(±0) = 1
+	case x.Sign() == 0:
+		return big.NewFloat(1).SetPrec(prec)
+
+	// sinc(±Inf) = 0
+	case x.IsInf():
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// sincos has full relative precision even close to the zeros of
+	// sin, and x is exact, so a few guard bits are enough.
+	sin, _ := sincos(new(big.Float).SetPrec(prec + 64).Set(x))
+	return sin.Quo(sin, x).SetPrec(prec)
+}
+
+// Si returns a big.Float representation of the sine integral Si(x),
+// the integral of sin(t)/t from 0 to x. Precision is the same as the
+// one of x. The function returns ±π/2 when x = ±Inf.
+func Si(x *big.Float) *big.Float {
+
+	prec := x.Prec()
+
+	switch {
+	// Si(±0) = ±0
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(x)
+
+	// Si(±Inf) = ±π/2
+	case x.IsInf():
+		r := pi(prec)
+		r.SetMantExp(r, -1)
+		return setSign(r, x.Signbit())
+	}
+
+	// Si is odd
+	r := sici(new(big.Float).Abs(x), false, prec+64)
+	return setSign(r, x.Signbit()).SetPrec(prec)
+}
+
+// Ci returns a big.Float representation of the cosine integral
+// Ci(x) = γ + log(x) + the integral of (cos(t) - 1)/t from 0 to x.
+// Precision is the same as the one of x. The function returns -Inf
+// when x = ±0 and 0 when x = +Inf, and panics if x < 0.
+func Ci(x *big.Float) *big.Float {
+
+	prec := x.Prec()
+
+	switch {
+	case x.Sign() < 0:
+		panic("Ci: argument is negative")
+
+	// Ci(±0) = -Inf
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInf(true)
+
+	// Ci(+Inf) = 0
+	case x.IsInf():
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return sici(x, true, prec+64).SetPrec(prec)
+}
+
+// FresnelS returns a big.Float representation of the Fresnel
+// integral S(x), the integral of sin(πt²/2) from 0 to x. Precision is
+// the same as the one of x. The function returns ±1/2 when x = ±Inf.
+func FresnelS(x *big.Float) *big.Float {
+	return fresnel(x, false)
+}
+
+// FresnelC returns a big.Float representation of the Fresnel
+// integral C(x), the integral of cos(πt²/2) from 0 to x. Precision is
+// the same as the one of x. The function returns ±1/2 when x = ±Inf.
+func FresnelC(x *big.Float) *big.Float {
+	return fresnel(x, true)
+}
+
+// fresnel returns C(x) if c is true, and S(x) otherwise, with the
+// precision of x.
+func fresnel(x *big.Float, c bool) *big.Float {
+
+	prec := x.Prec()
+
+	switch {
+	// S(±0) = C(±0) = ±0
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(x)
+
+	// S(±Inf) = C(±Inf) = ±1/2
+	case x.IsInf():
+		return setSign(big.NewFloat(0.5).SetPrec(prec), x.Signbit())
+	}
+
+	// S and C are odd
+	r := fresnelPos(new(big.Float).Abs(x), c, prec+64)
+	return setSign(r, x.Signbit()).SetPrec(prec)
+}
+
+// trigIntAsymptotic reports whether the asymptotic expansions of the
+// sine and cosine integrals in z, whose smallest term is about
+// exp(-z), reach prec bits of precision.
+func trigIntAsymptotic(z float64, prec uint) bool {
+	return z > float64(prec)*math.Ln2+math.Log(float64(prec))+4
+}
+
+// sici returns Ci(x) if ci is true, and Si(x) otherwise, for x > 0,
+// computed to prec bits of precision.
+func sici(x *big.Float, ci bool, prec uint) *big.Float {
+
+	// Ci has zeros, close to which the sums cancel, and for
+	// moderately large x the terms of the series grow to about
+	// exp(x) while the results stay below 2.
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		var r *big.Float
+		var scale int
+		if xf, _ := x.Float64(); trigIntAsymptotic(xf, wprec) {
+			r, scale = siciAsymptotic(x, ci, wprec)
+		} else {
+			r, scale = siciSeries(x, ci, wprec)
+		}
+
+		if lost := lostBits(r, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec)
+	}
+}
+
+// siciSeries returns Ci(x) if ci is true, and Si(x) otherwise, for
+// x > 0, computed to prec bits of precision using their series; and
+// the binary exponent of the largest term.
+func siciSeries(x *big.Float, ci bool, prec uint) (*big.Float, int) {
+
+	// Si(x) = Σ (-1)^k x^(2k+1)/((2k+1)·(2k+1)!), for k >= 0
+	if !ci {
+		return trigIntSeries(x, 1, 1, 0, prec)
+	}
+
+	// Ci(x) = γ + log(x) + Σ (-1)^k x^(2k)/(2k·(2k)!), for k >= 1
+	r := EulerGamma(prec)
+	r.Add(r, Log(new(big.Float).SetPrec(prec).Set(x)))
+	s, scale := trigIntSeries(x, 2, 1, 0, prec)
+	if e := r.MantExp(nil); e > scale {
+		scale = e
+	}
+	return r.Sub(r, s), scale
+}
+
+// siciAsymptotic returns Ci(x) if ci is true, and Si(x) otherwise,
+// for large x, computed to prec bits of precision using their
+// asymptotic expansions; and the binary exponent of the largest
+// term.
+func siciAsymptotic(x *big.Float, ci bool, prec uint) (*big.Float, int) {
+
+	// Si(x) = π/2 - f(x)cos(x) - g(x)sin(x)
+	// Ci(x) = f(x)sin(x) - g(x)cos(x)
+	// where
+	//     f(x) ~ 1/x · Σ (-1)^k (2k)!/x^(2k)
+	//     g(x) ~ 1/x² · Σ (-1)^k (2k+1)!/x^(2k)
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	f := trigIntAux(x2, 2, 1, 1, prec)
+	f.Quo(f, x)
+	g := trigIntAux(x2, 2, 0, 1, prec)
+	g.Quo(g, x2)
+
+	sin, cos := sincos(new(big.Float).SetPrec(prec).Set(x))
+	scale := f.MantExp(nil)
+	if ci {
+		f.Mul(f, sin)
+		g.Mul(g, cos)
+		return f.Sub(f, g), scale
+	}
+
+	f.Mul(f, cos)
+	g.Mul(g, sin)
+	r := pi(prec)
+	r.SetMantExp(r, -1)
+	r.Sub(r, f)
+	return r.Sub(r, g), 1
+}
+
+// fresnelPos returns C(x) if c is true, and S(x) otherwise, for
+// x > 0, computed to prec bits of precision.
+func fresnelPos(x *big.Float, c bool, prec uint) *big.Float {
+
+	// With z = πx²/2, we have
+	//     S(x) = x·Σ (-1)^k z^(2k+1)/((2k+1)!·(4k+3))
+	//     C(x) = x·Σ (-1)^k z^(2k)/((2k)!·(4k+1))
+	// for k >= 0. For moderately large x the terms grow to about
+	// exp(z) while the sums stay below 1. z is rounded with as many
+	// extra bits as its exponent,
+	// since its absolute error becomes a relative error in the terms.
+	n0 := int64(1)
+	if c {
+		n0 = 0
+	}
+	x2 := new(big.Float).SetPrec(2*x.MinPrec()).Mul(x, x)
+	guard := uint(0)
+	for {
+		wprec := prec + guard
+
+		if zf, _ := x2.Float64(); trigIntAsymptotic(zf*math.Pi/2, wprec) {
+			return fresnelAsymptotic(x, x2, c, wprec).SetPrec(prec)
+		}
+
+		z := new(big.Float).SetPrec(wprec + uint(bitsLen(x2)))
+		z.Mul(pi(z.Prec()), x2)
+		z.SetMantExp(z, -1)
+		r, scale := trigIntSeries(z, n0, 2, 1, wprec)
+		r.Mul(r, x)
+
+		scale += x.MantExp(nil)
+		if lost := lostBits(r, scale, wprec); lost > int(guard) {
+			guard = uint(lost)
+			continue
+		}
+		return r.SetPrec(prec)
+	}
+}
+
+// fresnelAsymptotic returns C(x) if c is true, and S(x) otherwise,
+// for large x, computed to prec bits of precision using their
+// asymptotic expansions. x2 must be x² computed exactly.
+func fresnelAsymptotic(x, x2 *big.Float, c bool, prec uint) *big.Float {
+
+	// C(x) = 1/2 + f(x)sin(πx²/2) - g(x)cos(πx²/2)
+	// S(x) = 1/2 - f(x)cos(πx²/2) - g(x)sin(πx²/2)
+	// where, with y = πx²,
+	//     f(x) ~ 1/(πx) · Σ (-1)^k (4k-1)!!/y^(2k)
+	//     g(x) ~ 1/(π²x³) · Σ (-1)^k (4k+1)!!/y^(2k)
+	// The terms are about 1/x, so the result, which is close to 1/2,
+	// does not suffer from cancellation.
+	p := pi(prec)
+	y := new(big.Float).SetPrec(prec).Mul(p, x2)
+	y2 := new(big.Float).Mul(y, y)
+
+	f := trigIntAux(y2, 4, 3, 2, prec)
+	f.Quo(f, p)
+	f.Quo(f, x)
+	g := trigIntAux(y2, 4, 1, 2, prec)
+	g.Quo(g, y)
+	g.Quo(g, p)
+	g.Quo(g, x)
+
+	// sin(πx²/2) and cos(πx²/2), with an exact reduction
+	h := new(big.Float).SetPrec(prec)
+	if mp := x2.MinPrec(); mp > prec {
+		h.SetPrec(mp)
+	}
+	h.SetMantExp(h.Set(x2), -1)
+	sin, cos := sincosPi(h)
+
+	r := big.NewFloat(0.5).SetPrec(prec)
+	if c {
+		r.Add(r, f.Mul(f, sin))
+		return r.Sub(r, g.Mul(g, cos))
+	}
+	r.Sub(r, f.Mul(f, cos))
+	return r.Sub(r, g.Mul(g, sin))
+}
+
+// trigIntSeries returns
+//
+//	Σ (-1)^k z^n/(n!·(a·n+b)), with n = n0+2k
+//
+// for k >= 0, computed to prec bits of precision, and the binary
+// exponent of its largest term. z must be positive, and a·n+b must
+// not be zero.
+func trigIntSeries(z *big.Float, n0, a, b int64, prec uint) (*big.Float, int) {
+
+	// t = z^n/n!
+	t := big.NewFloat(1).SetPrec(prec)
+	d := new(big.Float)
+	for n := int64(1); n <= n0; n++ {
+		t.Mul(t, z)
+		t.Quo(t, d.SetInt64(n))
+	}
+
+	s := new(big.Float).SetPrec(prec).Quo(t, d.SetInt64(a*n0+b))
+	u := new(big.Float).SetPrec(prec)
+	scale := s.MantExp(nil)
+	for n := n0 + 2; ; n += 2 {
+		t.Mul(t, z)
+		t.Quo(t, d.SetInt64(-(n-1)*n))
+		t.Mul(t, z)
+		u.Quo(t, d.SetInt64(a*n+b))
+		s.Add(s, u)
+		if e := u.MantExp(nil); e > scale {
+			scale = e
+		}
+		if d.SetInt64(n).Cmp(z) > 0 && u.MantExp(nil)-s.MantExp(nil) < -int(prec) {
+			return s, scale
+		}
+	}
+}
+
+// trigIntAux returns the asymptotic sum
+//
+//	Σ (-1)^k Π p_j(p_j+d)/w, with p_j = m·j-c
+//
+// for k >= 0 and j = 1, ..., k, computed to prec bits of precision.
+// The sum is stopped at its smallest term, which must be less than
+// 2**(-prec).
+func trigIntAux(w *big.Float, m, c, d int64, prec uint) *big.Float {
+	s := big.NewFloat(1).SetPrec(prec)
+	t := big.NewFloat(1).SetPrec(prec)
+	u := new(big.Float)
+	for j := int64(1); ; j++ {
+		p := m*j - c
+		t.Mul(t, u.SetInt64(-p*(p+d)))
+		t.Quo(t, w)
+		s.Add(s, t)
+		if t.MantExp(nil)-s.MantExp(nil) < -int(prec) {
+			return s
+		}
+	}
+}
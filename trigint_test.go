@@ -0,0 +1,244 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestSinc(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"1", "8.41470984807896506652502321630298999622563060798371065672751709991910404391239668948639743543052695854349037907920674293259118920991898881193410327729212409480791955826766606999907764011978408782732566347484802870298656157017962455394893572924670127086486281053382030561377218203868449667761674266239013382753397956764255565477963989764824328690275696429120630e-1"},
+		{"3.140625", "3.08108557622218337112928164833302323092077923957076832466203919597155123848315214136443943111550226369469208728346816991794319190568739184285150448820196897310789731874151207735781341352378968516464833207887710067117104034487882211590191184384738225582732884270594640351491938440528039945857459675611060851123874213746189920619738853184891776831806346954590542e-4"},
+		{"-100", "-5.06365641109758793656557610459785432065032721290657323443392473594357913419476696499236664512927392207244089392563840417341952587121858032142916007452053022165955928600662459809772287409637454010965819778579488483710856358024448788786583750612666237709063680584167511754581933305057190532871994394386016992471626028147500411925768810954366624877370163790255764e-3"},
+		{"1p-60", "9.99999999999999999999999999999999999874613935912289332483334769362960461037392173464532910397144150960972883877183436564705242594377650538582531761207541235377986753312030020309536771658557046032513241646490155728366884429223983729512376720126380555433957611430888201140590209299934291840894840455294125586054969940307125665974647111458428596370065767427717486e-1"},
+		{"355", "-8.49136714351787301812120563623946467331149494826046453684221077202071503252752474234091174648846152903864492603947288191000476040343180026809294149475085056738281939349508551420447868421045584832740095519443791388702047146752423199461892864562543823535703982892241924332538925366245968352847715811485168673407657141111647450403962303194615092431974822025062422e-8"},
+		{"1e10", "-4.87506025087510691527794294348106041676447316922786885745254537845158563447074794434213180143195804456735820740449580278488866903301853974537441134722275714682797681275061137565478903151147659335831819852572872383648697542598390246824950513958995553200289205824151154252139571605545130477132669027620085789524410906546687323748375794602992907926972527639643307e-11"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.Sinc(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Sinc(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestSi(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0.5", "4.93107418043066689161626707572764653641337138428721131660242614030237476932756287871640249351268707367916447243602157459775821050927535406620893761801849223588680573415026755675683626256532575218216339763118666348413893547534446520495125183196581455737754137479446632562997558068909907693488060346907006704569445041341217084214907850511936314782421655782530653e-1"},
+		{"1", "9.46083070367183014941353313823179657812337954738111790471454773566687036540797918088702133081740711215023985398458909963018871921565883288920609191883064647706426075351277728973725739371280321100977843163004432660852088846915065950322889298359607907415788585519033719274006056831946135887741026778468398492553582181422722945542258014806148746234447697031615948e-1"},
+		{"-2.5", "-1.77852017344382664210031198173622947870957384947778198988060328309682347065420393354980914099368115777262808567338644939486595743138614053931675038543769303330175155862731847796911023269417648408829513318151037035434608850392530243402384468629114141359287859456105663911760805054830619714311359381156660193574348946854735334314554826055642832948947990281622999e+0"},
+		{"3.375", "1.84371041379370367055041378730244531436013468740835223496256331645475393312083110459909609956382125280263940063169345410556912501784991928089699635681772227539154227559053145532109575345909855113343309022853928624648278798853592594709304557251291997030759204769301286873861603994096037813068433317027165911726627933438481769438327557026390943813908523241198444e+0"},
+		{"0.0009765625", "9.76562448259858446104091289949691989577883714860393689900534534233948266698531998368923821043976942734473202640732904336198277822912231331373179111578890823533894169923261990522159342286065062755128556932188002169541197375005363111410601656710945082311145835361300754343090568982222344262720463090920892051115116883344359048758543814396946152573402374948280501e-4"},
+		{"1p-100", "7.88860905221011805411728565282786229673206435109023004770278903391321026123741371690155805631801983471024930558613527158060969227698057035692250551577290951105785582770253359266879418046610464707753526747927271955841501615844452129465658023529016767508116708186158107533537439417134790827456824425725240622209259109051745990855608972292964890614742061316382858e-31"},
+		{"10", "1.65834759421887404933097187938967248063025434830957984219572269466095956578457190664731143515493061852449771662925468431940519538538564643405590007292953947178623368876889449137627945188365310349986336380716292745674021122278914494815508931402307085094833077673749003536722562320223828437305644610672271632743728784829525730962131719603276311034961023376807302e+0"},
+		{"40.5", "1.59383725732815979194567063381114158051606193288760471942647127537979048914623159333628484942966682376357521608076213195903332834568738407460232335624115183940299476644605442474838921990630846250528947213496377733110332378519787438589819584268356408363538619665502216973229944694101182223402950475050010461747256848339089484847266544135128656526752012991581600e+0"},
+		{"100", "1.56222546688905629335234513880450267722782498054108345638431169270916087090904049018240735245593475990479871218471371552692633008032293523867820356008823140266150940151260960757496624702216804108066989963677524930457878967572380253079320364601144000671970894761187768872834791324002642369095596253402951827839849001860818242098166401586495441563885366833904687e+0"},
+		{"700.25", "1.57214889944023174845517425871735147246881586970374622148641613844232822344821542828624312221460753254633370717744762040057338360781561224187467494154055454435142950965156018148206055010067770192712670878246026449897033510857790904243295720047008078436991821809135360170334704531424679175108649789555763372133445645752831226928948777423769306049062059486060107e+0"},
+		{"1000", "1.57023312196877121814796277803633444100178687988931412260451765276196468317697826995763236814499779661074143513575234100936853465123283081842482085511649589296435952454429631284208904331225230276490444092298983414529075004164688391648084521647704921436020163554644691082227920998927979345147725297951178783023376601250189694065928015646599425900699737292029655e+0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.Si(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Si(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestCi(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0.5", "-1.77784078806612901335810271070569078090519474812621968666825357595127265015902096770987644602721732358170016305100920915937526552306305694501037817829132391988430019252120566237803894403205055752645589172589031190438654178822859070070880520685652156117450260531267658715707295945724421257713964082406969523630792688462017119227458129306826541135902436861080921e-1"},
+		{"1", "3.37403922900968134662646203889150769997578032585731894801318542436130330025056052896848183097322994604948052677868873374428691473326665751587262822008641593735271629602356939680198450026475325099771708634303239278976289331209365712737983956730681506079666746941288395604487156385385355147333948210088629429523692562040911936678417539531125124212652422160465704e-1"},
+		{"3.375", "2.63985365536096270970639795744395353481352160201955031325035414985615108720837866034345956111352048079316180020452079333589227617504240457750694825060579145168484065790179941893935844335057315455564729679312980157737153170069547729035917446574213934048953341388354842712095281369624336057106447689652509732996407190367388882517218376589282786942080034977217396e-3"},
+		{"0.6165008544921875", "-6.12902301107630965753697789121138420698128122680126653848784261223599176329017952211365293288250449724582127435721059760182435035071531706085864331753618043089229687243911624747061009414049949542748345625683981105072270925617604861601029386375548706364965108205524054652930316467808939266799579536303193524300745241206001980616425126101324310205387715319543077e-6"},
+		{"0.0009765625", "-6.35425637911648986122536643467441045228414795921328900172234931747590183551982268107404840049131518979939241277952036857196532041144661107329423957767597359410959533782404130819307265695434004590337094009382417012521813896690879403379531286078073060959213030917223566026768501739892180476842513075517488638175115072613216047805102071593022253240998216996909451e+0"},
+		{"1p-100", "-6.87375023910929980811167000557352543765078541000856018132622338700298764167203495682509869808532280194418208728364398184155028553538860623784280786492402441831517950282672275688701999511562103111495149971323888817185714525800105234271750337088440112741318348880358891034190754199886882356307644460240254661761095845152366426118702648660746314635279899405027283e+1"},
+		{"10", "-4.54564330044553726345328299526278528876469579573168869305669878878647180495014889375503716650184337225932728983357893583192439694102248458671562723364998092499797995962101720977725983977904750068973315088919472348882995906812849034584578695253611811635971668941648767006220960505463855945257517806560378993167330556169417739413277867387296937659585974821861503e-2"},
+		{"40.5", "8.81320087631854092025612388757903510938172629775941540327194655476128939185925753682807356227912080385215487715502378263935529316603945360827143547632871159576382697451218471523792962224315751601396950590541658773389436311530974012614959876745819923275676063001098282558037491216209379939197911316922246253412633985014245423599982064540046436468812645757162167e-3"},
+		{"100", "-5.14882514261049214444355390534449785032633791932324580364417667955134279567644125286363711086409769625478173703153237657740109417493068958925218716790237285103378087013103299044658168745224201339728161955739193636329699991445685057170491955710206853338583488474621024278809885504425865552035589190044383570074182951599871254567599303910353112816624764429146366e-3"},
+		{"700.25", "4.58142056142329723468340109708465890969991940119692338901269932623914841336959338524501643044522631882376829292711976776955784329107215407904196209677098429172512529902511100398861434567930666257516353316950033164432770808957676395133468890868204752554655947522350332632278449176092640305553182484315460603002285279437993345188631467510011305818103037883071646e-4"},
+		{"1000", "8.26315511090682282001773882343207231780126228026933056700919647395105986476988175686097487360266922068444338399532314059380094451447098985199273278318576974858269778407358541834491230872273694444947924623665329769504527539034583294093441869485337197535409634628066016340183814396156158339294450357894364176711760696307798968349356101612122041511988227582797041e-4"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.Ci(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Ci(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestFresnelS(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0.5", "6.47324328599992776114805122306147676507259184935124927875889456482868976739219758994393412221420505808784636356069473991089748523661893573161084246919045399533422000694682014912949113276524908162760248862086519630324538773988057086409371011013207968172613229053224525339845587673687939928285097920116780147708585233455481581170961947753462119532409395728867266e-2"},
+		{"1", "4.38259147390354766076756696625152637493786572452416567334407326265805938211299877173460674141154845678783695214703314253063544410052059564474967388674221721132752178050536115860073458669634860369547775901819309057336048848708395296327963603589220701167154509789007432628836213497149872545559447928267215438491789011840522519154983768287067149863608791685916610e-1"},
+		{"1.5", "6.97504960082093013080655163187268332944769121379286600133600724438717290334584763690942384006950272625014349958919191885370165981328103612175605239894074762622738150653189724529013682049245593990573254456707981759747961577805584174358894451027765067801789926777965890494638744359611319106928197461101227468728272593354393542934341686523186368879018959795151667e-1"},
+		{"-2.25", "-5.05302226823693703559274028837347083523672907831843648115893635101596181658933618999728906893795394122161068826831752823671487806623214355553880787497064044589228614610793699880174268623322764090419828090088160663286323990500789068314181308797746678223063258168373397068387071344728433498137675145205770695770479622550967264914982780105148701108396068142594260e-1"},
+		{"5", "4.99191381917116886751928380465991655408431997072388153410141115175736820642972619220653604227057948937705467598007169570087158336910573666323264734782514638379131960668028066464314573307076187390592168140424189579704064388256112572615938066580713803251781139703647760816224457004111098235195903552019646893020369178107427236782655437265856785575827728138342280e-1"},
+		{"10.125", "5.21747845081085905878765724202608897854068826527767539135074805691369252987784653789075094396994065284141928789332660230358266356084276299429195642474962296082870488389802007987016225315177724896138461491324025685348204224141772211566021404862707342130238458643475640785819489770671348830367276761913564060545080542104585871709049954637014467196749314974506852e-1"},
+		{"30", "4.89389674442193796786325293999028836987952250499710746837597007669830152821274352986726366349202853395958528700950198301248206204847050961518389459577997077101926460306391895521233167493392959891127461866146629908407089029611346467909829493300009721389383579156233001917897375880445378456426617779718110074345060095450671496137843184574954295046320595812769576e-1"},
+		{"1p-50", "3.66859080084125356242485965729978279327446714864434843571336016257137287351348054783025030551566715860451565531357658609431340798168894330796582358285786193117147672490450366896413603267603831578535816501722728308417354055810452732252299871929948719661246465218338322352030760917366143343770608174499640058166296347312235823892981279553989591882393098271779161e-46"},
+		{"100.5", "4.97073792767426096961233044350200075822190759474547113143327663466650018022187858924559114295612188673350477898632239806771348771900406279396052476622676749525090094279203026973386947927292783875202910015610119411474191792066652851919715333847728873345848303185777626620586697357775706360903070278092153394310489910273707293412506667296264345954318111756830533e-1"},
+		{"1p40", "4.99999999999710498844993898572704194951488690345223477114376831472955664389588470453414485209538591099082786673819479334740530278896300855005606481460790988812556554608783258258090137102883702751311533310380767589544258426322850201433391469163880931023985343520922237267192594524885450742561190544900312544133122775377676786859951223578357513136857519995064003e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.FresnelS(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, FresnelS(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestFresnelC(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want string
+	}{
+		{"0.5", "4.92344225871446392878843665156681637766095145771501253294652619319107679598394581416502704714809305523919926134435979204373529387575921176716028096811582437894112784396649101841368229563025800431569978192183770497748157676568763398310786058112884627786993238836435558214426414469328781203514993182259388553438247071141999163619314746524346376597707398088088323e-1"},
+		{"1", "7.79893400376822829474206413652690136630625708136320960103133583178071760979108890108778707305278528158989027816291660638017082700749492157265735523786366463436393087606183392027542160688689218690116585020844615243349360379001660082377863259342362802406140345440664077278814151833226045882348638232440897863186419605438463971284764434837208011592689217692820395e-1"},
+		{"1.5", "4.45261176039821535064551009742089782159402057756099520134174032225144940017185485820392882002265659820519515166300432713480066408614751583398259939350955990804850405166908613385649677838474980190959350377199601511777235163453953163180641634971449269520907524030963653105405650531753419189166509664334518915642805262729981540069348214022223164403387547924040546e-1"},
+		{"-2.25", "-6.40124209946554640523614904698197106517528513310889243193903291342469439915635549779437277981590882889805677618889592329445316695429808165046751411235960749686123406356767813537283395833921533248387745553876438215169994771763987957306635368556487344514172874388221719071337549674480612558718032713800968386225202835868657229110475445829954004614484592112373238e-1"},
+		{"5", "5.63631188704012231102107404413013964120753762309992107861659341249885993533164618212608601570803980430361446842209874663295159910128860512810204981646987972285433568173568698011636667677580257866952862196158201793492828505190814556198361549608945193457829032395149804807085115972601837491206106571211422417555803962896574287609026569881896881476222389280611726e-1"},
+		{"10.125", "4.77299068339853329971988734527531531782669026176952441099063348666996920350085007106388080503101355754126621040296095228590969775897094675596855182148781796424614405845601793626023903273251374130559963151987897665847100178625414248265251907639203861055773078440100795219438156655343077549725111597272021961700859879313502797603495396161687131926405858042647796e-1"},
+		{"30", "4.99996247370609886910168399587938777474001702613912617352181818043493089394812613036910799122539638162254061925733676965160909785196718545575225353241948410811620095631829457575296261016452946987830847868767885415880028543169879743701808827003357054367313562701107511352394981394024264018863711167748771648924450964478960748373132488379857207622999863024025817e-1"},
+		{"1p-50", "8.88178419700125232338905334472656249999999999999999999999999863623092512144547616849542345166140113181015730998326288111951799012399935939932129048315484291706260939140766744953980867453926051877422814183112327034712148312121521778985376304865944280130505578377331863700972518170349275941688156228053860121071626492116371468645694513482167326761677796412883994e-16"},
+		{"100.5", "5.01211966681513623183407247169089826504397667648192365937281926136498142540740773074365184073188635919602138632985361904337186222731170102308573196482741229378998863885617940800468586225769386760630448583574743823427175403179552481921995138767841745040849721611222494684289257081934785527162470323797297723847454845614358055814929309313894417927664057197745587e-1"},
+		{"1p40", "4.99999999999999999999999999999999999923774413446274806575633734780461649144984821455696289169300138096542691111420501255308360604669455436002638678724104952291209402310250326943628233704002951883729713980842394317916040386021357435412725555679047360216165439795986568264422103404011575266084726179192849035335699731232195433068737860244635976331859564077162160e-1"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			x := new(big.Float).SetPrec(prec)
+			x.Parse(test.x, 10)
+
+			r := bigfloat.FresnelC(x)
+
+			if r.Cmp(want) != 0 {
+				t.Errorf("prec = %d, FresnelC(%v) =\ngot  %g;\nwant %g", prec, test.x, r, want)
+			}
+		}
+	}
+}
+
+func TestTrigIntFloat64(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(*big.Float) *big.Float
+		x    float64
+		want float64
+	}{
+		{"Si", bigfloat.Si, 2, 1.6054129768026948},
+		{"Si", bigfloat.Si, 25, 1.5314825509999613},
+		{"Ci", bigfloat.Ci, 2, 0.42298082877486500},
+		{"Ci", bigfloat.Ci, 25, -0.006848597179702591},
+		{"FresnelS", bigfloat.FresnelS, 0.75, 0.20887711123338357},
+		{"FresnelS", bigfloat.FresnelS, 3, 0.4963129989673750},
+		{"FresnelC", bigfloat.FresnelC, 0.75, 0.69352599078713590},
+		{"FresnelC", bigfloat.FresnelC, 3, 0.60572078929768563},
+	} {
+		x, _ := test.f(big.NewFloat(test.x)).Float64()
+		if math.Abs(x-test.want) > 1e-15*math.Abs(test.want) {
+			t.Errorf("%s(%g) = %g; want %g", test.name, test.x, x, test.want)
+		}
+	}
+}
+
+func TestTrigIntSpecialValues(t *testing.T) {
+	zero, negZero := big.NewFloat(0), big.NewFloat(math.Copysign(0, -1))
+	inf, negInf := big.NewFloat(math.Inf(+1)), big.NewFloat(math.Inf(-1))
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"Sinc(+0)", bigfloat.Sinc(zero), 1},
+		{"Sinc(-Inf)", bigfloat.Sinc(negInf), 0},
+		{"Si(-0)", bigfloat.Si(negZero), math.Copysign(0, -1)},
+		{"Si(+Inf)", bigfloat.Si(inf), math.Pi / 2},
+		{"Si(-Inf)", bigfloat.Si(negInf), -math.Pi / 2},
+		{"Ci(+0)", bigfloat.Ci(zero), math.Inf(-1)},
+		{"Ci(+Inf)", bigfloat.Ci(inf), 0},
+		{"FresnelS(-0)", bigfloat.FresnelS(negZero), math.Copysign(0, -1)},
+		{"FresnelS(+Inf)", bigfloat.FresnelS(inf), 0.5},
+		{"FresnelC(+0)", bigfloat.FresnelC(zero), 0},
+		{"FresnelC(-Inf)", bigfloat.FresnelC(negInf), -0.5},
+	} {
+		x, _ := test.got.Float64()
+		if x != test.want || math.Signbit(x) != math.Signbit(test.want) {
+			t.Errorf("%s = %g; want %g", test.name, x, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSi(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		x := big.NewFloat(10.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Si(x)
+			}
+		})
+	}
+}
+
+func BenchmarkFresnelC(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		x := big.NewFloat(3.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.FresnelC(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Integer values of s up to maxExactZeta, in absolute value, for
+// which Zeta, HurwitzZeta and Eta use the closed forms in terms of
+// Bernoulli numbers.
+const maxExactZeta = 1 << 10
+
+// Zeta returns a big.Float representation of the Riemann zeta
+// function ζ(s). Precision is the same as the one of the argument.
+// The function returns 1 when s = +Inf, and panics if s = 1 or
+// s = -Inf.
+//
+// For even integers s = 2n and non-positive integers s = -n with
+// |s| <= 1024, ζ(s) is computed from the closed forms
+// ζ(2n) = (-1)^(n+1) B_2n (2π)^2n / (2(2n)!) and
+// ζ(-n) = (-1)^n B_(n+1) / (n+1).
+func Zeta(s *big.Float) *big.Float {
+
+	prec := s.Prec()
+
+	switch {
+	// Zeta(+Inf) = 1
+	case s.IsInf() && s.Sign() > 0:
+		return big.NewFloat(1).SetPrec(prec)
+
+	case s.IsInf() || s.Cmp(big.NewFloat(1)) == 0:
+		panic("Zeta: argument is 1 or -Inf")
+	}
+
+	return zeta(s, prec+64).SetPrec(prec)
+}
+
+// HurwitzZeta returns a big.Float representation of the Hurwitz
+// zeta function ζ(s, a) = Σ 1/(a+k)^s, for k >= 0. Precision is the
+// same as the one of the first argument. The function panics if
+// s = 1 or s = -Inf, or if a is not positive and finite.
+func HurwitzZeta(s, a *big.Float) *big.Float {
+
+	prec := s.Prec()
+
+	one := big.NewFloat(1)
+	switch {
+	case s.IsInf() && s.Sign() < 0 || s.Cmp(one) == 0:
+		panic("HurwitzZeta: first argument is 1 or -Inf")
+
+	case a.Sign() <= 0 || a.IsInf():
+		panic("HurwitzZeta: second argument is not positive and finite")
+
+	// HurwitzZeta(+Inf, a) is +Inf, 1 or 0 when a < 1, a = 1, a > 1
+	case s.IsInf():
+		switch a.Cmp(one) {
+		case -1:
+			return new(big.Float).SetPrec(prec).SetInf(false)
+		case 0:
+			return big.NewFloat(1).SetPrec(prec)
+		default:
+			return new(big.Float).SetPrec(prec)
+		}
+
+	// HurwitzZeta(s, 1) = Zeta(s)
+	case a.Cmp(one) == 0:
+		return zeta(s, prec+64).SetPrec(prec)
+	}
+
+	// ζ(-n, a) = -B_(n+1)(a) / (n+1), where B_(n+1)(a) is a Bernoulli
+	// polynomial.
+	if n, ok := exactZetaArg(s); ok && n <= 0 {
+		x, _ := a.Rat(nil)
+		x = bernoulliPoly(int(1-n), x)
+		x.Quo(x, big.NewRat(n-1, 1))
+		return new(big.Float).SetPrec(prec).SetRat(x)
+	}
+
+	return hurwitzZeta(s, a, prec+64).SetPrec(prec)
+}
+
+// Eta returns a big.Float representation of the Dirichlet eta
+// function η(s) = Σ (-1)^(k-1)/k^s = (1 - 2^(1-s))ζ(s), for k >= 1.
+// Precision is the same as the one of the argument. The function
+// returns 1 when s = +Inf, and panics if s = -Inf.
+func Eta(s *big.Float) *big.Float {
+
+	prec := s.Prec()
+
+	one := big.NewFloat(1)
+	switch {
+	// Eta(+Inf) = 1
+	case s.IsInf() && s.Sign() > 0:
+		return big.NewFloat(1).SetPrec(prec)
+
+	case s.IsInf():
+		panic("Eta: argument is -Inf")
+
+	// Eta(1) = log(2)
+	case s.Cmp(one) == 0:
+		return Log(big.NewFloat(2).SetPrec(prec))
+	}
+
+	// t = 1 - 2^(1-s)
+	//
+	// Close to s = 1, t is about (s-1)·log(2), and the subtraction
+	// needs -log2|s-1| extra bits. For integer s, 2^(1-s) is exact.
+	w := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(one, s)
+	var t *big.Float
+	if s.IsInt() && new(big.Float).Abs(s).Cmp(big.NewFloat(math.MaxInt32)) < 0 {
+		n, _ := w.Int64()
+		p := new(big.Float).SetMantExp(one, int(n))
+		t = new(big.Float).SetPrec(exactSumPrec(p, one)).Sub(one, p)
+	} else {
+		guard := uint(64)
+		for {
+			p := zetaPow(big.NewFloat(2), w, prec+guard)
+			t = new(big.Float).SetPrec(prec+guard).Sub(one, p)
+			lost := p.MantExp(nil) - t.MantExp(nil)
+			if lost <= int(guard-64) {
+				break
+			}
+			guard = 64 + uint(lost)
+		}
+	}
+
+	x := zeta(s, prec+64)
+	x.Mul(x, t)
+	return x.SetPrec(prec)
+}
+
+// exactZetaArg returns s as an int64, and true, if s is an integer
+// for which ζ(s) has a closed form that we use: a non-positive
+// integer or an even positive integer, not larger than maxExactZeta
+// in absolute value.
+func exactZetaArg(s *big.Float) (int64, bool) {
+	if !s.IsInt() || new(big.Float).Abs(s).Cmp(big.NewFloat(maxExactZeta)) > 0 {
+		return 0, false
+	}
+	n, _ := s.Int64()
+	return n, n <= 0 || n%2 == 0
+}
+
+// bernoulliPoly returns the value at x of the Bernoulli polynomial
+// B_n(x) = Σ binomial(n, k) B_k x^(n-k), for k = 0, ..., n.
+func bernoulliPoly(n int, x *big.Rat) *big.Rat {
+	// Horner's method, in x
+	r := new(big.Rat)
+	c := new(big.Rat)
+	b := new(big.Int)
+	for k := 0; k <= n; k++ {
+		r.Mul(r, x)
+		c.SetInt(b.Binomial(int64(n), int64(k)))
+		r.Add(r, c.Mul(c, bernoulli(k)))
+	}
+	return r
+}
+
+// zeta returns ζ(s) computed to prec bits of precision. s must be
+// finite and different from 1.
+func zeta(s *big.Float, prec uint) *big.Float {
+
+	if n, ok := exactZetaArg(s); ok {
+		// ζ(-n) = (-1)^n B_(n+1) / (n+1)
+		if n <= 0 {
+			x := new(big.Rat).Quo(bernoulli(int(1-n)), big.NewRat(1-n, 1))
+			if n%2 != 0 {
+				x.Neg(x)
+			}
+			return new(big.Float).SetPrec(prec).SetRat(x)
+		}
+
+		// ζ(2n) = (-1)^(n+1) B_2n (2π)^2n / (2(2n)!)
+		//
+		// The relative error on 2π is amplified 2n times by the
+		// power, so we need as many extra bits as the exponent of 2n.
+		wprec := prec + uint(bits.Len64(uint64(n)))
+		x := new(big.Rat).Abs(bernoulli(int(n)))
+		x.Quo(x, new(big.Rat).SetInt(new(big.Int).MulRange(1, n)))
+		r := new(big.Float).SetPrec(wprec).SetRat(x)
+		r.Mul(r, powInt(new(big.Float).SetMantExp(pi(wprec), 1), int(n)))
+		r.SetMantExp(r, -1)
+		return r.SetPrec(prec)
+	}
+
+	if s.Sign() >= 0 {
+		return hurwitzZeta(s, big.NewFloat(1), prec)
+	}
+
+	// For s < 0 we use the functional equation
+	//     ζ(s) = (2π)^s / π · sin(πs/2) · Γ(1-s) · ζ(1-s)
+	// The relative error on 2π is amplified |s| times by the power,
+	// so we need as many extra bits as the exponent of s.
+	wprec := prec + 64
+	if e := s.MantExp(nil); e > 0 {
+		wprec += uint(e)
+	}
+
+	one := big.NewFloat(1)
+	w := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(one, s)
+
+	twoPi := new(big.Float).SetMantExp(pi(wprec), 1)
+	x := zetaPow(twoPi, s, wprec)
+	x.Quo(x, pi(wprec))
+
+	h := new(big.Float).SetMantExp(s, -1)
+	sin, _ := sincosPi(new(big.Float).SetPrec(wprec).Set(h))
+	x.Mul(x, sin)
+	x.Mul(x, Gamma(new(big.Float).SetPrec(wprec).Set(w)))
+	x.Mul(x, hurwitzZeta(w, one, wprec))
+
+	return x.SetPrec(prec)
+}
+
+// hurwitzZeta returns ζ(s, a) computed to prec bits of precision. s
+// must be finite and different from 1, and a must be positive.
+func hurwitzZeta(s, a *big.Float, prec uint) *big.Float {
+
+	// For s < 1 the terms of the Euler-Maclaurin formula can be
+	// much larger than ζ(s, a), in particular close to its zeros.
+	guard := uint(64)
+	for {
+		x, scale := hurwitzZetaEM(s, a, prec+guard)
+		if lost := lostBits(x, scale, prec+guard); lost > int(guard-64) {
+			guard = 64 + uint(lost)
+			continue
+		}
+		return x.SetPrec(prec)
+	}
+}
+
+// zetaPow returns x**w computed to prec bits of precision, using
+// Pow with as many extra bits as the magnitude of w·log2(x), since
+// its absolute error becomes a relative error in the result.
+func zetaPow(x, w *big.Float, prec uint) *big.Float {
+	guard := w.MantExp(nil) + bits.Len(uint(absInt(x.MantExp(nil))))
+	if guard < 0 {
+		guard = 0
+	}
+	z := new(big.Float).SetPrec(prec + uint(guard)).Set(x)
+	return Pow(z, w).SetPrec(prec)
+}
+
+// absInt returns the absolute value of n.
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// hurwitzZetaEM returns ζ(s, a), for finite s != 1 and a > 0,
+// computed to prec bits of precision using the Euler-Maclaurin
+// formula, and the binary exponent of the largest quantity that was
+// involved in the computation.
+func hurwitzZetaEM(s, a *big.Float, prec uint) (*big.Float, int) {
+
+	// ζ(s, a) = Σ 1/(a+k)^s + x^(1-s)/(s-1) + 1/(2x^s)
+	//           + Σ B_2j/(2j)! · s(s+1)...(s+2j-2) / x^(s+2j-1)
+	// where x = a + N, for k = 0, ..., N-1 and j >= 1.
+	//
+	// The terms of the second sum decrease as (s+2j)²/(2πx)², so
+	// that x >= |s|/4 + 0.15prec makes it converge quickly enough.
+	sf, _ := s.Float64()
+	af, _ := a.Float64()
+	n := int(math.Min(math.Abs(sf)/4+0.15*float64(prec)+1-af, math.MaxInt32))
+	if n < 0 {
+		n = 0
+	}
+
+	one := big.NewFloat(1)
+	ns := new(big.Float).Neg(s)
+	s1 := new(big.Float).SetPrec(exactSumPrec(s, one)).Sub(s, one) // s-1
+
+	scale := math.MinInt32
+	update := func(t *big.Float) {
+		if t.Sign() != 0 && t.MantExp(nil) > scale {
+			scale = t.MantExp(nil)
+		}
+	}
+
+	// When a = 1, k^(-s) is completely multiplicative, and we only
+	// need to call Pow for prime k; pw[k] holds k^(-s), and it grows
+	// with k.
+	var pw []*big.Float
+	if a.Cmp(one) == 0 {
+		pw = make([]*big.Float, 1, 64)
+	}
+
+	x := new(big.Float).SetPrec(exactSumPrec(a, big.NewFloat(float64(n+1)))).Set(a)
+	r := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for k := 0; k < n; k++ {
+		if pw == nil {
+			t = zetaPow(x, ns, prec)
+		} else if m := k + 1; smallestFactor(m) < m {
+			p := smallestFactor(m)
+			t.Mul(pw[p], pw[m/p])
+			pw = append(pw, new(big.Float).Set(t))
+		} else {
+			t = zetaPow(x, ns, prec)
+			pw = append(pw, new(big.Float).Set(t))
+		}
+		update(t)
+		r.Add(r, t)
+
+		// For s > 1 the remainder of the sum is less than
+		// (a+k)^(-s)·(1 + (a+k)/(s-1)), so we can stop early if it
+		// is negligible or underflows, which happens after a few
+		// terms for large s, long before k reaches n.
+		if s1.Sign() > 0 {
+			t.Mul(t, x)
+			t.Quo(t, s1)
+			if t.Sign() == 0 || t.MantExp(nil)-r.MantExp(nil) < -int(prec)-2 {
+				return r, scale
+			}
+		}
+		x.Add(x, one)
+	}
+
+	// x^(1-s)/(s-1) + 1/(2x^s)
+	xs := zetaPow(x, ns, prec)
+	t = new(big.Float).SetPrec(prec).Mul(xs, x)
+	t.Quo(t, s1)
+	update(t)
+	r.Add(r, t)
+	t.SetMantExp(xs, -1)
+	r.Add(r, t)
+
+	// p = s(s+1)...(s+2j-2) / x^(s+2j-1)
+	p := new(big.Float).SetPrec(prec).Mul(s, xs)
+	p.Quo(p, x)
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	b := new(big.Float).SetPrec(prec)
+	f := big.NewInt(2) // (2j)!
+	for j := int64(1); ; j++ {
+		t.Mul(p, b.SetRat(bernoulli(int(2*j))))
+		t.Quo(t, b.SetInt(f))
+		update(t)
+		r.Add(r, t)
+		if t.Sign() == 0 || t.MantExp(nil)-r.MantExp(nil) < -int(prec) {
+			break
+		}
+
+		p.Mul(p, b.Add(s, b.SetInt64(2*j-1)))
+		p.Mul(p, b.Add(s, b.SetInt64(2*j)))
+		p.Quo(p, x2)
+		f.Mul(f, big.NewInt((2*j+1)*(2*j+2)))
+	}
+
+	update(r)
+	return r, scale
+}
+
+// smallestFactor returns the smallest prime factor of n > 1.
+func smallestFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
@@ -0,0 +1,164 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+func TestZeta(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want string
+	}{
+		{"2", "1.64493406684822643647241516664602518921894990120679843773555822937000747040320087383362890061975870530400431896233719067962872468700500778793510294633086627683173330936776260509525100687214005479681155879489036082327776191984075645587696323563670971009694890208593200805163647887833884604444518405982514525068338763142276587939295880632044721979084773409105902e+0"},
+		{"3", "1.20205690315959428539973816151144999076498629234049888179227155534183820578631309018645587360933525814619915779526071941849199599867328321377639683720790016145394178294936006671919157552224249424396156390966410329115909578096551465127991840510571525598801543710978110203982753256678760352233698494166181105701471577863949973752378527793703095602570185318279000e+0"},
+		{"0.5", "-1.46035450880958681288949915251529801246722933101258149054288608782553052947450062527641937546335681951449637467986952958389234371035889426181923283975376292518263335864916412789122939415410119791731044810824194092788169842885717682395579918451788361465548665937991689152316352160424275374940796571353042261006512411854164516150879658464017534002986166504612595e+0"},
+		{"1.5", "2.61237534868548834334856756792407163057080065240006340757332824881492776768827286099624386812631195238297635877214975569815763296843445913443832056180833600833933396280548054166294852684829798168645847550187899242552790919645625985746620957819178983247798052614814070472260846524069586856423142070771015331232214326868361884423393999751309041916120794133844316e+0"},
+		{"1.0009765625", "1.02457728676950459405786816242488877765015975562264671131603521907029812195813414448638009130128188569508559982365641039107608534858753729168603805230039397199728912395309726028433543257318186169358536051734486670114667390276020920305744175262252018451796011252200088481428166347210464902245987778613679252035490404388536031128024237780362033611097330207943245e+3"},
+		{"10", "1.00099457512781808533714595890031901700601953156447751725778899463629146515191295439704196861038565275400689206320530767736809020353629380731906959498428739536216033347223525967320521789323288320665440138759279913286048883976147693647789769806971192063361022944054388731501219022076400989382492087774683640358090211703211108053398818156708705589184686966698150e+0"},
+		{"100.5", "1.00000000000000000000000000000055780889549473580137352192348928571930087704736312407815643134499578691763546555129636277229091727742827049821816583979995683896563318341174247848445334250956398938651887787353462908621627686397093823562794802826206600272842729743055684963097795090617880096974680050713918068511173255295014170807141989747670072733937325981686259e+0"},
+		{"1000", "1.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000009332636185032188789900895447238171696170914463717080246217e+0"},
+		{"1p-100", "-5.00000000000000000000000000000724914683146306953595632628014568768721310306674924166820466294262121503558475577907054363437790411061405823846065988770236699959976749427133575252896022011262577919316064011550051772627129656078130609710210155682902036366475957075375865723463912259146877658568914456966345707608736127321788527512330560187844663063767890527530517e-1"},
+		{"0", "-5.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e-1"},
+		{"-1", "-8.33333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333e-2"},
+		{"-2", "0"},
+		{"-1.5", "-2.54852018898330359495429869107047454690249846009729968346454983492493771883392785970925189475243606083956786090522338379231706922081480226595534733619982128781578133045264443099251947318696331135537227085510583845600615587030880946621048463536127324091812777875174201487812815241237315033326217955600948371966181171396286542356550862593551370473669272009584393e-2"},
+		{"-25.75", "-6.06558923025115063199310580121795418023831214898278003425569896876333464528891025036089953225469281150954091189988484326614464280493993114078441446512912245784932312940112140999888678056154136236935454547589416715229356705159968648131967325804718324094634512743027910582148345273628288777080207599578410881335524500920477226411656461597891199952322096797944046e+4"},
+		{"-99", "2.83822495706937069592641563364817647382846809280128821282285317144648651110702813414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414341434143414e+76"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			s := new(big.Float).SetPrec(prec)
+			s.Parse(test.s, 10)
+
+			x := bigfloat.Zeta(s)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Zeta(%v) =\ngot  %g;\nwant %g", prec, test.s, x, want)
+			}
+		}
+	}
+}
+
+func TestHurwitzZeta(t *testing.T) {
+	for _, test := range []struct {
+		s, a string
+		want string
+	}{
+		{"2", "0.5", "4.93480220054467930941724549993807556765684970362039531320667468811002241120960262150088670185927611591201295688701157203888617406101502336380530883899259883049519992810328781528575302061642016439043467638467108246983328575952226936763088970691012913029084670625779602415490943663501653813333555217947543575205016289426829763817887641896134165937254320227317706e+0"},
+		{"3", "0.25", "6.46638699687684601666689835894219949436449047514190763843281416533778646794824725627874654936642734211236983798396367660929775056151916027193005055962465927747440376501397445078123926466560367018736024881344345105277472447917645045327563356100140131284345020636468240444421064937222392093947698021801868714437128835536241648499702452416028914524165699922647575e+1"},
+		{"0.5", "2.5", "-2.83560878672245145178138266334761737565141691405941882969945724182323985777627240502441130228087120539091243371678618411965428309909581983135427982738402874409526631473793677844852093642296737424628626769095252822117824063893220369350189621406607991549139146627406177104126021144583753954060048103598780981927033567699331265159807294441122184047023681570724302e+0"},
+		{"-1.5", "0.75", "2.09340249327131221293125748177088029182797023376830624718128079835580810777737842605065057935569612107663814570561746616385958228789224098602159409711634850193992297663509749220521488436001722274195370468311971881232574661455563952653839969535042669335587247633728867232991223945035904175815500564982848432832111920226842166092294082012494117960877688656950988e-2"},
+		{"-3", "0.5", "-7.29166666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666666667e-3"},
+		{"-2", "0.5", "0"},
+		{"10.5", "1000.5", "3.32869949158627331186963186748142888799647262660693582186981676045414569095615429737584945792504832653450897114327716579845593621377384633685380988329441181170319254312727389422934881280001360130800397434228113207326646101508808098871996689131611947842153159290461869500992862723715339172866233745672073358466183239316747582307824672150473490197376694922389316e-30"},
+		{"1.5", "0.0009765625", "3.27706104122920717274220829652952786236513116704707612369850984610529691995076117979189759836608195218245255932434221362854361935669615305452151364300890001180466567563882347581485178072546337843682292229380549753535330162856661455887222621184516631638659017703176137981805500944413357932064478078299711063857422341670106686659492925626374137135603800343775772e+4"},
+		{"-0.5", "3", "-2.62209978735044961481899544960674738079594040666462061078679329509687977039439937933793080126837478837812382184504098659058616297054519552460982712039404536247486268373451263413087827125681903084835839077106466303426150836716623362840624680225183513739836142903143428828591177522742976767090035129520152381152743681900268668730553165996176217876674090879908297e+0"},
+		{"-7", "1.25", "-7.71840413411458333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333333e-5"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			s := new(big.Float).SetPrec(prec)
+			s.Parse(test.s, 10)
+			a := new(big.Float).SetPrec(prec)
+			a.Parse(test.a, 10)
+
+			x := bigfloat.HurwitzZeta(s, a)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, HurwitzZeta(%v, %v) =\ngot  %g;\nwant %g", prec, test.s, test.a, x, want)
+			}
+		}
+	}
+}
+
+func TestEta(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want string
+	}{
+		{"1", "6.93147180559945309417232121458176568075500134360255254120680009493393621969694715605863326996418687542001481020570685733685520235758130557032670751635075961930727570828371435190307038623891673471123350115364497955239120475172681574932065155524734139525882950453007095326366642654104239157814952043740430385500801944170641671518644712839968171784546957026271631e-1"},
+		{"2", "8.22467033424113218236207583323012594609474950603399218867779114685003735201600436916814450309879352652002159481168595339814362343502503893967551473165433138415866654683881302547625503436070027398405779397445180411638880959920378227938481617818354855048474451042966004025818239439169423022222592029912572625341693815711382939696479403160223609895423867045529510e-1"},
+		{"0.5", "6.04898643421630370247265914235955499759762545130247380378546648082187253495060357327403956918349554383033720410335824053109825223299163504834897013205000061739072732967872328610177617498177335444554360688396992997330484986820140334170442482300328860932980895589809414236848688548007656479244699692052118202640213113853272731924759552458678872828636806442396641e-1"},
+		{"1.0009765625", "6.93303271365636936225330282346135681770956442429423126202924229808371218289673513851533150143083078675911955737944844481192430482319117041138901870654987452021211893241137674910193754353468604117176341722304514910746978727929462102041738921124408220623032624314441814666512927258439260175585134972196110747584714580975954464457581230705565952527685299672105030e-1"},
+		{"3", "9.01542677369695714049803621133587493073739719255374161344203666506378654339734817639841905207001443609649368346445539563868996999004962410332297627905925121090456337212020050039393681641681870682971172932248077468369321835724135988459938803829286441991011577832335826529870649425090702641752738706246358292761036833979624803142838958452773217019276389887092502e-1"},
+		{"20.25", "9.99999198275968495705885856476231971067827678679543928390981552535012511455765389038718154089766096094301418125961439757939357733681391970428400491411663068141011252090721206758984949661125239012824280800673271738990536135012952212171859427759613997571805592716995982068798417118339343715462974617592116209025069555548543919283638908848350956295649849840914438e-1"},
+		{"0", "5.00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e-1"},
+		{"-1", "2.50000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000e-1"},
+		{"-2.5", "-8.78411207213628423952324500515566489627349256629903497355378936380892140900983916414512915157949778840028436141248043795902283519227521678050850975411320324262132495986946080013285694283633568307156050196940945204834016364128843301995174469728340177820675763635853779505552456408378003818188519137546643045939142802403195213235471817553269268323379390354138911e-2"},
+		{"-10", "0"},
+	} {
+		for _, prec := range []uint{24, 53, 64, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000} {
+			want := new(big.Float).SetPrec(prec)
+			want.Parse(test.want, 10)
+
+			s := new(big.Float).SetPrec(prec)
+			s.Parse(test.s, 10)
+
+			x := bigfloat.Eta(s)
+
+			if x.Cmp(want) != 0 {
+				t.Errorf("prec = %d, Eta(%v) =\ngot  %g;\nwant %g", prec, test.s, x, want)
+			}
+		}
+	}
+}
+
+func TestZetaSpecialValues(t *testing.T) {
+	inf := big.NewFloat(math.Inf(+1))
+	for _, test := range []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{"Zeta(+Inf)", bigfloat.Zeta(inf), 1},
+		{"Eta(+Inf)", bigfloat.Eta(inf), 1},
+		{"HurwitzZeta(+Inf, 0.5)", bigfloat.HurwitzZeta(inf, big.NewFloat(0.5)), math.Inf(+1)},
+		{"HurwitzZeta(+Inf, 1)", bigfloat.HurwitzZeta(inf, big.NewFloat(1)), 1},
+		{"HurwitzZeta(+Inf, 2)", bigfloat.HurwitzZeta(inf, big.NewFloat(2)), 0},
+	} {
+		if x, acc := test.got.Float64(); x != test.want || acc != big.Exact {
+			t.Errorf("%s = %g (%v); want %g (Exact)", test.name, x, acc, test.want)
+		}
+	}
+}
+
+func TestZetaLargeS(t *testing.T) {
+	// for large s, ζ(s) = 1 + 2^(-s) + … rounds to 1
+	if z := bigfloat.Zeta(big.NewFloat(1e13 + 1).SetPrec(100)); z.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("Zeta(1e13+1) = %g; want 1", z)
+	}
+
+	// ζ(s, 1/2) = 2ˢ + (2/3)ˢ + … rounds to 2ˢ
+	h := bigfloat.HurwitzZeta(big.NewFloat(1e9+1).SetPrec(100), big.NewFloat(0.5))
+	if want := new(big.Float).SetMantExp(big.NewFloat(1), 1e9+1); h.Cmp(want) != 0 {
+		t.Errorf("HurwitzZeta(1e9+1, 0.5) = %v·2^%d; want 2^(1e9+1)", new(big.Float).SetMantExp(h, -h.MantExp(nil)), h.MantExp(nil))
+	}
+
+	// ζ(s, 13/4) = (4/13)ˢ + … underflows to 0
+	if h := bigfloat.HurwitzZeta(big.NewFloat(1e15).SetPrec(100), big.NewFloat(3.25)); h.Sign() != 0 {
+		t.Errorf("HurwitzZeta(1e15, 3.25) = %g; want 0", h)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkZeta(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		z := big.NewFloat(2.5).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Zeta(z)
+			}
+		})
+	}
+}